@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+)
+
+const accountsUsage = `usage: expense-tracker accounts [-add name [-opening balance]]`
+
+// runAccounts lists the accounts with their balances, or adds a new one.
+func runAccounts(args []string) error {
+	flags := flag.NewFlagSet("accounts", flag.ExitOnError)
+	add := flags.String("add", "", "name of a new account, e.g. \"Debit card\"")
+	opening := flags.Float64("opening", 0, "balance of the new account before its first expense")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), accountsUsage)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *add != "" {
+		account, err := expense.AddAccount(domain.Account{Name: *add, OpeningBalance: *opening})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Added account %s with an opening balance of %.2f\n", account.Name, account.OpeningBalance)
+		return nil
+	}
+
+	balances, err := expense.GetAccountBalances()
+	if err != nil {
+		return err
+	}
+
+	if len(balances) == 0 {
+		fmt.Println("No accounts yet, add one with: expense-tracker accounts -add Cash")
+		return nil
+	}
+
+	for _, b := range balances {
+		fmt.Printf("%-24s %12.2f\n", b.Account.Name, b.Balance)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/config"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/menu"
+	tea "github.com/charmbracelet/bubbletea"
+	"os"
+	"strings"
+)
+
+const addUsage = `usage: expense-tracker add [-y] "coffee 3.50 food yesterday"`
+
+// runAdd saves an expense described in a single line. The interpretation is shown for confirmation
+// first, and lines that cannot be understood with certainty are opened in the full form instead.
+func runAdd(args []string) error {
+	flags := flag.NewFlagSet("add", flag.ExitOnError)
+	yes := flags.Bool("y", false, "save without asking for confirmation")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), addUsage)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	line := strings.TrimSpace(strings.Join(flags.Args(), " "))
+	if line == "" {
+		return errors.New(addUsage)
+	}
+
+	entry, err := expense.ParseQuickAdd(line)
+	if err != nil {
+		return err
+	}
+
+	printEntry(entry)
+
+	if entry.IsAmbiguous() {
+		fmt.Printf("Not sure about it: %s, opening the full form\n", strings.Join(entry.Ambiguities, ", "))
+
+		m, err := menu.InitialDraftModel(entry.Draft())
+		if err != nil {
+			return err
+		}
+
+		_, err = tea.NewProgram(m, tea.WithAltScreen()).Run()
+		return err
+	}
+
+	if !*yes && !confirm("Save it? [Y/n] ") {
+		fmt.Println("Nothing saved")
+		return nil
+	}
+
+	added, err := expense.AddQuickEntry(entry)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Added expense %d (%s)\n", added.Id, added.Category)
+	return nil
+}
+
+func printEntry(entry expense.QuickEntry) {
+	e := entry.Expense()
+
+	amount := fmt.Sprintf("%.2f", e.Amount)
+	if entry.Currency != "" {
+		amount += " " + strings.ToUpper(entry.Currency)
+	}
+
+	category := e.Category
+	if entry.Category == "" {
+		category = "- (set by a matching rule)"
+	}
+
+	fmt.Printf("Description: %s\nCategory:    %s\nAmount:      %s\nDate:        %s\n",
+		e.Description, category, amount, e.SpentAt.Format(dateLayout()))
+}
+
+// dateLayout returns the layout of the preferred date format, ISO when the settings cannot be read.
+func dateLayout() string {
+	settings, err := config.Load()
+	if err != nil {
+		return expense.ISODateFormat.Layout()
+	}
+
+	return expense.DateFormat(settings.DateFormat).Layout()
+}
+
+func confirm(prompt string) bool {
+	fmt.Print(prompt)
+
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	return answer == "" || answer == "y" || answer == "yes"
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/config"
+	"strconv"
+	"time"
+)
+
+const restoreUsage = `usage: expense-tracker restore [-y] [backup name]`
+
+// runBackup takes a backup of every expense right away.
+func runBackup(args []string) error {
+	flags := flag.NewFlagSet("backup", flag.ExitOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	backup, err := expense.BackupExpenses()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Backed up %d expenses to %s\n", backup.Count, backup.Name)
+	return nil
+}
+
+// runRestore lists the backups, or replaces every expense with the ones of the named backup.
+func runRestore(args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ExitOnError)
+	yes := flags.Bool("y", false, "restore without asking for confirmation")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), restoreUsage)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() == 0 {
+		return listBackups()
+	}
+
+	name := flags.Arg(0)
+
+	if !*yes && !confirm(fmt.Sprintf("Replace every expense with the ones of %s? [Y/n] ", name)) {
+		fmt.Println("Nothing restored")
+		return nil
+	}
+
+	count, err := expense.RestoreBackup(name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Restored %d expenses from %s, the replaced ones were backed up first\n", count, name)
+	return nil
+}
+
+func listBackups() error {
+	backups, err := expense.GetBackups()
+	if err != nil {
+		return err
+	}
+
+	if len(backups) == 0 {
+		fmt.Println("No backups yet, one is taken before the first change of every day")
+		return nil
+	}
+
+	loc := time.Local
+	if settings, err := config.Load(); err == nil {
+		loc = settings.Location()
+	}
+
+	fmt.Printf("%-40s %-17s %10s\n", "Backup", "Taken", "Expenses")
+
+	for _, b := range backups {
+		count := strconv.Itoa(b.Count)
+		if b.Unreadable {
+			count = "unreadable"
+		}
+
+		fmt.Printf("%-40s %-17s %10s\n", b.Name, b.CreatedAt.In(loc).Format(dateLayout()+" 15:04"), count)
+	}
+
+	fmt.Println("\n" + restoreUsage)
+	return nil
+}
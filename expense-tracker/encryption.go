@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"golang.org/x/term"
+	"os"
+	"strings"
+)
+
+const encryptionUsage = `usage: expense-tracker [--passphrase-file file] encryption enable|disable|rotate [-new-passphrase-file file]`
+
+// runEncryption encrypts the expenses, accounts, rules and backups with a passphrase, writes them back in plaintext
+// or changes the passphrase.
+func runEncryption(args []string) error {
+	flags := flag.NewFlagSet("encryption", flag.ExitOnError)
+	newPassphraseFile := flags.String("new-passphrase-file", "", "file holding the new passphrase, asked for when missing")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), encryptionUsage)
+		flags.PrintDefaults()
+	}
+
+	if len(args) == 0 {
+		return errors.New(encryptionUsage)
+	}
+
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "enable":
+		passphrase, err := newPassphrase(*newPassphraseFile)
+		if err != nil {
+			return err
+		}
+
+		if err = expense.EnableEncryption(passphrase); err != nil {
+			return err
+		}
+
+		fmt.Println("The expenses, accounts, rules and backups are encrypted, keep the passphrase safe: they cannot be read without it")
+	case "disable":
+		if err := expense.DisableEncryption(); err != nil {
+			return err
+		}
+
+		fmt.Println("The expenses, accounts, rules and backups are kept in plaintext again")
+	case "rotate":
+		passphrase, err := newPassphrase(*newPassphraseFile)
+		if err != nil {
+			return err
+		}
+
+		if err = expense.RotatePassphrase(passphrase); err != nil {
+			return err
+		}
+
+		fmt.Println("The expenses, accounts, rules and backups are encrypted with the new passphrase")
+	default:
+		return errors.New(encryptionUsage)
+	}
+
+	return nil
+}
+
+// unlock gives the passphrase of encrypted expenses, read from passphraseFile or asked for in the terminal
+// when prompt is set. Plaintext expenses are left alone.
+func unlock(passphraseFile string, prompt bool) error {
+	locked, err := expense.IsLocked()
+	if err != nil || !locked {
+		return err
+	}
+
+	var passphrase string
+
+	switch {
+	case passphraseFile != "":
+		if passphrase, err = readPassphraseFile(passphraseFile); err != nil {
+			return err
+		}
+	case prompt:
+		if passphrase, err = askPassphrase("Passphrase: "); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	return expense.Unlock(passphrase)
+}
+
+// newPassphrase reads the new passphrase from file, or asks for it twice in the terminal.
+func newPassphrase(file string) (string, error) {
+	if file != "" {
+		return readPassphraseFile(file)
+	}
+
+	passphrase, err := askPassphrase("New passphrase: ")
+	if err != nil {
+		return "", err
+	}
+
+	repeated, err := askPassphrase("Repeat the new passphrase: ")
+	if err != nil {
+		return "", err
+	}
+
+	if passphrase != repeated {
+		return "", errors.New("the passphrases do not match")
+	}
+
+	return passphrase, nil
+}
+
+// readPassphraseFile reads a passphrase kept in a file, without the line break editors add at its end.
+func readPassphraseFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("Error reading passphrase file: %w", err)
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+func askPassphrase(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", errors.New("the expenses are encrypted, give the passphrase with --passphrase-file")
+	}
+
+	fmt.Print(prompt)
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Println()
+
+	return string(passphrase), err
+}
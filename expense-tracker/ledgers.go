@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+)
+
+const ledgersUsage = `usage: expense-tracker ledgers [-create name]`
+
+// runLedgers lists the ledgers, or adds a new one.
+func runLedgers(args []string) error {
+	flags := flag.NewFlagSet("ledgers", flag.ExitOnError)
+	create := flags.String("create", "", "name of a new ledger to add")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), ledgersUsage)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *create != "" {
+		if err := expense.CreateLedger(*create); err != nil {
+			return err
+		}
+
+		fmt.Printf("Created ledger %s, open it with: expense-tracker --ledger %q\n", *create, *create)
+		return nil
+	}
+
+	ledgers, err := expense.GetLedgers()
+	if err != nil {
+		return err
+	}
+
+	current := expense.CurrentLedger()
+
+	for _, name := range ledgers {
+		marker := " "
+		if name == current {
+			marker = "*"
+		}
+
+		fmt.Printf("%s %s\n", marker, name)
+	}
+
+	return nil
+}
@@ -1,12 +1,87 @@
 package main
 
 import (
+	"flag"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
 	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/menu"
 	tea "github.com/charmbracelet/bubbletea"
 	"log"
+	"os"
 )
 
 func main() {
+	flags := flag.NewFlagSet("expense-tracker", flag.ExitOnError)
+	passphraseFile := flags.String("passphrase-file", "", "file holding the passphrase of encrypted expenses, asked for when missing")
+	ledger := flags.String("ledger", expense.DefaultLedger, "ledger to keep the expenses in")
+
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		log.Fatal(err)
+	}
+
+	args := flags.Args()
+
+	if err := expense.SwitchLedger(*ledger); err != nil {
+		log.Fatalf("Error opening ledger: %v", err)
+	}
+
+	// the table asks for the passphrase itself, the commands ask for it in the terminal
+	if err := unlock(*passphraseFile, len(args) > 0); err != nil {
+		log.Fatalf("Error unlocking expenses: %v", err)
+	}
+
+	if len(args) > 0 {
+		switch args[0] {
+		case "add":
+			if err := runAdd(args[1:]); err != nil {
+				log.Fatalf("Error adding expense: %v", err)
+			}
+
+			return
+		case "report":
+			if err := runReport(args[1:]); err != nil {
+				log.Fatalf("Error building report: %v", err)
+			}
+
+			return
+		case "backup":
+			if err := runBackup(args[1:]); err != nil {
+				log.Fatalf("Error backing up expenses: %v", err)
+			}
+
+			return
+		case "restore":
+			if err := runRestore(args[1:]); err != nil {
+				log.Fatalf("Error restoring expenses: %v", err)
+			}
+
+			return
+		case "migrate":
+			if err := runMigrate(args[1:]); err != nil {
+				log.Fatalf("Error migrating expenses: %v", err)
+			}
+
+			return
+		case "ledgers":
+			if err := runLedgers(args[1:]); err != nil {
+				log.Fatalf("Error managing ledgers: %v", err)
+			}
+
+			return
+		case "accounts":
+			if err := runAccounts(args[1:]); err != nil {
+				log.Fatalf("Error managing accounts: %v", err)
+			}
+
+			return
+		case "encryption":
+			if err := runEncryption(args[1:]); err != nil {
+				log.Fatalf("Error changing encryption: %v", err)
+			}
+
+			return
+		}
+	}
+
 	m, err := menu.InitialModel()
 
 	if err != nil {
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/config"
+)
+
+const migrateUsage = `usage: expense-tracker migrate [-to sqlite|journal|json] [-force]`
+
+// runMigrate copies the expenses into another backend and switches the storage setting to it.
+func runMigrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	target := flags.String("to", config.SQLiteStorage, "storage to move the expenses to: sqlite, journal or json")
+	force := flags.Bool("force", false, "overwrite a database that already holds expenses")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), migrateUsage)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	count, err := expense.MigrateStorage(*target, *force)
+
+	var notEmpty *expense.StorageNotEmptyError
+	if errors.As(err, &notEmpty) {
+		return fmt.Errorf("%w, run with -force to overwrite them", err)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Migrated %d expenses to %s\n", count, *target)
+	return nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/config"
+	"time"
+)
+
+const reportUsage = `usage: expense-tracker report [-month 2026-10] [-depth 1] [-top 3]`
+
+// runReport prints the spending of a month per category next to the previous month and the same month
+// last year, with the biggest movers listed at the end.
+func runReport(args []string) error {
+	settings, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	flags := flag.NewFlagSet("report", flag.ExitOnError)
+	monthFlag := flags.String("month", time.Now().In(settings.Location()).Format("2006-01"), "month to report, as YYYY-MM")
+	depth := flags.Int("depth", 1, "category levels to show, 0 for all")
+	top := flags.Int("top", 3, "number of biggest movers to highlight")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), reportUsage)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *top < 0 {
+		return errors.New("top should not be negative")
+	}
+
+	month, err := time.Parse("2006-01", *monthFlag)
+	if err != nil {
+		return fmt.Errorf("month should look like YYYY-MM: %w", err)
+	}
+
+	report, err := expense.GetMonthlyComparison(month.Year(), month.Month(), *depth)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s compared with %s and %s\n\n", month.Format("January 2006"),
+		month.AddDate(0, -1, 0).Format("January 2006"), month.AddDate(-1, 0, 0).Format("January 2006"))
+
+	fmt.Printf("%-20s %10s %10s %20s %10s %20s\n", "Category", "This month", "Previous", "Change", "Last year", "Change")
+
+	for _, c := range append(report.Categories, report.Total) {
+		percent, ok := c.PreviousChangePercent()
+		previousChange := formatChange(c.PreviousChange(), percent, ok)
+
+		percent, ok = c.LastYearChangePercent()
+		lastYearChange := formatChange(c.LastYearChange(), percent, ok)
+
+		fmt.Printf("%-20s %10.2f %10.2f %20s %10.2f %20s\n", c.Name, c.Current, c.Previous, previousChange, c.LastYear, lastYearChange)
+	}
+
+	if movers := report.BiggestMovers(*top); len(movers) > 0 {
+		fmt.Println("\nBiggest movers:")
+
+		for _, c := range movers {
+			percent, ok := c.PreviousChangePercent()
+			fmt.Printf("  %s %s\n", c.Name, formatChange(c.PreviousChange(), percent, ok))
+		}
+	}
+
+	return nil
+}
+
+// formatChange shows an absolute change with its percentage, or "new" when there was nothing to compare with.
+func formatChange(change float64, percent float64, ok bool) string {
+	switch {
+	case change == 0:
+		return "0.00"
+	case !ok:
+		return fmt.Sprintf("%+.2f (new)", change)
+	}
+
+	return fmt.Sprintf("%+.2f (%+.0f%%)", change, percent)
+}
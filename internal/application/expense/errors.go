@@ -1,6 +1,9 @@
 package expense
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 type ExpenseNotFoundError struct {
 	ID int
@@ -19,3 +22,247 @@ func (e *ExpenseNotFoundError) Is(target error) bool {
 
 	return cErr.ID == e.ID
 }
+
+type CategoryNotFoundError struct {
+	Name string
+}
+
+func (e *CategoryNotFoundError) Error() string {
+	return fmt.Sprintf("Category %q not found", e.Name)
+}
+
+func (e *CategoryNotFoundError) Is(target error) bool {
+	cErr, ok := target.(*CategoryNotFoundError)
+
+	if !ok {
+		return false
+	}
+
+	return cErr.Name == e.Name
+}
+
+type CategoryExistsError struct {
+	Name string
+}
+
+func (e *CategoryExistsError) Error() string {
+	return fmt.Sprintf("Category %q already exists", e.Name)
+}
+
+func (e *CategoryExistsError) Is(target error) bool {
+	cErr, ok := target.(*CategoryExistsError)
+
+	if !ok {
+		return false
+	}
+
+	return cErr.Name == e.Name
+}
+
+type RuleNotFoundError struct {
+	ID int
+}
+
+func (e *RuleNotFoundError) Error() string {
+	return fmt.Sprintf("Rule with ID %d not found", e.ID)
+}
+
+func (e *RuleNotFoundError) Is(target error) bool {
+	cErr, ok := target.(*RuleNotFoundError)
+
+	if !ok {
+		return false
+	}
+
+	return cErr.ID == e.ID
+}
+
+type StorageNotEmptyError struct {
+	Count int
+}
+
+func (e *StorageNotEmptyError) Error() string {
+	return fmt.Sprintf("Target storage already holds %d expenses", e.Count)
+}
+
+func (e *StorageNotEmptyError) Is(target error) bool {
+	cErr, ok := target.(*StorageNotEmptyError)
+
+	if !ok {
+		return false
+	}
+
+	return cErr.Count == e.Count
+}
+
+var ErrSameStorage = errors.New("expenses are already kept in this storage")
+
+type UnknownStorageError struct {
+	Name string
+}
+
+func (e *UnknownStorageError) Error() string {
+	return fmt.Sprintf("Unknown storage %q", e.Name)
+}
+
+func (e *UnknownStorageError) Is(target error) bool {
+	cErr, ok := target.(*UnknownStorageError)
+
+	if !ok {
+		return false
+	}
+
+	return cErr.Name == e.Name
+}
+
+type BackupNotFoundError struct {
+	Name string
+}
+
+func (e *BackupNotFoundError) Error() string {
+	return fmt.Sprintf("Backup %q not found", e.Name)
+}
+
+func (e *BackupNotFoundError) Is(target error) bool {
+	cErr, ok := target.(*BackupNotFoundError)
+
+	if !ok {
+		return false
+	}
+
+	return cErr.Name == e.Name
+}
+
+var (
+	ErrWrongPassphrase  = errors.New("Wrong passphrase")
+	ErrEmptyPassphrase  = errors.New("The passphrase cannot be empty")
+	ErrNotEncrypted     = errors.New("The expenses are not encrypted")
+	ErrAlreadyEncrypted = errors.New("The expenses are already encrypted, rotate the passphrase to change it")
+)
+
+type EncryptionUnsupportedError struct {
+	Storage string
+}
+
+func (e *EncryptionUnsupportedError) Error() string {
+	return fmt.Sprintf("Encryption is only available for the json storage, not %s", e.Storage)
+}
+
+func (e *EncryptionUnsupportedError) Is(target error) bool {
+	cErr, ok := target.(*EncryptionUnsupportedError)
+
+	if !ok {
+		return false
+	}
+
+	return cErr.Storage == e.Storage
+}
+
+type InvalidLedgerNameError struct {
+	Name string
+}
+
+func (e *InvalidLedgerNameError) Error() string {
+	return fmt.Sprintf("Invalid ledger name %q, use up to 40 letters, digits, spaces, dashes and underscores", e.Name)
+}
+
+func (e *InvalidLedgerNameError) Is(target error) bool {
+	cErr, ok := target.(*InvalidLedgerNameError)
+
+	if !ok {
+		return false
+	}
+
+	return cErr.Name == e.Name
+}
+
+type LedgerNotFoundError struct {
+	Name string
+}
+
+func (e *LedgerNotFoundError) Error() string {
+	return fmt.Sprintf("Ledger %q not found", e.Name)
+}
+
+func (e *LedgerNotFoundError) Is(target error) bool {
+	cErr, ok := target.(*LedgerNotFoundError)
+
+	if !ok {
+		return false
+	}
+
+	return cErr.Name == e.Name
+}
+
+type LedgerExistsError struct {
+	Name string
+}
+
+func (e *LedgerExistsError) Error() string {
+	return fmt.Sprintf("Ledger %q already exists", e.Name)
+}
+
+func (e *LedgerExistsError) Is(target error) bool {
+	cErr, ok := target.(*LedgerExistsError)
+
+	if !ok {
+		return false
+	}
+
+	return cErr.Name == e.Name
+}
+
+type AccountNotFoundError struct {
+	Name string
+}
+
+func (e *AccountNotFoundError) Error() string {
+	return fmt.Sprintf("Account %q not found", e.Name)
+}
+
+func (e *AccountNotFoundError) Is(target error) bool {
+	cErr, ok := target.(*AccountNotFoundError)
+
+	if !ok {
+		return false
+	}
+
+	return cErr.Name == e.Name
+}
+
+type AccountExistsError struct {
+	Name string
+}
+
+func (e *AccountExistsError) Error() string {
+	return fmt.Sprintf("Account %q already exists", e.Name)
+}
+
+func (e *AccountExistsError) Is(target error) bool {
+	cErr, ok := target.(*AccountExistsError)
+
+	if !ok {
+		return false
+	}
+
+	return cErr.Name == e.Name
+}
+
+type AccountInUseError struct {
+	Name  string
+	Count int
+}
+
+func (e *AccountInUseError) Error() string {
+	return fmt.Sprintf("Account %q is used by %d expenses", e.Name, e.Count)
+}
+
+func (e *AccountInUseError) Is(target error) bool {
+	cErr, ok := target.(*AccountInUseError)
+
+	if !ok {
+		return false
+	}
+
+	return cErr.Name == e.Name && cErr.Count == e.Count
+}
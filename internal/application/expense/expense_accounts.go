@@ -0,0 +1,362 @@
+package expense
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"slices"
+	"time"
+)
+
+var (
+	ErrEmptyAccountName = errors.New("account name cannot be empty")
+	ErrSameAccount      = errors.New("a transfer needs two different accounts")
+	ErrInvalidAmount    = errors.New("amount should be a positive number")
+	ErrNotTransfer      = errors.New("expense is not a transfer")
+)
+
+// AccountBalance is what an account holds after every expense and transfer recorded against it.
+type AccountBalance struct {
+	Account domain.Account
+	Balance float64
+	Count   int
+}
+
+// AccountEntry is an expense or transfer of an account with the balance of the account right after it.
+type AccountEntry struct {
+	Expense domain.Expense
+	Change  float64
+	Balance float64
+}
+
+func getAccounts(accountStorage domain.AccountStorage) ([]domain.Account, error) {
+	accounts, err := accountStorage.Load()
+
+	if err != nil {
+		return nil, fmt.Errorf("Error loading accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+func addAccount(accountStorage domain.AccountStorage, account domain.Account) (domain.Account, error) {
+	account.Name = domain.NormalizeAccount(account.Name)
+	if account.Name == "" {
+		return domain.Account{}, ErrEmptyAccountName
+	}
+
+	accounts, err := getAccounts(accountStorage)
+	if err != nil {
+		return domain.Account{}, err
+	}
+
+	if findAccount(accounts, account.Name) >= 0 {
+		return domain.Account{}, &AccountExistsError{Name: account.Name}
+	}
+
+	err = accountStorage.Save(append(accounts, account))
+
+	if err != nil {
+		return domain.Account{}, fmt.Errorf("Error saving accounts: %w", err)
+	}
+
+	return account, nil
+}
+
+// updateAccount changes the name and opening balance of an account. A new name is carried over to
+// every expense and transfer of the account.
+func updateAccount(storage domain.ExpenseStorage, accountStorage domain.AccountStorage, name string, account domain.Account) (domain.Account, error) {
+	account.Name = domain.NormalizeAccount(account.Name)
+	if account.Name == "" {
+		return domain.Account{}, ErrEmptyAccountName
+	}
+
+	accounts, err := getAccounts(accountStorage)
+	if err != nil {
+		return domain.Account{}, err
+	}
+
+	index := findAccount(accounts, name)
+	if index < 0 {
+		return domain.Account{}, &AccountNotFoundError{Name: name}
+	}
+
+	if other := findAccount(accounts, account.Name); other >= 0 && other != index {
+		return domain.Account{}, &AccountExistsError{Name: account.Name}
+	}
+
+	previous := slices.Clone(accounts)
+	accounts[index] = account
+	err = accountStorage.Save(accounts)
+
+	if err != nil {
+		return domain.Account{}, fmt.Errorf("Error saving accounts: %w", err)
+	}
+
+	// the accounts are saved first and put back when the expenses cannot follow, so no expense is left
+	// pointing to an account that does not exist
+	if account.Name != previous[index].Name {
+		if err = renameAccountExpenses(storage, previous[index].Name, account.Name); err != nil {
+			if rollbackErr := accountStorage.Save(previous); rollbackErr != nil {
+				return domain.Account{}, fmt.Errorf("%w; the account could not be renamed back to %s: %w", err, previous[index].Name, rollbackErr)
+			}
+
+			return domain.Account{}, err
+		}
+	}
+
+	return account, nil
+}
+
+// deleteAccount removes an account no expense or transfer is recorded against.
+func deleteAccount(storage domain.ExpenseStorage, accountStorage domain.AccountStorage, name string) error {
+	accounts, err := getAccounts(accountStorage)
+	if err != nil {
+		return err
+	}
+
+	index := findAccount(accounts, name)
+	if index < 0 {
+		return &AccountNotFoundError{Name: name}
+	}
+
+	expenses, err := storage.Query(domain.ExpenseFilter{Account: name})
+
+	if err != nil {
+		return fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	if len(expenses) > 0 {
+		return &AccountInUseError{Name: accounts[index].Name, Count: len(expenses)}
+	}
+
+	err = accountStorage.Save(slices.Delete(accounts, index, index+1))
+
+	if err != nil {
+		return fmt.Errorf("Error saving accounts: %w", err)
+	}
+
+	return nil
+}
+
+// resolveAccount returns the account name as it was defined, so expenses keep its spelling.
+// An empty name is left empty, an expense does not need an account.
+func resolveAccount(accountStorage domain.AccountStorage, name string) (string, error) {
+	if domain.NormalizeAccount(name) == "" {
+		return "", nil
+	}
+
+	accounts, err := getAccounts(accountStorage)
+	if err != nil {
+		return "", err
+	}
+
+	index := findAccount(accounts, name)
+	if index < 0 {
+		return "", &AccountNotFoundError{Name: name}
+	}
+
+	return accounts[index].Name, nil
+}
+
+func getAccountBalances(storage domain.ExpenseStorage, accountStorage domain.AccountStorage) ([]AccountBalance, error) {
+	accounts, err := getAccounts(accountStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	expenses, err := storage.Query(domain.ExpenseFilter{})
+
+	if err != nil {
+		return nil, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	balances := make([]AccountBalance, len(accounts))
+
+	for i, account := range accounts {
+		balances[i] = AccountBalance{Account: account, Balance: account.OpeningBalance}
+
+		for _, e := range expenses {
+			if e.UsesAccount(account.Name) {
+				balances[i].Balance += e.BalanceChange(account.Name)
+				balances[i].Count++
+			}
+		}
+	}
+
+	return balances, nil
+}
+
+// getAccountEntries lists the expenses and transfers of an account in the order they were made,
+// each with the running balance of the account.
+func getAccountEntries(storage domain.ExpenseStorage, accountStorage domain.AccountStorage, name string, loc *time.Location) ([]AccountEntry, error) {
+	accounts, err := getAccounts(accountStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	index := findAccount(accounts, name)
+	if index < 0 {
+		return nil, &AccountNotFoundError{Name: name}
+	}
+
+	expenses, err := storage.Query(domain.ExpenseFilter{Account: name})
+
+	if err != nil {
+		return nil, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	slices.SortStableFunc(expenses, func(a, b domain.Expense) int {
+		return cmp.Or(a.LocalTime(loc).Compare(b.LocalTime(loc)), cmp.Compare(a.Id, b.Id))
+	})
+
+	entries := make([]AccountEntry, len(expenses))
+	balance := accounts[index].OpeningBalance
+
+	for i, e := range expenses {
+		change := e.BalanceChange(name)
+		balance += change
+		entries[i] = AccountEntry{Expense: e, Change: change, Balance: balance}
+	}
+
+	return entries, nil
+}
+
+// addTransfer records money moved between two accounts. Transfers are not counted as spending.
+func addTransfer(storage domain.ExpenseStorage, accountStorage domain.AccountStorage, spentAt time.Time, from string, to string, amount float64, description string) (domain.Expense, error) {
+	from, to, err := resolveTransfer(accountStorage, from, to, amount)
+	if err != nil {
+		return domain.Expense{}, err
+	}
+
+	id, err := storage.NextID()
+
+	if err != nil {
+		return domain.Expense{}, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	transfer := domain.Expense{
+		Id:          id,
+		SpentAt:     spentAt,
+		Description: description,
+		Amount:      amount,
+		Account:     from,
+		Kind:        domain.TransferKind,
+		ToAccount:   to,
+	}
+
+	err = storage.Insert(transfer)
+
+	if err != nil {
+		return domain.Expense{}, fmt.Errorf("Error saving expenses: %w", err)
+	}
+
+	return transfer, nil
+}
+
+// updateTransfer changes a recorded transfer. Expenses cannot be turned into transfers, as that would
+// silently take them out of the spending.
+func updateTransfer(storage domain.ExpenseStorage, accountStorage domain.AccountStorage, id int, spentAt time.Time, from string, to string, amount float64, description string) (domain.Expense, error) {
+	from, to, err := resolveTransfer(accountStorage, from, to, amount)
+	if err != nil {
+		return domain.Expense{}, err
+	}
+
+	transfer, err := getExpense(storage, id)
+	if err != nil {
+		return domain.Expense{}, err
+	}
+
+	if !transfer.IsTransfer() {
+		return domain.Expense{}, fmt.Errorf("%w: %d", ErrNotTransfer, id)
+	}
+
+	transfer.SpentAt = spentAt
+	transfer.Description = description
+	transfer.Amount = amount
+	transfer.Account = from
+	transfer.ToAccount = to
+
+	err = storage.Update(transfer)
+
+	if err != nil {
+		return domain.Expense{}, fmt.Errorf("Error saving expenses: %w", err)
+	}
+
+	return transfer, nil
+}
+
+// resolveTransfer checks a transfer between two different existing accounts of a positive amount and
+// returns the account names as they were defined.
+func resolveTransfer(accountStorage domain.AccountStorage, from string, to string, amount float64) (string, string, error) {
+	if domain.NormalizeAccount(from) == "" || domain.NormalizeAccount(to) == "" {
+		return "", "", ErrEmptyAccountName
+	}
+
+	if domain.SameAccount(from, to) {
+		return "", "", ErrSameAccount
+	}
+
+	if amount <= 0 {
+		return "", "", ErrInvalidAmount
+	}
+
+	from, err := resolveAccount(accountStorage, from)
+	if err != nil {
+		return "", "", err
+	}
+
+	to, err = resolveAccount(accountStorage, to)
+	if err != nil {
+		return "", "", err
+	}
+
+	return from, to, nil
+}
+
+func renameAccountExpenses(storage domain.ExpenseStorage, oldName string, newName string) error {
+	expenses, err := storage.Query(domain.ExpenseFilter{Account: oldName})
+
+	if err != nil {
+		return fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	for i := range expenses {
+		if domain.SameAccount(expenses[i].Account, oldName) {
+			expenses[i].Account = newName
+		}
+
+		if domain.SameAccount(expenses[i].ToAccount, oldName) {
+			expenses[i].ToAccount = newName
+		}
+	}
+
+	if len(expenses) == 0 {
+		return nil
+	}
+
+	err = storage.Update(expenses...)
+
+	if err != nil {
+		return fmt.Errorf("Error saving expenses: %w", err)
+	}
+
+	return nil
+}
+
+// querySpending returns the expenses matching the filter that count as spending, leaving the transfers out.
+func querySpending(storage domain.ExpenseStorage, filter domain.ExpenseFilter) ([]domain.Expense, error) {
+	expenses, err := storage.Query(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.Spending(expenses), nil
+}
+
+func findAccount(accounts []domain.Account, name string) int {
+	return slices.IndexFunc(accounts, func(a domain.Account) bool {
+		return domain.SameAccount(a.Name, name)
+	})
+}
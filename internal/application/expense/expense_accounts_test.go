@@ -0,0 +1,505 @@
+package expense
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense/mocks"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAddAccount(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	type testCase struct {
+		name            string
+		storageFn       func(t *testing.T) domain.AccountStorage
+		account         domain.Account
+		expectedAccount domain.Account
+		expectedErr     error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Successful addition",
+			storageFn: func(t *testing.T) domain.AccountStorage {
+				t.Helper()
+
+				current := []domain.Account{{Name: "Cash", OpeningBalance: 50}}
+				expected := append(current, domain.Account{Name: "Debit card", OpeningBalance: 1200})
+
+				result := mocks.NewMockAccountStorage(ctrl)
+				result.EXPECT().Load().Return(current, nil).Times(1)
+				result.EXPECT().Save(gomock.Eq(expected)).Return(nil).Times(1)
+
+				return result
+			},
+			account:         domain.Account{Name: "  Debit   card ", OpeningBalance: 1200},
+			expectedAccount: domain.Account{Name: "Debit card", OpeningBalance: 1200},
+		},
+		{
+			name: "Name taken in another case",
+			storageFn: func(t *testing.T) domain.AccountStorage {
+				t.Helper()
+
+				result := mocks.NewMockAccountStorage(ctrl)
+				result.EXPECT().Load().Return([]domain.Account{{Name: "Cash"}}, nil).Times(1)
+				result.EXPECT().Save(gomock.Any()).Times(0)
+
+				return result
+			},
+			account:     domain.Account{Name: "cash"},
+			expectedErr: &AccountExistsError{Name: "cash"},
+		},
+		{
+			name: "Empty name",
+			storageFn: func(t *testing.T) domain.AccountStorage {
+				t.Helper()
+
+				return mocks.NewMockAccountStorage(ctrl)
+			},
+			account:     domain.Account{Name: "  "},
+			expectedErr: ErrEmptyAccountName,
+		},
+		{
+			name: "Save error",
+			storageFn: func(t *testing.T) domain.AccountStorage {
+				t.Helper()
+
+				result := mocks.NewMockAccountStorage(ctrl)
+				result.EXPECT().Load().Return([]domain.Account{}, nil).Times(1)
+				result.EXPECT().Save(gomock.Any()).Return(assert.AnError).Times(1)
+
+				return result
+			},
+			account:     domain.Account{Name: "Cash"},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := addAccount(tt.storageFn(t), tt.account)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedAccount, result)
+			}
+		})
+	}
+}
+
+func TestUpdateAccount(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	accounts := []domain.Account{{Name: "Cash", OpeningBalance: 50}, {Name: "Debit card"}}
+	expenses := []domain.Expense{
+		{Id: 1, Description: "Coffee", Amount: 3, Account: "Cash"},
+		{Id: 2, Description: "ATM", Amount: 100, Account: "Debit card", Kind: domain.TransferKind, ToAccount: "Cash"},
+		{Id: 3, Description: "Rent", Amount: 500, Account: "Debit card"},
+	}
+
+	type testCase struct {
+		name        string
+		storageFn   func(t *testing.T) (domain.ExpenseStorage, domain.AccountStorage)
+		accountName string
+		account     domain.Account
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Rename carries over to expenses and transfers",
+			storageFn: func(t *testing.T) (domain.ExpenseStorage, domain.AccountStorage) {
+				t.Helper()
+
+				expenseStorage := mocks.NewMockExpenseStorage(ctrl)
+				expenseStorage.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(expenses)).Times(1)
+				expenseStorage.EXPECT().Update(
+					domain.Expense{Id: 1, Description: "Coffee", Amount: 3, Account: "Wallet"},
+					domain.Expense{Id: 2, Description: "ATM", Amount: 100, Account: "Debit card", Kind: domain.TransferKind, ToAccount: "Wallet"},
+				).Return(nil).Times(1)
+
+				accountStorage := mocks.NewMockAccountStorage(ctrl)
+				accountStorage.EXPECT().Load().Return(append([]domain.Account{}, accounts...), nil).Times(1)
+				accountStorage.EXPECT().Save([]domain.Account{{Name: "Wallet", OpeningBalance: 80}, {Name: "Debit card"}}).Return(nil).Times(1)
+
+				return expenseStorage, accountStorage
+			},
+			accountName: "cash",
+			account:     domain.Account{Name: "Wallet", OpeningBalance: 80},
+		},
+		{
+			name: "Opening balance only",
+			storageFn: func(t *testing.T) (domain.ExpenseStorage, domain.AccountStorage) {
+				t.Helper()
+
+				expenseStorage := mocks.NewMockExpenseStorage(ctrl)
+				expenseStorage.EXPECT().Query(gomock.Any()).Times(0)
+
+				accountStorage := mocks.NewMockAccountStorage(ctrl)
+				accountStorage.EXPECT().Load().Return(append([]domain.Account{}, accounts...), nil).Times(1)
+				accountStorage.EXPECT().Save([]domain.Account{{Name: "Cash", OpeningBalance: 20}, {Name: "Debit card"}}).Return(nil).Times(1)
+
+				return expenseStorage, accountStorage
+			},
+			accountName: "Cash",
+			account:     domain.Account{Name: "Cash", OpeningBalance: 20},
+		},
+		{
+			name: "Save error leaves the expenses alone",
+			storageFn: func(t *testing.T) (domain.ExpenseStorage, domain.AccountStorage) {
+				t.Helper()
+
+				expenseStorage := mocks.NewMockExpenseStorage(ctrl)
+				expenseStorage.EXPECT().Query(gomock.Any()).Times(0)
+				expenseStorage.EXPECT().Update(gomock.Any()).Times(0)
+
+				accountStorage := mocks.NewMockAccountStorage(ctrl)
+				accountStorage.EXPECT().Load().Return(append([]domain.Account{}, accounts...), nil).Times(1)
+				accountStorage.EXPECT().Save(gomock.Any()).Return(assert.AnError).Times(1)
+
+				return expenseStorage, accountStorage
+			},
+			accountName: "Cash",
+			account:     domain.Account{Name: "Wallet"},
+			expectedErr: assert.AnError,
+		},
+		{
+			name: "Rename error puts the account back",
+			storageFn: func(t *testing.T) (domain.ExpenseStorage, domain.AccountStorage) {
+				t.Helper()
+
+				expenseStorage := mocks.NewMockExpenseStorage(ctrl)
+				expenseStorage.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(expenses)).Times(1)
+				expenseStorage.EXPECT().Update(gomock.Any()).Return(assert.AnError).Times(1)
+
+				accountStorage := mocks.NewMockAccountStorage(ctrl)
+				accountStorage.EXPECT().Load().Return(append([]domain.Account{}, accounts...), nil).Times(1)
+				firstCall := accountStorage.EXPECT().Save([]domain.Account{{Name: "Wallet"}, {Name: "Debit card"}}).Return(nil).Times(1)
+				accountStorage.EXPECT().Save(accounts).Return(nil).Times(1).After(firstCall)
+
+				return expenseStorage, accountStorage
+			},
+			accountName: "Cash",
+			account:     domain.Account{Name: "Wallet"},
+			expectedErr: assert.AnError,
+		},
+		{
+			name: "Name of another account",
+			storageFn: func(t *testing.T) (domain.ExpenseStorage, domain.AccountStorage) {
+				t.Helper()
+
+				accountStorage := mocks.NewMockAccountStorage(ctrl)
+				accountStorage.EXPECT().Load().Return(append([]domain.Account{}, accounts...), nil).Times(1)
+				accountStorage.EXPECT().Save(gomock.Any()).Times(0)
+
+				return mocks.NewMockExpenseStorage(ctrl), accountStorage
+			},
+			accountName: "Cash",
+			account:     domain.Account{Name: "debit card"},
+			expectedErr: &AccountExistsError{Name: "debit card"},
+		},
+		{
+			name: "Unknown account",
+			storageFn: func(t *testing.T) (domain.ExpenseStorage, domain.AccountStorage) {
+				t.Helper()
+
+				accountStorage := mocks.NewMockAccountStorage(ctrl)
+				accountStorage.EXPECT().Load().Return(append([]domain.Account{}, accounts...), nil).Times(1)
+
+				return mocks.NewMockExpenseStorage(ctrl), accountStorage
+			},
+			accountName: "Savings",
+			account:     domain.Account{Name: "Savings"},
+			expectedErr: &AccountNotFoundError{Name: "Savings"},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			expenseStorage, accountStorage := tt.storageFn(t)
+			result, err := updateAccount(expenseStorage, accountStorage, tt.accountName, tt.account)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.account, result)
+			}
+		})
+	}
+}
+
+func TestDeleteAccount(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	accounts := []domain.Account{{Name: "Cash"}, {Name: "Debit card"}, {Name: "Savings"}}
+	expenses := []domain.Expense{
+		{Id: 1, Description: "ATM", Amount: 100, Account: "Debit card", Kind: domain.TransferKind, ToAccount: "Cash"},
+	}
+
+	type testCase struct {
+		name        string
+		accountName string
+		saved       []domain.Account
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			name:        "Unused account",
+			accountName: "Savings",
+			saved:       []domain.Account{{Name: "Cash"}, {Name: "Debit card"}},
+		},
+		{
+			name:        "Account a transfer went into",
+			accountName: "Cash",
+			expectedErr: &AccountInUseError{Name: "Cash", Count: 1},
+		},
+		{
+			name:        "Unknown account",
+			accountName: "Credit card",
+			expectedErr: &AccountNotFoundError{Name: "Credit card"},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			expenseStorage := mocks.NewMockExpenseStorage(ctrl)
+			expenseStorage.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(expenses)).MaxTimes(1)
+
+			accountStorage := mocks.NewMockAccountStorage(ctrl)
+			accountStorage.EXPECT().Load().Return(append([]domain.Account{}, accounts...), nil).Times(1)
+
+			if tt.saved != nil {
+				accountStorage.EXPECT().Save(tt.saved).Return(nil).Times(1)
+			}
+
+			err := deleteAccount(expenseStorage, accountStorage, tt.accountName)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetAccountBalances(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	expenses := []domain.Expense{
+		{Id: 1, Description: "Coffee", Amount: 3, Account: "Cash"},
+		{Id: 2, Description: "ATM", Amount: 100, Account: "Debit card", Kind: domain.TransferKind, ToAccount: "Cash"},
+		{Id: 3, Description: "Rent", Amount: 500, Account: "Debit card"},
+		{Id: 4, Description: "Gift", Amount: 20},
+	}
+
+	expenseStorage := mocks.NewMockExpenseStorage(ctrl)
+	expenseStorage.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(expenses)).Times(1)
+
+	accountStorage := mocks.NewMockAccountStorage(ctrl)
+	accountStorage.EXPECT().Load().Return([]domain.Account{
+		{Name: "Cash", OpeningBalance: 50},
+		{Name: "Debit card", OpeningBalance: 1200},
+		{Name: "Savings", OpeningBalance: 300},
+	}, nil).Times(1)
+
+	balances, err := getAccountBalances(expenseStorage, accountStorage)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []AccountBalance{
+		{Account: domain.Account{Name: "Cash", OpeningBalance: 50}, Balance: 147, Count: 2},
+		{Account: domain.Account{Name: "Debit card", OpeningBalance: 1200}, Balance: 600, Count: 2},
+		{Account: domain.Account{Name: "Savings", OpeningBalance: 300}, Balance: 300, Count: 0},
+	}, balances)
+}
+
+func TestGetAccountEntries(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	expenses := []domain.Expense{
+		{Id: 1, Description: "Dinner", Amount: 30, Account: "Cash", SpentAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{Id: 2, Description: "ATM", Amount: 100, Account: "Debit card", Kind: domain.TransferKind, ToAccount: "Cash", SpentAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Id: 3, Description: "Rent", Amount: 500, Account: "Debit card", SpentAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// 1 January 23:30 in UTC+2, before dinner
+		{Id: 4, Description: "Taxi", Amount: 15, Account: "Cash", SpentAt: time.Date(2024, 1, 1, 21, 30, 0, 0, time.UTC)},
+	}
+
+	expenseStorage := mocks.NewMockExpenseStorage(ctrl)
+	expenseStorage.EXPECT().Query(domain.ExpenseFilter{Account: "cash"}).DoAndReturn(queryOver(expenses)).Times(1)
+
+	accountStorage := mocks.NewMockAccountStorage(ctrl)
+	accountStorage.EXPECT().Load().Return([]domain.Account{{Name: "Cash", OpeningBalance: 10}}, nil).Times(1)
+
+	entries, err := getAccountEntries(expenseStorage, accountStorage, "cash", loc)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []AccountEntry{
+		{Expense: expenses[1], Change: 100, Balance: 110},
+		{Expense: expenses[3], Change: -15, Balance: 95},
+		{Expense: expenses[0], Change: -30, Balance: 65},
+	}, entries)
+}
+
+func TestAddTransfer(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	accounts := []domain.Account{{Name: "Cash"}, {Name: "Debit card"}}
+	spentAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name             string
+		from             string
+		to               string
+		amount           float64
+		expectedTransfer domain.Expense
+		expectedErr      error
+	}
+
+	testCases := []testCase{
+		{
+			name:             "Successful transfer",
+			from:             "debit card",
+			to:               "CASH",
+			amount:           100,
+			expectedTransfer: domain.Expense{Id: 7, SpentAt: spentAt, Description: "ATM", Amount: 100, Account: "Debit card", Kind: domain.TransferKind, ToAccount: "Cash"},
+		},
+		{
+			name:        "Same account",
+			from:        "Cash",
+			to:          "cash",
+			amount:      100,
+			expectedErr: ErrSameAccount,
+		},
+		{
+			name:        "Unknown account",
+			from:        "Cash",
+			to:          "Savings",
+			amount:      100,
+			expectedErr: &AccountNotFoundError{Name: "Savings"},
+		},
+		{
+			name:        "Missing account",
+			from:        "",
+			to:          "Cash",
+			amount:      100,
+			expectedErr: ErrEmptyAccountName,
+		},
+		{
+			name:        "Non-positive amount",
+			from:        "Cash",
+			to:          "Debit card",
+			amount:      0,
+			expectedErr: ErrInvalidAmount,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			expenseStorage := mocks.NewMockExpenseStorage(ctrl)
+			accountStorage := mocks.NewMockAccountStorage(ctrl)
+			accountStorage.EXPECT().Load().Return(accounts, nil).AnyTimes()
+
+			if tt.expectedErr == nil {
+				firstCall := expenseStorage.EXPECT().NextID().Return(7, nil).Times(1)
+				expenseStorage.EXPECT().Insert(tt.expectedTransfer).Return(nil).Times(1).After(firstCall)
+			}
+
+			result, err := addTransfer(expenseStorage, accountStorage, spentAt, tt.from, tt.to, tt.amount, "ATM")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedTransfer, result)
+			}
+		})
+	}
+}
+
+func TestUpdateTransfer(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	accounts := []domain.Account{{Name: "Cash"}, {Name: "Debit card"}}
+	spentAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name             string
+		current          domain.Expense
+		amount           float64
+		expectedTransfer domain.Expense
+		expectedErr      error
+	}
+
+	testCases := []testCase{
+		{
+			name:             "Successful update",
+			current:          domain.Expense{Id: 7, Description: "ATM", Amount: 100, Account: "Debit card", Kind: domain.TransferKind, ToAccount: "Cash"},
+			amount:           50,
+			expectedTransfer: domain.Expense{Id: 7, SpentAt: spentAt, Description: "ATM", Amount: 50, Account: "Cash", Kind: domain.TransferKind, ToAccount: "Debit card"},
+		},
+		{
+			name:        "Expense turned into a transfer",
+			current:     domain.Expense{Id: 7, Description: "Coffee", Category: "food", Amount: 3.5},
+			amount:      50,
+			expectedErr: ErrNotTransfer,
+		},
+		{
+			name:        "Non-positive amount",
+			current:     domain.Expense{Id: 7, Description: "ATM", Amount: 100, Account: "Debit card", Kind: domain.TransferKind, ToAccount: "Cash"},
+			amount:      -5,
+			expectedErr: ErrInvalidAmount,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			expenseStorage := mocks.NewMockExpenseStorage(ctrl)
+			accountStorage := mocks.NewMockAccountStorage(ctrl)
+			accountStorage.EXPECT().Load().Return(accounts, nil).AnyTimes()
+			expenseStorage.EXPECT().Get(tt.current.Id).Return(tt.current, true, nil).AnyTimes()
+
+			if tt.expectedErr == nil {
+				expenseStorage.EXPECT().Update(tt.expectedTransfer).Return(nil).Times(1)
+			}
+
+			result, err := updateTransfer(expenseStorage, accountStorage, tt.current.Id, spentAt, "cash", "Debit Card", tt.amount, "ATM")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedTransfer, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package expense
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"slices"
+	"time"
+)
+
+// AnomalyKind tells what makes an expense or a category unusual.
+type AnomalyKind string
+
+const (
+	HighAmountAnomaly    AnomalyKind = "high amount"
+	NewMerchantAnomaly   AnomalyKind = "new merchant"
+	CategorySpikeAnomaly AnomalyKind = "category spike"
+)
+
+const (
+	// highAmountFactor is how many times the median of its category an expense has to exceed.
+	highAmountFactor = 3.0
+	// minCategoryHistory is the number of earlier expenses a category needs before its median is trusted.
+	minCategoryHistory = 5
+	// minMerchantHistory is the number of earlier expenses needed before unseen merchants are worth a mention.
+	minMerchantHistory = 20
+	// spikeFactor is how many times its rolling average a category has to exceed in a month.
+	spikeFactor = 1.5
+	// spikeWindowMonths is the most months before the checked one the rolling average is taken over.
+	spikeWindowMonths = 3
+)
+
+// Anomaly is an unusual expense, or a category spending far more than usual in a month when ExpenseId is 0.
+type Anomaly struct {
+	ExpenseId int
+	Category  string
+	Kind      AnomalyKind
+	Reason    string
+}
+
+// getExpenseAnomalies checks every expense against the ones made before it: amounts far above the median
+// of their category and merchants that never appeared before. Uncategorized expenses have no usual amount.
+// An expense gets at most one anomaly.
+func getExpenseAnomalies(storage domain.ExpenseStorage) ([]Anomaly, error) {
+	expenses, err := querySpending(storage, domain.ExpenseFilter{})
+
+	if err != nil {
+		return nil, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	return expenseAnomalies(expenses), nil
+}
+
+func expenseAnomalies(expenses []domain.Expense) []Anomaly {
+	expenses = slices.Clone(expenses)
+	slices.SortFunc(expenses, func(a, b domain.Expense) int {
+		return cmp.Or(a.SpentAt.Compare(b.SpentAt), cmp.Compare(a.Id, b.Id))
+	})
+
+	anomalies := make([]Anomaly, 0)
+	amountsByCategory := make(map[string][]float64)
+	seenMerchants := make(map[string]struct{})
+
+	for i, e := range expenses {
+		history := amountsByCategory[e.Category]
+		merchant := domain.Payee(e.Description)
+		_, seen := seenMerchants[merchant]
+
+		switch {
+		case !domain.IsUncategorized(e.Category) && len(history) >= minCategoryHistory && e.Amount > highAmountFactor*median(history):
+			anomalies = append(anomalies, Anomaly{
+				ExpenseId: e.Id,
+				Category:  e.Category,
+				Kind:      HighAmountAnomaly,
+				Reason:    fmt.Sprintf("%.1f× the usual %.2f for %s", e.Amount/median(history), median(history), e.Category),
+			})
+		case i >= minMerchantHistory && merchant != "" && !seen:
+			anomalies = append(anomalies, Anomaly{
+				ExpenseId: e.Id,
+				Category:  e.Category,
+				Kind:      NewMerchantAnomaly,
+				Reason:    fmt.Sprintf("first expense at %q", merchant),
+			})
+		}
+
+		amountsByCategory[e.Category] = append(history, e.Amount)
+		seenMerchants[merchant] = struct{}{}
+	}
+
+	return anomalies
+}
+
+// getMonthlyAnomalies lists the top-level categories spending more than spikeFactor times their average
+// over the months before, followed by the unusual expenses made in the month.
+func getMonthlyAnomalies(storage domain.ExpenseStorage, year int, month time.Month, loc *time.Location) ([]Anomaly, error) {
+	expenses, err := querySpending(storage, domain.ExpenseFilter{})
+
+	if err != nil {
+		return nil, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	anomalies := categorySpikes(expenses, year, month, loc)
+
+	inMonth := make(map[int]struct{})
+
+	for _, e := range expenses {
+		if e.IsInMonth(year, month, loc) {
+			inMonth[e.Id] = struct{}{}
+		}
+	}
+
+	for _, a := range expenseAnomalies(expenses) {
+		if _, ok := inMonth[a.ExpenseId]; ok {
+			anomalies = append(anomalies, a)
+		}
+	}
+
+	return anomalies, nil
+}
+
+func categorySpikes(expenses []domain.Expense, year int, month time.Month, loc *time.Location) []Anomaly {
+	series := monthlySeries(expenses, time.Date(year, month, 1, 0, 0, 0, 0, time.UTC), spikeWindowMonths+1, 1, loc)
+	current, window := series[len(series)-1], series[:len(series)-1]
+
+	// months before the first expense ever recorded would only pull the averages down
+	for len(window) > 0 && window[0].Total == 0 {
+		window = window[1:]
+	}
+
+	spikes := make([]Anomaly, 0)
+
+	for category, total := range current.ByCategory {
+		average := 0.0
+
+		for _, t := range window {
+			average += t.ByCategory[category] / float64(len(window))
+		}
+
+		if average > 0 && total > spikeFactor*average {
+			spikes = append(spikes, Anomaly{
+				Category: category,
+				Kind:     CategorySpikeAnomaly,
+				Reason: fmt.Sprintf("%.2f in %s, %.1f× the average of %.2f over the %d months before",
+					total, category, total/average, average, len(window)),
+			})
+		}
+	}
+
+	slices.SortFunc(spikes, func(a, b Anomaly) int {
+		return cmp.Compare(a.Category, b.Category)
+	})
+
+	return spikes
+}
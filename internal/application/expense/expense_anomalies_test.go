@@ -0,0 +1,236 @@
+package expense
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense/mocks"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// dailyExpenses makes n expenses of the same kind on consecutive days starting with the given id and day.
+func dailyExpenses(firstId int, first time.Time, n int, description string, category string, amount float64) []domain.Expense {
+	expenses := make([]domain.Expense, n)
+
+	for i := range expenses {
+		expenses[i] = domain.Expense{
+			Id:          firstId + i,
+			Description: description,
+			Category:    category,
+			Amount:      amount,
+			SpentAt:     first.AddDate(0, 0, i),
+		}
+	}
+
+	return expenses
+}
+
+func TestGetExpenseAnomalies(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name              string
+		storageFn         func(t *testing.T) domain.ExpenseStorage
+		expectedAnomalies []Anomaly
+		expectedErr       error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Amount far above the category median",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := dailyExpenses(1, start, 5, "Coffee", "Food", 10)
+				currentExpenses = append(currentExpenses,
+					domain.Expense{Id: 6, Description: "Coffee", Category: "Food", Amount: 40, SpentAt: start.AddDate(0, 0, 5)},
+					domain.Expense{Id: 7, Description: "Coffee", Category: "Food", Amount: 30, SpentAt: start.AddDate(0, 0, 6)},
+				)
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			expectedAnomalies: []Anomaly{
+				{ExpenseId: 6, Category: "Food", Kind: HighAmountAnomaly, Reason: "4.0× the usual 10.00 for Food"},
+			},
+		},
+		{
+			name: "Too little history for a median",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := dailyExpenses(1, start, 4, "Coffee", "Food", 10)
+				currentExpenses = append(currentExpenses,
+					domain.Expense{Id: 5, Description: "Coffee", Category: "Food", Amount: 100, SpentAt: start.AddDate(0, 0, 4)},
+				)
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			expectedAnomalies: []Anomaly{},
+		},
+		{
+			name: "New merchant after enough history",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := dailyExpenses(1, start, 20, "STARBUCKS 1234", "Food", 5)
+				currentExpenses = append(currentExpenses,
+					domain.Expense{Id: 21, Description: "Starbucks 999", Category: "Food", Amount: 5, SpentAt: start.AddDate(0, 0, 20)},
+					domain.Expense{Id: 22, Description: "UBER *TRIP", Category: "Transport", Amount: 12, SpentAt: start.AddDate(0, 0, 21)},
+				)
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			expectedAnomalies: []Anomaly{
+				{ExpenseId: 22, Category: "Transport", Kind: NewMerchantAnomaly, Reason: `first expense at "uber"`},
+			},
+		},
+		{
+			name: "Load error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+				return result
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := tt.storageFn(t)
+			result, err := getExpenseAnomalies(mockStorage)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedAnomalies, result)
+			}
+		})
+	}
+}
+
+func TestGetMonthlyAnomalies(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	type testCase struct {
+		name              string
+		storageFn         func(t *testing.T) domain.ExpenseStorage
+		expectedAnomalies []Anomaly
+		expectedErr       error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Category spiking above its rolling average",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Description: "Groceries", Category: "Food:Groceries", Amount: 100, SpentAt: time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC)},
+					{Id: 2, Description: "Groceries", Category: "Food:Groceries", Amount: 100, SpentAt: time.Date(2023, 11, 5, 0, 0, 0, 0, time.UTC)},
+					{Id: 3, Description: "Groceries", Category: "Food:Groceries", Amount: 100, SpentAt: time.Date(2023, 12, 5, 0, 0, 0, 0, time.UTC)},
+					{Id: 4, Description: "Groceries", Category: "Food:Groceries", Amount: 120, SpentAt: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+					{Id: 5, Description: "Dinner", Category: "Food:Restaurants", Amount: 80, SpentAt: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)},
+					{Id: 6, Description: "Rent", Category: "Rent", Amount: 500, SpentAt: time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)},
+					{Id: 7, Description: "Rent", Category: "Rent", Amount: 500, SpentAt: time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)},
+					{Id: 8, Description: "Rent", Category: "Rent", Amount: 500, SpentAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			expectedAnomalies: []Anomaly{
+				{Category: "Food", Kind: CategorySpikeAnomaly, Reason: "200.00 in Food, 2.0× the average of 100.00 over the 3 months before"},
+			},
+		},
+		{
+			name: "Months before the first expense left out of the average",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Description: "Rent", Category: "Rent", Amount: 500, SpentAt: time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)},
+					{Id: 2, Description: "Rent", Category: "Rent", Amount: 500, SpentAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			expectedAnomalies: []Anomaly{},
+		},
+		{
+			name: "Unusual expenses of other months left out",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := dailyExpenses(1, time.Date(2023, 12, 20, 0, 0, 0, 0, time.UTC), 5, "Coffee", "Coffee", 2)
+				currentExpenses = append(currentExpenses,
+					domain.Expense{Id: 6, Description: "Coffee", Category: "Coffee", Amount: 20, SpentAt: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)},
+					domain.Expense{Id: 7, Description: "Coffee", Category: "Coffee", Amount: 10, SpentAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+				)
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			expectedAnomalies: []Anomaly{
+				{ExpenseId: 7, Category: "Coffee", Kind: HighAmountAnomaly, Reason: "5.0× the usual 2.00 for Coffee"},
+			},
+		},
+		{
+			name: "Load error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+				return result
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := tt.storageFn(t)
+			result, err := getMonthlyAnomalies(mockStorage, 2024, time.January, time.UTC)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedAnomalies, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package expense
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/samber/lo"
+	"io/fs"
+	"time"
+)
+
+// BackupRetention is how many of the latest days and months keep their last backup.
+type BackupRetention struct {
+	Days   int
+	Months int
+}
+
+// backupExpenses takes a backup of every expense and drops the backups falling out of the retention.
+func backupExpenses(storage domain.ExpenseStorage, backups domain.BackupStorage, now time.Time, retention BackupRetention, loc *time.Location) (domain.Backup, error) {
+	expenses, err := storage.Query(domain.ExpenseFilter{})
+
+	if err != nil {
+		return domain.Backup{}, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	return createBackup(backups, expenses, now, retention, loc)
+}
+
+func createBackup(backups domain.BackupStorage, expenses []domain.Expense, now time.Time, retention BackupRetention, loc *time.Location) (domain.Backup, error) {
+	backup, err := backups.Create(expenses, now)
+	if err != nil {
+		return domain.Backup{}, fmt.Errorf("Error creating backup: %w", err)
+	}
+
+	if err = pruneBackups(backups, retention, now, loc); err != nil {
+		return backup, err
+	}
+
+	return backup, nil
+}
+
+// getBackups lists the backups with the number of expenses in each. A backup that cannot be read is
+// marked as unreadable rather than failing the listing.
+func getBackups(backups domain.BackupStorage) ([]domain.Backup, error) {
+	existing, err := backups.List()
+	if err != nil {
+		return nil, fmt.Errorf("Error listing backups: %w", err)
+	}
+
+	for i := range existing {
+		expenses, err := backups.Load(existing[i].Name)
+		if err != nil {
+			existing[i].Unreadable = true
+			continue
+		}
+
+		existing[i].Count = len(expenses)
+	}
+
+	return existing, nil
+}
+
+// ensureDailyBackup takes a backup before the first change of the day. Nothing is backed up while there
+// are no expenses yet.
+func ensureDailyBackup(storage domain.ExpenseStorage, backups domain.BackupStorage, now time.Time, retention BackupRetention, loc *time.Location) error {
+	existing, err := backups.List()
+	if err != nil {
+		return fmt.Errorf("Error listing backups: %w", err)
+	}
+
+	today := now.In(loc).Format(time.DateOnly)
+	takenToday := lo.ContainsBy(existing, func(b domain.Backup) bool {
+		return b.CreatedAt.In(loc).Format(time.DateOnly) == today
+	})
+
+	if takenToday {
+		return nil
+	}
+
+	expenses, err := storage.Query(domain.ExpenseFilter{})
+
+	if err != nil {
+		return fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	if len(expenses) == 0 {
+		return nil
+	}
+
+	_, err = createBackup(backups, expenses, now, retention, loc)
+	return err
+}
+
+// restoreBackup replaces every expense with the ones of the backup. The current expenses are backed up first,
+// so a restore can be undone by restoring that backup. Nothing is pruned on the way, so neither the backup
+// being restored nor the one just taken can be removed.
+func restoreBackup(storage domain.ExpenseStorage, backups domain.BackupStorage, name string, now time.Time) (int, error) {
+	restored, err := backups.Load(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, &BackupNotFoundError{Name: name}
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("Error loading backup: %w", err)
+	}
+
+	current, err := storage.Query(domain.ExpenseFilter{})
+
+	if err != nil {
+		return 0, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	if _, err = backups.Create(current, now); err != nil {
+		return 0, fmt.Errorf("Error creating backup: %w", err)
+	}
+
+	if err = storage.Replace(restored...); err != nil {
+		return 0, fmt.Errorf("Error saving expenses: %w", err)
+	}
+
+	return len(restored), nil
+}
+
+// pruneBackups keeps the last backup of each of the latest retention.Days days and retention.Months months
+// that have backups, along with every backup taken today, and removes the others.
+func pruneBackups(backups domain.BackupStorage, retention BackupRetention, now time.Time, loc *time.Location) error {
+	existing, err := backups.List()
+	if err != nil {
+		return fmt.Errorf("Error listing backups: %w", err)
+	}
+
+	for _, b := range backupsToPrune(existing, retention, now, loc) {
+		if err = backups.Remove(b.Name); err != nil {
+			return fmt.Errorf("Error removing backup %s: %w", b.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// backupsToPrune picks the backups falling out of the retention from backups listed the newest first.
+func backupsToPrune(backups []domain.Backup, retention BackupRetention, now time.Time, loc *time.Location) []domain.Backup {
+	keep := make(map[string]struct{})
+	days := make(map[string]struct{})
+	months := make(map[string]struct{})
+	today := now.In(loc).Format(time.DateOnly)
+
+	for _, b := range backups {
+		createdAt := b.CreatedAt.In(loc)
+
+		day := createdAt.Format(time.DateOnly)
+		if day == today {
+			keep[b.Name] = struct{}{}
+		}
+
+		if _, ok := days[day]; !ok && len(days) < retention.Days {
+			days[day] = struct{}{}
+			keep[b.Name] = struct{}{}
+		}
+
+		month := createdAt.Format("2006-01")
+		if _, ok := months[month]; !ok && len(months) < retention.Months {
+			months[month] = struct{}{}
+			keep[b.Name] = struct{}{}
+		}
+	}
+
+	return lo.Filter(backups, func(b domain.Backup, _ int) bool {
+		_, ok := keep[b.Name]
+		return !ok
+	})
+}
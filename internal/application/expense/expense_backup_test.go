@@ -0,0 +1,409 @@
+package expense
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense/mocks"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+func backupAt(year int, month time.Month, day int, hour int) domain.Backup {
+	createdAt := time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	return domain.Backup{Name: createdAt.Format(time.RFC3339), CreatedAt: createdAt}
+}
+
+func TestBackupsToPrune(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name      string
+		backups   []domain.Backup
+		retention BackupRetention
+		location  *time.Location
+		expected  []domain.Backup
+	}
+
+	testCases := []testCase{
+		{
+			name: "Keeps the last backup of each day",
+			backups: []domain.Backup{
+				backupAt(2026, 10, 18, 20),
+				backupAt(2026, 10, 18, 9),
+				backupAt(2026, 10, 17, 9),
+			},
+			retention: BackupRetention{Days: 7},
+			expected:  []domain.Backup{backupAt(2026, 10, 18, 9)},
+		},
+		{
+			name: "Keeps every backup of today",
+			backups: []domain.Backup{
+				backupAt(2026, 10, 19, 11),
+				backupAt(2026, 10, 19, 9),
+			},
+			retention: BackupRetention{Days: 1},
+			expected:  []domain.Backup{},
+		},
+		{
+			name: "Months keep their last backup past the daily ones",
+			backups: []domain.Backup{
+				backupAt(2026, 10, 18, 9),
+				backupAt(2026, 10, 17, 9),
+				backupAt(2026, 9, 30, 9),
+				backupAt(2026, 9, 2, 9),
+				backupAt(2026, 8, 31, 9),
+				backupAt(2026, 7, 31, 9),
+			},
+			retention: BackupRetention{Days: 1, Months: 3},
+			expected: []domain.Backup{
+				backupAt(2026, 10, 17, 9),
+				backupAt(2026, 9, 2, 9),
+				backupAt(2026, 7, 31, 9),
+			},
+		},
+		{
+			name: "Days counted in the location",
+			backups: []domain.Backup{
+				backupAt(2026, 10, 18, 23),
+				backupAt(2026, 10, 18, 21),
+			},
+			retention: BackupRetention{Days: 2},
+			location:  time.FixedZone("", 2*60*60),
+			expected:  []domain.Backup{},
+		},
+		{
+			name:      "No backups",
+			backups:   []domain.Backup{},
+			retention: BackupRetention{Days: 7, Months: 6},
+			expected:  []domain.Backup{},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			loc := tt.location
+			if loc == nil {
+				loc = time.UTC
+			}
+
+			result := backupsToPrune(tt.backups, tt.retention, now, loc)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestEnsureDailyBackup(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	retention := BackupRetention{Days: 7, Months: 6}
+	expenses := []domain.Expense{{Id: 1, Description: "Coffee", Amount: 3}}
+
+	type testCase struct {
+		name        string
+		storageFn   func(t *testing.T) domain.ExpenseStorage
+		backupsFn   func(t *testing.T) domain.BackupStorage
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			name: "First change of the day",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(expenses)).Times(1)
+
+				return result
+			},
+			backupsFn: func(t *testing.T) domain.BackupStorage {
+				t.Helper()
+
+				result := mocks.NewMockBackupStorage(ctrl)
+				firstCall := result.EXPECT().List().Return([]domain.Backup{backupAt(2026, 10, 18, 9)}, nil).Times(1)
+				secondCall := result.EXPECT().Create(expenses, now).Return(backupAt(2026, 10, 19, 12), nil).Times(1).After(firstCall)
+				result.EXPECT().List().Return([]domain.Backup{backupAt(2026, 10, 19, 12), backupAt(2026, 10, 18, 9)}, nil).Times(1).After(secondCall)
+
+				return result
+			},
+		},
+		{
+			name: "Already backed up today",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				return mocks.NewMockExpenseStorage(ctrl)
+			},
+			backupsFn: func(t *testing.T) domain.BackupStorage {
+				t.Helper()
+
+				result := mocks.NewMockBackupStorage(ctrl)
+				result.EXPECT().List().Return([]domain.Backup{backupAt(2026, 10, 19, 9)}, nil).Times(1)
+				result.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+
+				return result
+			},
+		},
+		{
+			name: "Nothing to back up",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(nil)).Times(1)
+
+				return result
+			},
+			backupsFn: func(t *testing.T) domain.BackupStorage {
+				t.Helper()
+
+				result := mocks.NewMockBackupStorage(ctrl)
+				result.EXPECT().List().Return([]domain.Backup{}, nil).Times(1)
+				result.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+
+				return result
+			},
+		},
+		{
+			name: "Create error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(expenses)).Times(1)
+
+				return result
+			},
+			backupsFn: func(t *testing.T) domain.BackupStorage {
+				t.Helper()
+
+				result := mocks.NewMockBackupStorage(ctrl)
+				result.EXPECT().List().Return([]domain.Backup{}, nil).Times(1)
+				result.EXPECT().Create(expenses, now).Return(domain.Backup{}, assert.AnError).Times(1)
+
+				return result
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ensureDailyBackup(tt.storageFn(t), tt.backupsFn(t), now, retention, time.UTC)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetBackups(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	readable, corrupt := backupAt(2026, 10, 19, 9), backupAt(2026, 10, 18, 9)
+
+	type testCase struct {
+		name            string
+		backupsFn       func(t *testing.T) domain.BackupStorage
+		expectedBackups []domain.Backup
+		expectedErr     error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Counts the expenses and marks unreadable backups",
+			backupsFn: func(t *testing.T) domain.BackupStorage {
+				t.Helper()
+
+				result := mocks.NewMockBackupStorage(ctrl)
+				result.EXPECT().List().Return([]domain.Backup{readable, corrupt}, nil).Times(1)
+				result.EXPECT().Load(readable.Name).Return([]domain.Expense{{Id: 1}, {Id: 2}}, nil).Times(1)
+				result.EXPECT().Load(corrupt.Name).Return(nil, assert.AnError).Times(1)
+
+				return result
+			},
+			expectedBackups: []domain.Backup{
+				{Name: readable.Name, CreatedAt: readable.CreatedAt, Count: 2},
+				{Name: corrupt.Name, CreatedAt: corrupt.CreatedAt, Unreadable: true},
+			},
+		},
+		{
+			name: "List error",
+			backupsFn: func(t *testing.T) domain.BackupStorage {
+				t.Helper()
+
+				result := mocks.NewMockBackupStorage(ctrl)
+				result.EXPECT().List().Return(nil, assert.AnError).Times(1)
+
+				return result
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := getBackups(tt.backupsFn(t))
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedBackups, result)
+			}
+		})
+	}
+}
+
+func TestRestoreBackup(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	current := []domain.Expense{
+		{Id: 1, Description: "Coffee", Category: "Food", Amount: 3},
+		{Id: 2, Description: "Taxi", Category: "Food", Amount: 12},
+	}
+	restored := []domain.Expense{
+		{Id: 1, Description: "Coffee", Category: "Food", Amount: 3},
+		{Id: 2, Description: "Taxi", Category: "Transport", Amount: 12},
+	}
+
+	type testCase struct {
+		name          string
+		storageFn     func(t *testing.T) domain.ExpenseStorage
+		backupsFn     func(t *testing.T) domain.BackupStorage
+		backupName    string
+		expectedCount int
+		expectedErr   error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Successful restore",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				firstCall := result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(current)).Times(1)
+				result.EXPECT().Replace(restored[0], restored[1]).Return(nil).Times(1).After(firstCall)
+
+				return result
+			},
+			backupsFn: func(t *testing.T) domain.BackupStorage {
+				t.Helper()
+
+				result := mocks.NewMockBackupStorage(ctrl)
+				firstCall := result.EXPECT().Load("before").Return(restored, nil).Times(1)
+				result.EXPECT().Create(current, now).Return(backupAt(2026, 10, 19, 12), nil).Times(1).After(firstCall)
+				result.EXPECT().List().Times(0)
+				result.EXPECT().Remove(gomock.Any()).Times(0)
+
+				return result
+			},
+			backupName:    "before",
+			expectedCount: 2,
+		},
+		{
+			name: "Backup not found",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				return mocks.NewMockExpenseStorage(ctrl)
+			},
+			backupsFn: func(t *testing.T) domain.BackupStorage {
+				t.Helper()
+
+				result := mocks.NewMockBackupStorage(ctrl)
+				result.EXPECT().Load("missing").Return(nil, fs.ErrNotExist).Times(1)
+
+				return result
+			},
+			backupName:  "missing",
+			expectedErr: &BackupNotFoundError{Name: "missing"},
+		},
+		{
+			name: "Current expenses not backed up",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(current)).Times(1)
+				result.EXPECT().Replace(gomock.Any()).Times(0)
+
+				return result
+			},
+			backupsFn: func(t *testing.T) domain.BackupStorage {
+				t.Helper()
+
+				result := mocks.NewMockBackupStorage(ctrl)
+				result.EXPECT().Load("before").Return(restored, nil).Times(1)
+				result.EXPECT().Create(current, now).Return(domain.Backup{}, assert.AnError).Times(1)
+
+				return result
+			},
+			backupName:  "before",
+			expectedErr: assert.AnError,
+		},
+		{
+			name: "Save error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				firstCall := result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(current)).Times(1)
+				result.EXPECT().Replace(restored[0], restored[1]).Return(assert.AnError).Times(1).After(firstCall)
+
+				return result
+			},
+			backupsFn: func(t *testing.T) domain.BackupStorage {
+				t.Helper()
+
+				result := mocks.NewMockBackupStorage(ctrl)
+				firstCall := result.EXPECT().Load("before").Return(restored, nil).Times(1)
+				result.EXPECT().Create(current, now).Return(backupAt(2026, 10, 19, 12), nil).Times(1).After(firstCall)
+				result.EXPECT().List().Times(0)
+				result.EXPECT().Remove(gomock.Any()).Times(0)
+
+				return result
+			},
+			backupName:  "before",
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			count, err := restoreBackup(tt.storageFn(t), tt.backupsFn(t), tt.backupName, now)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCount, count)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package expense
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/samber/lo"
+	"slices"
+)
+
+// BulkChange describes a modification applied to every expense of a bulk update.
+// Zero values leave the corresponding field untouched. A new category replaces the split lines of an expense.
+type BulkChange struct {
+	Category   string
+	ShiftDays  int
+	AddTags    []string
+	RemoveTags []string
+}
+
+func deleteExpenses(storage domain.ExpenseStorage, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	expenses, err := storage.Query(domain.ExpenseFilter{Ids: ids})
+
+	if err != nil {
+		return fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	if err = ensureAllExist(expenses, ids); err != nil {
+		return err
+	}
+
+	err = storage.Delete(ids...)
+
+	if err != nil {
+		return fmt.Errorf("Error saving expenses: %w", err)
+	}
+
+	return nil
+}
+
+func updateExpenses(storage domain.ExpenseStorage, ids []int, change BulkChange) ([]domain.Expense, error) {
+	if len(ids) == 0 {
+		return []domain.Expense{}, nil
+	}
+
+	expenses, err := storage.Query(domain.ExpenseFilter{Ids: ids})
+
+	if err != nil {
+		return nil, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	if err = ensureAllExist(expenses, ids); err != nil {
+		return nil, err
+	}
+
+	for i := range expenses {
+		if change.Category != "" {
+			expenses[i].Category = domain.NormalizeCategory(change.Category)
+			expenses[i].Splits = nil
+		}
+
+		if change.ShiftDays != 0 {
+			expenses[i].SpentAt = expenses[i].SpentAt.AddDate(0, 0, change.ShiftDays)
+		}
+
+		if len(change.AddTags) > 0 || len(change.RemoveTags) > 0 {
+			tags := append(slices.Clone(expenses[i].Tags), change.AddTags...)
+			removed := domain.NormalizeTags(change.RemoveTags)
+
+			expenses[i].Tags = domain.NormalizeTags(lo.Reject(tags, func(tag string, _ int) bool {
+				return slices.Contains(removed, domain.NormalizeTag(tag))
+			}))
+		}
+	}
+
+	err = storage.Update(expenses...)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error saving expenses: %w", err)
+	}
+
+	return expenses, nil
+}
+
+func ensureAllExist(expenses []domain.Expense, ids []int) error {
+	existing := lo.SliceToMap(expenses, func(e domain.Expense) (int, struct{}) {
+		return e.Id, struct{}{}
+	})
+
+	for _, id := range ids {
+		if _, ok := existing[id]; !ok {
+			return &ExpenseNotFoundError{ID: id}
+		}
+	}
+
+	return nil
+}
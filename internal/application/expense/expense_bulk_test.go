@@ -0,0 +1,291 @@
+package expense
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense/mocks"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDeleteExpenses(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	type testCase struct {
+		name        string
+		storageFn   func(t *testing.T) domain.ExpenseStorage
+		ids         []int
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Successful deletion",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Description: "Coffee", Amount: 3.5},
+					{Id: 2, Description: "Lunch", Amount: 12.0},
+					{Id: 3, Description: "Dinner", Amount: 20.0},
+					{Id: 4, Description: "Snacks", Amount: 5.0},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				firstCall := result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+				result.EXPECT().Delete(2, 3).Return(nil).Times(1).After(firstCall)
+
+				return result
+			},
+			ids:         []int{2, 3},
+			expectedErr: nil,
+		},
+		{
+			name: "No ids",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				return mocks.NewMockExpenseStorage(ctrl)
+			},
+			ids:         []int{},
+			expectedErr: nil,
+		},
+		{
+			name: "One of expenses not found",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Description: "Coffee", Amount: 3.5},
+					{Id: 2, Description: "Lunch", Amount: 12.0},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			ids:         []int{1, 54},
+			expectedErr: &ExpenseNotFoundError{ID: 54},
+		},
+		{
+			name: "Load error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+				return result
+			},
+			ids:         []int{1},
+			expectedErr: assert.AnError,
+		},
+		{
+			name: "Save error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Description: "Subscription", Amount: 9.99},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				firstCall := result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+				result.EXPECT().Delete(1).Return(assert.AnError).Times(1).After(firstCall)
+
+				return result
+			},
+			ids:         []int{1},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := tt.storageFn(t)
+			err := deleteExpenses(mockStorage, tt.ids)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateExpenses(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	type testCase struct {
+		name             string
+		storageFn        func(t *testing.T) domain.ExpenseStorage
+		ids              []int
+		change           BulkChange
+		expectedExpenses []domain.Expense
+		expectedErr      error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Change category",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Description: "Coffee", Category: "Food", Amount: 3.5},
+					{Id: 2, Description: "Taxi", Category: "Food", Amount: 12.0},
+					{Id: 3, Description: "Bus", Category: "Food", Amount: 2.0},
+				}
+
+				resExpenses := []domain.Expense{
+					{Id: 1, Description: "Coffee", Category: "Food", Amount: 3.5},
+					{Id: 2, Description: "Taxi", Category: "Transport", Amount: 12.0},
+					{Id: 3, Description: "Bus", Category: "Transport", Amount: 2.0},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				firstCall := result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+				result.EXPECT().Update(resExpenses[1], resExpenses[2]).Return(nil).Times(1).After(firstCall)
+
+				return result
+			},
+			ids:    []int{2, 3},
+			change: BulkChange{Category: "Transport"},
+			expectedExpenses: []domain.Expense{
+				{Id: 2, Description: "Taxi", Category: "Transport", Amount: 12.0},
+				{Id: 3, Description: "Bus", Category: "Transport", Amount: 2.0},
+			},
+		},
+		{
+			name: "Shift dates",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Description: "Rent", Category: "Home", Amount: 500, SpentAt: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+					{Id: 2, Description: "Water", Category: "Home", Amount: 20, SpentAt: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+				}
+
+				resExpenses := []domain.Expense{
+					{Id: 1, Description: "Rent", Category: "Home", Amount: 500, SpentAt: time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC)},
+					currentExpenses[1],
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				firstCall := result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+				result.EXPECT().Update(resExpenses[0]).Return(nil).Times(1).After(firstCall)
+
+				return result
+			},
+			ids:    []int{1},
+			change: BulkChange{ShiftDays: 2},
+			expectedExpenses: []domain.Expense{
+				{Id: 1, Description: "Rent", Category: "Home", Amount: 500, SpentAt: time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name: "Add and remove tags",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Description: "Hotel", Amount: 300, Tags: []string{"personal", "trip-lisbon"}},
+					{Id: 2, Description: "Taxi", Amount: 25},
+				}
+
+				resExpenses := []domain.Expense{
+					{Id: 1, Description: "Hotel", Amount: 300, Tags: []string{"reimbursable", "trip-lisbon", "work"}},
+					{Id: 2, Description: "Taxi", Amount: 25, Tags: []string{"reimbursable", "work"}},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				firstCall := result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+				result.EXPECT().Update(resExpenses[0], resExpenses[1]).Return(nil).Times(1).After(firstCall)
+
+				return result
+			},
+			ids:    []int{1, 2},
+			change: BulkChange{AddTags: []string{"Work", " reimbursable"}, RemoveTags: []string{"PERSONAL"}},
+			expectedExpenses: []domain.Expense{
+				{Id: 1, Description: "Hotel", Amount: 300, Tags: []string{"reimbursable", "trip-lisbon", "work"}},
+				{Id: 2, Description: "Taxi", Amount: 25, Tags: []string{"reimbursable", "work"}},
+			},
+		},
+		{
+			name: "Expense not found",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Description: "Coffee", Amount: 3.5},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			ids:         []int{1, 2},
+			change:      BulkChange{Category: "Food"},
+			expectedErr: &ExpenseNotFoundError{ID: 2},
+		},
+		{
+			name: "Load error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+				return result
+			},
+			ids:         []int{1},
+			change:      BulkChange{Category: "Food"},
+			expectedErr: assert.AnError,
+		},
+		{
+			name: "Save error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Description: "Coffee", Category: "Drinks", Amount: 3.5},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				firstCall := result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+				result.EXPECT().Update(gomock.Any()).Return(assert.AnError).Times(1).After(firstCall)
+
+				return result
+			},
+			ids:         []int{1},
+			change:      BulkChange{Category: "Food"},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := tt.storageFn(t)
+			result, err := updateExpenses(mockStorage, tt.ids, tt.change)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedExpenses, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package expense
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/samber/lo"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyCategory     = errors.New("category name cannot be empty")
+	ErrCategoryIntoChild = errors.New("a category cannot be moved into its own subcategory")
+)
+
+// CategoryStats holds the number of expenses and their total for a category, including all its subcategories.
+// Every split line of an expense is counted under its own category.
+type CategoryStats struct {
+	Name  string
+	Depth int
+	Count int
+	Total float64
+}
+
+func getCategoriesStats(storage domain.ExpenseStorage) ([]CategoryStats, error) {
+	expenses, err := querySpending(storage, domain.ExpenseFilter{})
+
+	if err != nil {
+		return nil, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	statsByName := make(map[string]CategoryStats)
+
+	for _, e := range domain.SplitParts(expenses) {
+		for depth := 1; depth <= max(domain.CategoryDepth(e.Category), 1); depth++ {
+			name := domain.CategoryAtDepth(e.Category, depth)
+
+			stats := statsByName[name]
+			stats.Name = name
+			stats.Depth = depth
+			stats.Count++
+			stats.Total += e.Amount
+			statsByName[name] = stats
+		}
+	}
+
+	stats := lo.Values(statsByName)
+	slices.SortFunc(stats, func(a, b CategoryStats) int {
+		return slices.Compare(domain.CategoryLevels(a.Name), domain.CategoryLevels(b.Name))
+	})
+
+	return stats, nil
+}
+
+// getCategoryBreakdown sums the expenses of a month per category, rolled up to the given depth.
+// A non-positive depth keeps the categories as they are.
+func getCategoryBreakdown(storage domain.ExpenseStorage, year int, month time.Month, depth int, loc *time.Location) (map[string]float64, error) {
+	expenses, err := querySpending(storage, domain.MonthFilter(year, month, loc))
+
+	if err != nil {
+		return nil, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	breakdown := make(map[string]float64)
+
+	for _, e := range domain.SplitParts(expenses) {
+		breakdown[domain.CategoryAtDepth(e.Category, depth)] += e.Amount
+	}
+
+	return breakdown, nil
+}
+
+// renameCategory renames a category and its subcategories on every expense. Renaming into another existing
+// category is refused, mergeCategories should be used for that.
+func renameCategory(storage domain.ExpenseStorage, oldName string, newName string) (int, error) {
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return 0, ErrEmptyCategory
+	}
+
+	expenses, err := storage.Query(domain.ExpenseFilter{})
+
+	if err != nil {
+		return 0, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	if !hasCategory(expenses, oldName) {
+		return 0, &CategoryNotFoundError{Name: oldName}
+	}
+
+	if domain.NormalizeCategory(newName) != domain.NormalizeCategory(oldName) && hasCategory(expenses, newName) {
+		return 0, &CategoryExistsError{Name: newName}
+	}
+
+	return reassignCategories(storage, expenses, []string{oldName}, newName)
+}
+
+// mergeCategories moves every expense of the source categories into an existing target category.
+// Subcategories of a source are kept below the target.
+func mergeCategories(storage domain.ExpenseStorage, sources []string, target string) (int, error) {
+	expenses, err := storage.Query(domain.ExpenseFilter{})
+
+	if err != nil {
+		return 0, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	for _, name := range append(slices.Clone(sources), target) {
+		if !hasCategory(expenses, name) {
+			return 0, &CategoryNotFoundError{Name: name}
+		}
+	}
+
+	return reassignCategories(storage, expenses, sources, target)
+}
+
+// deleteCategory removes a category by moving its expenses into reassignTo, which may be a new category.
+// Subcategories of the deleted category are kept below reassignTo.
+func deleteCategory(storage domain.ExpenseStorage, name string, reassignTo string) (int, error) {
+	reassignTo = strings.TrimSpace(reassignTo)
+	if reassignTo == "" {
+		return 0, ErrEmptyCategory
+	}
+
+	expenses, err := storage.Query(domain.ExpenseFilter{})
+
+	if err != nil {
+		return 0, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	if !hasCategory(expenses, name) {
+		return 0, &CategoryNotFoundError{Name: name}
+	}
+
+	return reassignCategories(storage, expenses, []string{name}, reassignTo)
+}
+
+// reassignCategories moves the categories in from, with their subcategories, under to. Moving a category
+// into one of its own subcategories is refused, as it would nest the subcategory into itself.
+func reassignCategories(storage domain.ExpenseStorage, expenses []domain.Expense, from []string, to string) (int, error) {
+	for _, source := range from {
+		if domain.IsCategoryWithin(to, source) && domain.NormalizeCategory(to) != domain.NormalizeCategory(source) {
+			return 0, fmt.Errorf("%w: %s is within %s", ErrCategoryIntoChild, to, source)
+		}
+	}
+
+	affected := make([]domain.Expense, 0)
+
+	for _, e := range expenses {
+		changed := false
+		reassign := func(category string) string {
+			source, found := lo.Find(from, func(source string) bool {
+				return domain.IsCategoryWithin(category, source)
+			})
+
+			if !found {
+				return category
+			}
+
+			changed = true
+			return domain.ReparentCategory(category, source, to)
+		}
+
+		e.Category = reassign(e.Category)
+		e.Splits = slices.Clone(e.Splits)
+
+		for i := range e.Splits {
+			e.Splits[i].Category = reassign(e.Splits[i].Category)
+		}
+
+		if changed {
+			affected = append(affected, e)
+		}
+	}
+
+	err := storage.Update(affected...)
+
+	if err != nil {
+		return 0, fmt.Errorf("Error saving expenses: %w", err)
+	}
+
+	return len(affected), nil
+}
+
+func hasCategory(expenses []domain.Expense, name string) bool {
+	return lo.ContainsBy(expenses, func(e domain.Expense) bool {
+		return e.IsWithinAnyCategory(name)
+	})
+}
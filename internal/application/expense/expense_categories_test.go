@@ -0,0 +1,607 @@
+package expense
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense/mocks"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetCategoriesStats(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	type testCase struct {
+		name          string
+		storageFn     func(t *testing.T) domain.ExpenseStorage
+		expectedStats []CategoryStats
+		expectedErr   error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Counts and totals per category",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Category: "Food", Amount: 3.5},
+					{Id: 2, Category: "Transport", Amount: 12.0},
+					{Id: 3, Category: "Food", Amount: 20.0},
+					{Id: 4, Category: "food ", Amount: 5.0},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			expectedStats: []CategoryStats{
+				{Name: "Food", Depth: 1, Count: 2, Total: 23.5},
+				{Name: "Transport", Depth: 1, Count: 1, Total: 12.0},
+				{Name: "food", Depth: 1, Count: 1, Total: 5.0},
+			},
+		},
+		{
+			name: "Subcategories roll up into parents",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Category: "Food:Groceries", Amount: 40},
+					{Id: 2, Category: "Food:Restaurants", Amount: 25},
+					{Id: 3, Category: "Food", Amount: 5},
+					{Id: 4, Category: "Home:Utilities:Water", Amount: 30},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			expectedStats: []CategoryStats{
+				{Name: "Food", Depth: 1, Count: 3, Total: 70},
+				{Name: "Food:Groceries", Depth: 2, Count: 1, Total: 40},
+				{Name: "Food:Restaurants", Depth: 2, Count: 1, Total: 25},
+				{Name: "Home", Depth: 1, Count: 1, Total: 30},
+				{Name: "Home:Utilities", Depth: 2, Count: 1, Total: 30},
+				{Name: "Home:Utilities:Water", Depth: 3, Count: 1, Total: 30},
+			},
+		},
+		{
+			name: "Split lines count under their own categories",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Category: "Food", Amount: 50, Splits: []domain.Split{
+						{Category: "Food", Amount: 35},
+						{Category: "Household", Amount: 15},
+					}},
+					{Id: 2, Category: "Household", Amount: 10},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			expectedStats: []CategoryStats{
+				{Name: "Food", Depth: 1, Count: 1, Total: 35},
+				{Name: "Household", Depth: 1, Count: 2, Total: 25},
+			},
+		},
+		{
+			name: "Load error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+				return result
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := tt.storageFn(t)
+			result, err := getCategoriesStats(mockStorage)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStats, result)
+			}
+		})
+	}
+}
+
+func TestGetCategoryBreakdown(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	currentExpenses := []domain.Expense{
+		{Id: 1, Category: "Food:Groceries", Amount: 40, SpentAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{Id: 2, Category: "Food:Restaurants", Amount: 25, SpentAt: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{Id: 3, Category: "Transport", Amount: 10, SpentAt: time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)},
+		{Id: 4, Category: "Food:Groceries", Amount: 99, SpentAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	type testCase struct {
+		name              string
+		storageFn         func(t *testing.T) domain.ExpenseStorage
+		depth             int
+		expectedBreakdown map[string]float64
+		expectedErr       error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Rolled up to parents",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			depth: 1,
+			expectedBreakdown: map[string]float64{
+				"Food":      65,
+				"Transport": 10,
+			},
+		},
+		{
+			name: "Drilled down to leaves",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			depth: 0,
+			expectedBreakdown: map[string]float64{
+				"Food:Groceries":   40,
+				"Food:Restaurants": 25,
+				"Transport":        10,
+			},
+		},
+		{
+			name: "Load error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+				return result
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := tt.storageFn(t)
+			result, err := getCategoryBreakdown(mockStorage, 2024, time.January, tt.depth, time.UTC)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedBreakdown, result)
+			}
+		})
+	}
+}
+
+func TestRenameCategory(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	type testCase struct {
+		name             string
+		storageFn        func(t *testing.T) domain.ExpenseStorage
+		oldName          string
+		newName          string
+		expectedAffected int
+		expectedErr      error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Successful rename",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Category: "Fod", Amount: 3.5},
+					{Id: 2, Category: "Transport", Amount: 12.0},
+					{Id: 3, Category: "Fod", Amount: 20.0},
+				}
+
+				resExpenses := []domain.Expense{
+					{Id: 1, Category: "Food", Amount: 3.5},
+					{Id: 2, Category: "Transport", Amount: 12.0},
+					{Id: 3, Category: "Food", Amount: 20.0},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				firstCall := result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+				result.EXPECT().Update(resExpenses[0], resExpenses[2]).Return(nil).Times(1).After(firstCall)
+
+				return result
+			},
+			oldName:          "Fod",
+			newName:          " Food ",
+			expectedAffected: 2,
+		},
+		{
+			name: "Rename a parent with its subcategories",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Category: "Food:Groceries"},
+					{Id: 2, Category: "Food"},
+					{Id: 3, Category: "Foodstuff"},
+				}
+
+				resExpenses := []domain.Expense{
+					{Id: 1, Category: "Meals:Groceries"},
+					{Id: 2, Category: "Meals"},
+					{Id: 3, Category: "Foodstuff"},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				firstCall := result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+				result.EXPECT().Update(resExpenses[0], resExpenses[1]).Return(nil).Times(1).After(firstCall)
+
+				return result
+			},
+			oldName:          "Food",
+			newName:          "Meals",
+			expectedAffected: 2,
+		},
+		{
+			name: "Rename split lines",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Category: "Food", Amount: 50, Splits: []domain.Split{
+						{Category: "Food", Amount: 35},
+						{Category: "Household", Amount: 15},
+					}},
+					{Id: 2, Category: "Food", Amount: 20},
+				}
+
+				resExpenses := []domain.Expense{
+					{Id: 1, Category: "Food", Amount: 50, Splits: []domain.Split{
+						{Category: "Food", Amount: 35},
+						{Category: "Home", Amount: 15},
+					}},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				firstCall := result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+				result.EXPECT().Update(resExpenses[0]).Return(nil).Times(1).After(firstCall)
+
+				return result
+			},
+			oldName:          "Household",
+			newName:          "Home",
+			expectedAffected: 1,
+		},
+		{
+			name: "Category not found",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver([]domain.Expense{{Id: 1, Category: "Food"}})).Times(1)
+
+				return result
+			},
+			oldName:     "Fod",
+			newName:     "Meals",
+			expectedErr: &CategoryNotFoundError{Name: "Fod"},
+		},
+		{
+			name: "Target already exists",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Category: "Fod"},
+					{Id: 2, Category: "Food"},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			oldName:     "Fod",
+			newName:     "Food",
+			expectedErr: &CategoryExistsError{Name: "Food"},
+		},
+		{
+			name: "Empty new name",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				return mocks.NewMockExpenseStorage(ctrl)
+			},
+			oldName:     "Food",
+			newName:     "  ",
+			expectedErr: ErrEmptyCategory,
+		},
+		{
+			name: "Save error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				firstCall := result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver([]domain.Expense{{Id: 1, Category: "Fod"}})).Times(1)
+				result.EXPECT().Update(gomock.Any()).Return(assert.AnError).Times(1).After(firstCall)
+
+				return result
+			},
+			oldName:     "Fod",
+			newName:     "Food",
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := tt.storageFn(t)
+			affected, err := renameCategory(mockStorage, tt.oldName, tt.newName)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedAffected, affected)
+			}
+		})
+	}
+}
+
+func TestMergeCategories(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	type testCase struct {
+		name             string
+		storageFn        func(t *testing.T) domain.ExpenseStorage
+		sources          []string
+		target           string
+		expectedAffected int
+		expectedErr      error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Successful merge",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Category: "Food"},
+					{Id: 2, Category: "food "},
+					{Id: 3, Category: "Fod"},
+					{Id: 4, Category: "Transport"},
+				}
+
+				resExpenses := []domain.Expense{
+					{Id: 1, Category: "Food"},
+					{Id: 2, Category: "Food"},
+					{Id: 3, Category: "Food"},
+					{Id: 4, Category: "Transport"},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				firstCall := result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+				result.EXPECT().Update(resExpenses[1], resExpenses[2]).Return(nil).Times(1).After(firstCall)
+
+				return result
+			},
+			sources:          []string{"food ", "Fod"},
+			target:           "Food",
+			expectedAffected: 2,
+		},
+		{
+			name: "Merge keeps subcategories below the target",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Category: "Fod:Snacks"},
+					{Id: 2, Category: "Food:Groceries"},
+				}
+
+				resExpenses := []domain.Expense{
+					{Id: 1, Category: "Food:Snacks"},
+					{Id: 2, Category: "Food:Groceries"},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				firstCall := result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+				result.EXPECT().Update(resExpenses[0]).Return(nil).Times(1).After(firstCall)
+
+				return result
+			},
+			sources:          []string{"Fod"},
+			target:           "Food",
+			expectedAffected: 1,
+		},
+		{
+			name: "Merge into own subcategory",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Category: "Food"},
+					{Id: 2, Category: "Food:Groceries"},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			sources:     []string{"Food"},
+			target:      "Food:Groceries",
+			expectedErr: ErrCategoryIntoChild,
+		},
+		{
+			name: "Target not found",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver([]domain.Expense{{Id: 1, Category: "Fod"}})).Times(1)
+
+				return result
+			},
+			sources:     []string{"Fod"},
+			target:      "Food",
+			expectedErr: &CategoryNotFoundError{Name: "Food"},
+		},
+		{
+			name: "Load error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+				return result
+			},
+			sources:     []string{"Fod"},
+			target:      "Food",
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := tt.storageFn(t)
+			affected, err := mergeCategories(mockStorage, tt.sources, tt.target)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedAffected, affected)
+			}
+		})
+	}
+}
+
+func TestDeleteCategory(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	type testCase struct {
+		name             string
+		storageFn        func(t *testing.T) domain.ExpenseStorage
+		category         string
+		reassignTo       string
+		expectedAffected int
+		expectedErr      error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Reassign to a new category",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Category: "Misc"},
+					{Id: 2, Category: "Food"},
+				}
+
+				resExpenses := []domain.Expense{
+					{Id: 1, Category: "Other"},
+					{Id: 2, Category: "Food"},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				firstCall := result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+				result.EXPECT().Update(resExpenses[0]).Return(nil).Times(1).After(firstCall)
+
+				return result
+			},
+			category:         "Misc",
+			reassignTo:       "Other",
+			expectedAffected: 1,
+		},
+		{
+			name: "Category not found",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver([]domain.Expense{{Id: 1, Category: "Food"}})).Times(1)
+
+				return result
+			},
+			category:    "Misc",
+			reassignTo:  "Food",
+			expectedErr: &CategoryNotFoundError{Name: "Misc"},
+		},
+		{
+			name: "Empty reassign target",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				return mocks.NewMockExpenseStorage(ctrl)
+			},
+			category:    "Misc",
+			reassignTo:  "",
+			expectedErr: ErrEmptyCategory,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := tt.storageFn(t)
+			affected, err := deleteCategory(mockStorage, tt.category, tt.reassignTo)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedAffected, affected)
+			}
+		})
+	}
+}
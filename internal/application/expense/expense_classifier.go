@@ -0,0 +1,118 @@
+package expense
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// CategoryPrediction is a category guessed for a description with the probability the classifier gives it.
+type CategoryPrediction struct {
+	Category   string
+	Confidence float64
+}
+
+// Classifier is a naive Bayes model over description words, trained on the categories of existing expenses.
+type Classifier struct {
+	categoryCounts map[string]int
+	wordCounts     map[string]map[string]int
+	wordTotals     map[string]int
+	vocabulary     map[string]struct{}
+	documents      int
+}
+
+// trainClassifier builds a classifier from every categorized expense.
+func trainClassifier(storage domain.ExpenseStorage) (*Classifier, error) {
+	expenses, err := querySpending(storage, domain.ExpenseFilter{})
+
+	if err != nil {
+		return nil, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	c := &Classifier{
+		categoryCounts: make(map[string]int),
+		wordCounts:     make(map[string]map[string]int),
+		wordTotals:     make(map[string]int),
+		vocabulary:     make(map[string]struct{}),
+	}
+
+	for _, e := range expenses {
+		words := descriptionWords(e.Description)
+
+		if domain.IsUncategorized(e.Category) || len(words) == 0 {
+			continue
+		}
+
+		if c.wordCounts[e.Category] == nil {
+			c.wordCounts[e.Category] = make(map[string]int)
+		}
+
+		c.documents++
+		c.categoryCounts[e.Category]++
+
+		for _, word := range words {
+			c.wordCounts[e.Category][word]++
+			c.wordTotals[e.Category]++
+			c.vocabulary[word] = struct{}{}
+		}
+	}
+
+	return c, nil
+}
+
+// Predict returns the most likely category of a description. Nothing is predicted when none of
+// the words of the description were seen during training.
+func (c *Classifier) Predict(description string) (CategoryPrediction, bool) {
+	words := descriptionWords(description)
+
+	known := false
+	for _, word := range words {
+		if _, ok := c.vocabulary[word]; ok {
+			known = true
+			break
+		}
+	}
+
+	if !known {
+		return CategoryPrediction{}, false
+	}
+
+	scores := make(map[string]float64, len(c.categoryCounts))
+	best := CategoryPrediction{}
+	bestScore := math.Inf(-1)
+
+	for category, count := range c.categoryCounts {
+		score := math.Log(float64(count) / float64(c.documents))
+		denominator := float64(c.wordTotals[category] + len(c.vocabulary))
+
+		for _, word := range words {
+			score += math.Log(float64(c.wordCounts[category][word]+1) / denominator)
+		}
+
+		scores[category] = score
+
+		if score > bestScore || (score == bestScore && category < best.Category) {
+			bestScore = score
+			best.Category = category
+		}
+	}
+
+	// softmax of the log scores, shifted by the best one to stay in range
+	total := 0.0
+	for _, score := range scores {
+		total += math.Exp(score - bestScore)
+	}
+
+	best.Confidence = 1 / total
+
+	return best, true
+}
+
+// descriptionWords splits a description into lower-cased words, skipping numbers and punctuation.
+func descriptionWords(description string) []string {
+	return strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+}
@@ -0,0 +1,101 @@
+package expense
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense/mocks"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestClassifierPredict(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	history := []domain.Expense{
+		{Id: 1, Description: "TESCO STORES 1234", Category: "Food:Groceries"},
+		{Id: 2, Description: "Tesco express", Category: "Food:Groceries"},
+		{Id: 3, Description: "Lidl", Category: "Food:Groceries"},
+		{Id: 4, Description: "UBER *TRIP", Category: "Transport"},
+		{Id: 5, Description: "Uber trip to airport", Category: "Transport"},
+		{Id: 6, Description: "Uber eats pizza", Category: "Food:Delivery"},
+		{Id: 7, Description: "Mystery", Category: "-"},
+	}
+
+	type testCase struct {
+		name               string
+		description        string
+		expectedPrediction string
+		minConfidence      float64
+		expectedFound      bool
+	}
+
+	testCases := []testCase{
+		{
+			name:               "Known payee",
+			description:        "TESCO STORES 5678",
+			expectedPrediction: "Food:Groceries",
+			minConfidence:      0.8,
+			expectedFound:      true,
+		},
+		{
+			name:               "Words decide between categories of the same payee",
+			description:        "uber eats",
+			expectedPrediction: "Food:Delivery",
+			minConfidence:      0.35,
+			expectedFound:      true,
+		},
+		{
+			name:               "Most frequent use of a word",
+			description:        "uber",
+			expectedPrediction: "Transport",
+			minConfidence:      0.5,
+			expectedFound:      true,
+		},
+		{
+			name:          "Unknown words",
+			description:   "Cinema tickets",
+			expectedFound: false,
+		},
+		{
+			name:          "Uncategorized expenses are not learned",
+			description:   "Mystery",
+			expectedFound: false,
+		},
+	}
+
+	storage := mocks.NewMockExpenseStorage(ctrl)
+	storage.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(history)).Times(1)
+
+	classifier, err := trainClassifier(storage)
+	assert.NoError(t, err)
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			prediction, found := classifier.Predict(tt.description)
+
+			assert.Equal(t, tt.expectedFound, found)
+
+			if tt.expectedFound {
+				assert.Equal(t, tt.expectedPrediction, prediction.Category)
+				assert.GreaterOrEqual(t, prediction.Confidence, tt.minConfidence)
+				assert.LessOrEqual(t, prediction.Confidence, 1.0)
+			}
+		})
+	}
+}
+
+func TestTrainClassifierLoadError(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	storage := mocks.NewMockExpenseStorage(ctrl)
+	storage.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+	_, err := trainClassifier(storage)
+
+	assert.ErrorIs(t, err, assert.AnError)
+}
@@ -0,0 +1,121 @@
+package expense
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"math"
+	"slices"
+	"time"
+)
+
+// Comparison holds the spending of a month next to the previous month and the same month a year before.
+type Comparison struct {
+	Name     string
+	Current  float64
+	Previous float64
+	LastYear float64
+}
+
+// PreviousChange returns how much more was spent than in the previous month.
+func (c Comparison) PreviousChange() float64 {
+	return c.Current - c.Previous
+}
+
+// LastYearChange returns how much more was spent than in the same month last year.
+func (c Comparison) LastYearChange() float64 {
+	return c.Current - c.LastYear
+}
+
+// PreviousChangePercent returns the change against the previous month in percent, false when nothing was spent then.
+func (c Comparison) PreviousChangePercent() (float64, bool) {
+	return changePercent(c.Current, c.Previous)
+}
+
+// LastYearChangePercent returns the change against the same month last year in percent, false when nothing was spent then.
+func (c Comparison) LastYearChangePercent() (float64, bool) {
+	return changePercent(c.Current, c.LastYear)
+}
+
+// ComparisonReport compares a month per category. Categories are ordered from the biggest mover against
+// the previous month, up or down, to the smallest.
+type ComparisonReport struct {
+	Year       int
+	Month      time.Month
+	Total      Comparison
+	Categories []Comparison
+}
+
+// BiggestMovers returns up to n categories that changed the most against the previous month, none when n
+// is not positive.
+func (r ComparisonReport) BiggestMovers(n int) []Comparison {
+	movers := r.Categories[:min(max(n, 0), len(r.Categories))]
+
+	return slices.DeleteFunc(slices.Clone(movers), func(c Comparison) bool {
+		return c.PreviousChange() == 0
+	})
+}
+
+// getComparison compares the spending per category, rolled up to depth, of a month with the month before
+// and with the same month of the previous year.
+func getComparison(storage domain.ExpenseStorage, year int, month time.Month, depth int, loc *time.Location) (ComparisonReport, error) {
+	expenses, err := querySpending(storage, domain.ExpenseFilter{})
+
+	if err != nil {
+		return ComparisonReport{}, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	current := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	previous := current.AddDate(0, -1, 0)
+	lastYear := current.AddDate(-1, 0, 0)
+
+	report := ComparisonReport{Year: year, Month: month, Total: Comparison{Name: "Total"}}
+	byName := make(map[string]*Comparison)
+
+	for _, e := range domain.SplitParts(expenses) {
+		name := domain.CategoryAtDepth(e.Category, depth)
+
+		c := byName[name]
+		if c == nil {
+			c = &Comparison{Name: name}
+		}
+
+		switch {
+		case e.IsInMonth(current.Year(), current.Month(), loc):
+			c.Current += e.Amount
+			report.Total.Current += e.Amount
+		case e.IsInMonth(previous.Year(), previous.Month(), loc):
+			c.Previous += e.Amount
+			report.Total.Previous += e.Amount
+		case e.IsInMonth(lastYear.Year(), lastYear.Month(), loc):
+			c.LastYear += e.Amount
+			report.Total.LastYear += e.Amount
+		default:
+			continue
+		}
+
+		byName[name] = c
+	}
+
+	for _, c := range byName {
+		report.Categories = append(report.Categories, *c)
+	}
+
+	slices.SortFunc(report.Categories, func(a, b Comparison) int {
+		return cmp.Or(
+			cmp.Compare(math.Abs(b.PreviousChange()), math.Abs(a.PreviousChange())),
+			cmp.Compare(b.Current, a.Current),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+
+	return report, nil
+}
+
+func changePercent(current float64, base float64) (float64, bool) {
+	if base == 0 {
+		return 0, false
+	}
+
+	return (current - base) / base * 100, true
+}
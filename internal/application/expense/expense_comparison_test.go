@@ -0,0 +1,107 @@
+package expense
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense/mocks"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetComparison(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	type testCase struct {
+		name           string
+		storageFn      func(t *testing.T) domain.ExpenseStorage
+		expectedReport ComparisonReport
+		expectedErr    error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Categories ordered by the biggest change",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Category: "Food:Groceries", Amount: 100, SpentAt: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+					{Id: 2, Category: "Food:Restaurants", Amount: 50, SpentAt: time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)},
+					{Id: 3, Category: "Food", Amount: 140, SpentAt: time.Date(2023, 12, 10, 0, 0, 0, 0, time.UTC)},
+					{Id: 4, Category: "Rent", Amount: 500, SpentAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+					{Id: 5, Category: "Rent", Amount: 500, SpentAt: time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)},
+					{Id: 6, Category: "Rent", Amount: 400, SpentAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+					{Id: 7, Category: "Travel", Amount: 300, SpentAt: time.Date(2023, 12, 24, 0, 0, 0, 0, time.UTC)},
+					{Id: 8, Category: "Travel", Amount: 999, SpentAt: time.Date(2023, 11, 24, 0, 0, 0, 0, time.UTC)},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			expectedReport: ComparisonReport{
+				Year:  2024,
+				Month: time.January,
+				Total: Comparison{Name: "Total", Current: 650, Previous: 940, LastYear: 400},
+				Categories: []Comparison{
+					{Name: "Travel", Current: 0, Previous: 300},
+					{Name: "Food", Current: 150, Previous: 140},
+					{Name: "Rent", Current: 500, Previous: 500, LastYear: 400},
+				},
+			},
+		},
+		{
+			name: "Load error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+				return result
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := tt.storageFn(t)
+			result, err := getComparison(mockStorage, 2024, time.January, 1, time.UTC)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedReport, result)
+			}
+		})
+	}
+}
+
+func TestComparisonChanges(t *testing.T) {
+	t.Parallel()
+
+	c := Comparison{Name: "Food", Current: 150, Previous: 100, LastYear: 0}
+
+	assert.Equal(t, 50.0, c.PreviousChange())
+	assert.Equal(t, 150.0, c.LastYearChange())
+
+	percent, ok := c.PreviousChangePercent()
+	assert.True(t, ok)
+	assert.Equal(t, 50.0, percent)
+
+	_, ok = c.LastYearChangePercent()
+	assert.False(t, ok)
+
+	report := ComparisonReport{Categories: []Comparison{c, {Name: "Rent", Current: 500, Previous: 500}}}
+	assert.Equal(t, []Comparison{c}, report.BiggestMovers(3))
+	assert.Empty(t, report.BiggestMovers(0))
+	assert.Empty(t, report.BiggestMovers(-1))
+}
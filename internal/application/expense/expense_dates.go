@@ -0,0 +1,142 @@
+package expense
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateFormat is the order in which the user types and reads day, month and year.
+type DateFormat string
+
+const (
+	ISODateFormat        DateFormat = "iso"
+	DayFirstDateFormat   DateFormat = "dmy"
+	MonthFirstDateFormat DateFormat = "mdy"
+)
+
+var (
+	numericDateRegexp = regexp.MustCompile(`^(\d{1,4})[./-](\d{1,2})[./-](\d{1,4})$`)
+	dayOffsetRegexp   = regexp.MustCompile(`^([+-]\d+)([dw])$`)
+	timeOfDayRegexp   = regexp.MustCompile(`^([01]?\d|2[0-3]):([0-5]\d)$`)
+)
+
+// Layout returns the time layout dates are shown with in this format.
+func (f DateFormat) Layout() string {
+	switch f {
+	case DayFirstDateFormat:
+		return "02.01.2006"
+	case MonthFirstDateFormat:
+		return "01/02/2006"
+	}
+
+	return "2006-01-02"
+}
+
+// Format shows a date in this format, adding the time of day in loc for expenses that have one.
+func (f DateFormat) Format(t time.Time, loc *time.Location) string {
+	if domain.IsDateOnly(t) {
+		return t.Format(f.Layout())
+	}
+
+	return t.In(loc).Format(f.Layout() + " 15:04")
+}
+
+// Hint describes the accepted input for placeholders.
+func (f DateFormat) Hint() string {
+	switch f {
+	case DayFirstDateFormat:
+		return "DD.MM.YYYY [HH:MM], today, -2d, last monday"
+	case MonthFirstDateFormat:
+		return "MM/DD/YYYY [HH:MM], today, -2d, last monday"
+	}
+
+	return "YYYY-MM-DD [HH:MM], today, -2d, last monday"
+}
+
+// parseDateTime reads a date as parseDate does, optionally followed by a time of day ("21:30") in the
+// location of now. Without a time of day the result is date-only, see domain.IsDateOnly.
+func parseDateTime(input string, format DateFormat, now time.Time) (time.Time, error) {
+	fields := strings.Fields(input)
+
+	if len(fields) < 2 {
+		return parseDate(input, format, now)
+	}
+
+	match := timeOfDayRegexp.FindStringSubmatch(fields[len(fields)-1])
+	if match == nil {
+		return parseDate(input, format, now)
+	}
+
+	date, err := parseDate(strings.Join(fields[:len(fields)-1], " "), format, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	hour, _ := strconv.Atoi(match[1])
+	minute, _ := strconv.Atoi(match[2])
+
+	return time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, now.Location()), nil
+}
+
+// parseDate understands ISO dates, dates in the preferred format with '.', '/' or '-' separators,
+// day offsets like "-2d" or "+1w" and the relative words of quick-add ("yesterday", "last fri", "3 days ago").
+func parseDate(input string, format DateFormat, now time.Time) (time.Time, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if input == "" {
+		return time.Time{}, fmt.Errorf("date cannot be empty")
+	}
+
+	if match := dayOffsetRegexp.FindStringSubmatch(input); match != nil {
+		n, _ := strconv.Atoi(match[1])
+
+		if match[2] == "w" {
+			n *= 7
+		}
+
+		return today.AddDate(0, 0, n), nil
+	}
+
+	if tokens := strings.Fields(input); len(tokens) > 0 {
+		if date, consumed, ok := parseRelativeDate(tokens, today); ok && consumed == len(tokens) {
+			return date, nil
+		}
+	}
+
+	match := numericDateRegexp.FindStringSubmatch(input)
+	if match == nil {
+		return time.Time{}, fmt.Errorf("date should look like %s", format.Hint())
+	}
+
+	first, _ := strconv.Atoi(match[1])
+	second, _ := strconv.Atoi(match[2])
+	third, _ := strconv.Atoi(match[3])
+
+	var year, month, day int
+
+	switch {
+	case len(match[1]) == 4:
+		year, month, day = first, second, third
+	case len(match[3]) != 4:
+		return time.Time{}, fmt.Errorf("year should have four digits")
+	case format == DayFirstDateFormat:
+		year, month, day = third, second, first
+	case format == MonthFirstDateFormat:
+		year, month, day = third, first, second
+	default:
+		return time.Time{}, fmt.Errorf("date should look like %s", format.Hint())
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+
+	if date.Year() != year || int(date.Month()) != month || date.Day() != day {
+		return time.Time{}, fmt.Errorf("%s is not a valid date", input)
+	}
+
+	return date, nil
+}
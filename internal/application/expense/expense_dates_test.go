@@ -0,0 +1,96 @@
+package expense
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	t.Parallel()
+
+	// a Sunday
+	now := time.Date(2026, 10, 18, 23, 10, 0, 0, time.UTC)
+
+	type testCase struct {
+		name         string
+		input        string
+		format       DateFormat
+		expectedDate time.Time
+		expectErr    bool
+	}
+
+	testCases := []testCase{
+		{name: "ISO", input: "2026-10-01", format: DayFirstDateFormat, expectedDate: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "Today", input: " Today ", format: ISODateFormat, expectedDate: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{name: "Days back", input: "-2d", format: ISODateFormat, expectedDate: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)},
+		{name: "Weeks ahead", input: "+1w", format: ISODateFormat, expectedDate: time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)},
+		{name: "Last weekday", input: "last monday", format: ISODateFormat, expectedDate: time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)},
+		{name: "Days ago", input: "3 days ago", format: ISODateFormat, expectedDate: time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "Day first", input: "18.10.2026", format: DayFirstDateFormat, expectedDate: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{name: "Day first with slashes", input: "1/2/2026", format: DayFirstDateFormat, expectedDate: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "Month first", input: "10/18/2026", format: MonthFirstDateFormat, expectedDate: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{name: "Month first rejects day first", input: "18/10/2026", format: MonthFirstDateFormat, expectErr: true},
+		{name: "Locale date without preference", input: "18.10.2026", format: ISODateFormat, expectErr: true},
+		{name: "Short year", input: "18.10.26", format: DayFirstDateFormat, expectErr: true},
+		{name: "Day out of month", input: "2026-02-30", format: ISODateFormat, expectErr: true},
+		{name: "Garbage", input: "someday", format: ISODateFormat, expectErr: true},
+		{name: "Empty", input: "", format: ISODateFormat, expectErr: true},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := parseDate(tt.input, tt.format, now)
+
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedDate, result)
+			}
+		})
+	}
+}
+
+func TestParseDateTime(t *testing.T) {
+	t.Parallel()
+
+	berlin := time.FixedZone("CEST", 2*60*60)
+	now := time.Date(2026, 10, 18, 23, 10, 0, 0, berlin)
+
+	type testCase struct {
+		name         string
+		input        string
+		format       DateFormat
+		expectedDate time.Time
+		expectErr    bool
+	}
+
+	testCases := []testCase{
+		{name: "Date only", input: "18.10.2026", format: DayFirstDateFormat, expectedDate: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{name: "Date with time", input: "18.10.2026 21:30", format: DayFirstDateFormat, expectedDate: time.Date(2026, 10, 18, 21, 30, 0, 0, berlin)},
+		{name: "Relative date with time", input: "yesterday 7:05", format: ISODateFormat, expectedDate: time.Date(2026, 10, 17, 7, 5, 0, 0, berlin)},
+		{name: "Invalid time", input: "2026-10-18 25:00", format: ISODateFormat, expectErr: true},
+		{name: "Time alone", input: "21:30", format: ISODateFormat, expectErr: true},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := parseDateTime(tt.input, tt.format, now)
+
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, tt.expectedDate.Equal(result))
+				assert.Equal(t, tt.expectedDate.Location(), result.Location())
+			}
+		})
+	}
+}
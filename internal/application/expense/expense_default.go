@@ -5,30 +5,447 @@ import (
 	"time"
 )
 
-func AddExpense(description string, category string, amount float64, spentTime time.Time) (domain.Expense, error) {
-	return addExpense(defaultExpenseStorage, spentTime, description, category, amount)
+func AddExpense(description string, category string, amount float64, spentTime time.Time, tags []string, account string, splits []domain.Split, sharing *domain.Sharing) (domain.Expense, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return domain.Expense{}, err
+	}
+
+	account, err = resolveAccount(defaultAccountStorage, account)
+	if err != nil {
+		return domain.Expense{}, err
+	}
+
+	return addCategorizedExpense(storage, defaultRuleStorage, spentTime, description, category, amount, tags, account, splits, sharing)
 }
 
 func DeleteExpense(id int) error {
-	return deleteExpense(defaultExpenseStorage, id)
+	storage, err := expenseStorage()
+	if err != nil {
+		return err
+	}
+
+	return deleteExpense(storage, id)
 }
 
-func UpdateExpense(id int, description string, category string, amount float64, spentAt time.Time) (domain.Expense, error) {
-	return updateExpense(defaultExpenseStorage, id, description, category, amount, spentAt)
+func DeleteExpenses(ids []int) error {
+	storage, err := expenseStorage()
+	if err != nil {
+		return err
+	}
+
+	return deleteExpenses(storage, ids)
+}
+
+func UpdateExpense(id int, description string, category string, amount float64, spentAt time.Time, tags []string, account string, splits []domain.Split, sharing *domain.Sharing) (domain.Expense, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return domain.Expense{}, err
+	}
+
+	account, err = resolveAccount(defaultAccountStorage, account)
+	if err != nil {
+		return domain.Expense{}, err
+	}
+
+	return updateExpense(storage, id, description, category, amount, spentAt, tags, account, splits, sharing)
+}
+
+func UpdateExpenses(ids []int, change BulkChange) ([]domain.Expense, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	return updateExpenses(storage, ids, change)
 }
 
 func GetExpense(id int) (domain.Expense, error) {
-	return getExpense(defaultExpenseStorage, id)
+	storage, err := expenseStorage()
+	if err != nil {
+		return domain.Expense{}, err
+	}
+
+	return getExpense(storage, id)
 }
 
 func GetAllExpenses() ([]domain.Expense, error) {
-	return defaultExpenseStorage.Load()
+	storage, err := expenseStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	return storage.Query(domain.ExpenseFilter{})
 }
 
 func GetAllExpensesSummary() (float64, error) {
-	return getAllExpensesSummary(defaultExpenseStorage)
+	storage, err := expenseStorage()
+	if err != nil {
+		return 0, err
+	}
+
+	return getAllExpensesSummary(storage)
 }
 
 func GetMonthlyExpensesSummary(year int, month time.Month) (float64, error) {
-	return getExpensesSummary(defaultExpenseStorage, year, month)
+	storage, err := expenseStorage()
+	if err != nil {
+		return 0, err
+	}
+
+	loc, err := getLocation()
+	if err != nil {
+		return 0, err
+	}
+
+	return getExpensesSummary(storage, year, month, loc)
+}
+
+func GetMonthlyDailyTotals(year int, month time.Month) (map[int]float64, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := getLocation()
+	if err != nil {
+		return nil, err
+	}
+
+	return getDailyTotals(storage, year, month, loc)
+}
+
+func GetMonthlySeries(months int) ([]MonthlyTotal, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := getLocation()
+	if err != nil {
+		return nil, err
+	}
+
+	return getMonthlySeries(storage, time.Now().In(loc), months, 1, loc)
+}
+
+func GetMonthlyComparison(year int, month time.Month, depth int) (ComparisonReport, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return ComparisonReport{}, err
+	}
+
+	loc, err := getLocation()
+	if err != nil {
+		return ComparisonReport{}, err
+	}
+
+	return getComparison(storage, year, month, depth, loc)
+}
+
+func GetExpenseAnomalies() ([]Anomaly, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	return getExpenseAnomalies(storage)
+}
+
+func GetMonthlyAnomalies(year int, month time.Month) ([]Anomaly, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := getLocation()
+	if err != nil {
+		return nil, err
+	}
+
+	return getMonthlyAnomalies(storage, year, month, loc)
+}
+
+func GetMonthForecast() (MonthForecast, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return MonthForecast{}, err
+	}
+
+	loc, err := getLocation()
+	if err != nil {
+		return MonthForecast{}, err
+	}
+
+	return getForecast(storage, time.Now(), loc)
+}
+
+func GetAllTags() ([]string, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	return getAllTags(storage)
+}
+
+func GetMonthlyTagsSummary(year int, month time.Month) (map[string]float64, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := getLocation()
+	if err != nil {
+		return nil, err
+	}
+
+	return getTagsSummary(storage, year, month, loc)
+}
+
+func GetCategoriesStats() ([]CategoryStats, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	return getCategoriesStats(storage)
+}
+
+func GetMonthlyCategoryBreakdown(year int, month time.Month, depth int) (map[string]float64, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := getLocation()
+	if err != nil {
+		return nil, err
+	}
+
+	return getCategoryBreakdown(storage, year, month, depth, loc)
+}
+
+func RenameCategory(oldName string, newName string) (int, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return 0, err
+	}
+
+	return renameCategory(storage, oldName, newName)
+}
+
+func MergeCategories(sources []string, target string) (int, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return 0, err
+	}
+
+	return mergeCategories(storage, sources, target)
+}
+
+func DeleteCategory(name string, reassignTo string) (int, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return 0, err
+	}
+
+	return deleteCategory(storage, name, reassignTo)
+}
+
+func GetSuggestions() (Suggestions, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return Suggestions{}, err
+	}
+
+	loc, err := getLocation()
+	if err != nil {
+		return Suggestions{}, err
+	}
+
+	return getSuggestions(storage, time.Now().In(loc))
+}
+
+func GetAccounts() ([]domain.Account, error) {
+	return getAccounts(defaultAccountStorage)
+}
+
+func AddAccount(account domain.Account) (domain.Account, error) {
+	return addAccount(defaultAccountStorage, account)
+}
+
+func UpdateAccount(name string, account domain.Account) (domain.Account, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return domain.Account{}, err
+	}
+
+	return updateAccount(storage, defaultAccountStorage, name, account)
+}
+
+func DeleteAccount(name string) error {
+	storage, err := expenseStorage()
+	if err != nil {
+		return err
+	}
+
+	return deleteAccount(storage, defaultAccountStorage, name)
+}
+
+func GetAccountBalances() ([]AccountBalance, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	return getAccountBalances(storage, defaultAccountStorage)
+}
+
+func GetAccountEntries(name string) ([]AccountEntry, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := getLocation()
+	if err != nil {
+		return nil, err
+	}
+
+	return getAccountEntries(storage, defaultAccountStorage, name, loc)
+}
+
+func AddTransfer(spentAt time.Time, from string, to string, amount float64, description string) (domain.Expense, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return domain.Expense{}, err
+	}
+
+	return addTransfer(storage, defaultAccountStorage, spentAt, from, to, amount, description)
+}
+
+func UpdateTransfer(id int, spentAt time.Time, from string, to string, amount float64, description string) (domain.Expense, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return domain.Expense{}, err
+	}
+
+	return updateTransfer(storage, defaultAccountStorage, id, spentAt, from, to, amount, description)
+}
+
+func GetMemberBalances() ([]MemberBalance, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	return getMemberBalances(storage)
+}
+
+func Settle(spentAt time.Time, from string, to string, amount float64) (domain.Expense, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return domain.Expense{}, err
+	}
+
+	return settle(storage, spentAt, from, to, amount)
+}
+
+func GetRules() ([]domain.Rule, error) {
+	return getRules(defaultRuleStorage)
+}
+
+func AddRule(rule domain.Rule) (domain.Rule, error) {
+	return addRule(defaultRuleStorage, rule)
+}
+
+func UpdateRule(rule domain.Rule) (domain.Rule, error) {
+	return updateRule(defaultRuleStorage, rule)
+}
+
+func DeleteRule(id int) error {
+	return deleteRule(defaultRuleStorage, id)
+}
+
+func PreviewRules() ([]CategoryChange, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	return previewRules(storage, defaultRuleStorage)
+}
+
+func ApplyRules() ([]CategoryChange, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	return applyRules(storage, defaultRuleStorage)
+}
+
+func TrainClassifier() (*Classifier, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	return trainClassifier(storage)
+}
+
+func ParseQuickAdd(line string) (QuickEntry, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return QuickEntry{}, err
+	}
+
+	loc, err := getLocation()
+	if err != nil {
+		return QuickEntry{}, err
+	}
+
+	return parseQuickAdd(storage, defaultRuleStorage, line, time.Now().In(loc))
+}
+
+func AddQuickEntry(entry QuickEntry) (domain.Expense, error) {
+	e := entry.Expense()
+	return AddExpense(e.Description, e.Category, e.Amount, e.SpentAt, e.Tags, e.Account, nil, nil)
+}
+
+func BackupExpenses() (domain.Backup, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return domain.Backup{}, err
+	}
+
+	settings, err := loadSettings()
+	if err != nil {
+		return domain.Backup{}, err
+	}
+
+	return backupExpenses(storage, defaultBackupStorage, time.Now(), backupRetention(settings), settings.Location())
+}
+
+func GetBackups() ([]domain.Backup, error) {
+	return getBackups(defaultBackupStorage)
+}
+
+func RestoreBackup(name string) (int, error) {
+	storage, err := expenseStorage()
+	if err != nil {
+		return 0, err
+	}
+
+	return restoreBackup(storage, defaultBackupStorage, name, time.Now())
+}
+
+func ParseDateTime(input string, format DateFormat) (time.Time, error) {
+	loc, err := getLocation()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return parseDateTime(input, format, time.Now().In(loc))
 }
@@ -0,0 +1,123 @@
+package expense
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/config"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/encryption"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/files"
+)
+
+// IsEncrypted reports whether the expenses are sealed with a passphrase and have to be unlocked first.
+func IsEncrypted() (bool, error) {
+	return files.IsEncrypted()
+}
+
+// IsLocked reports whether the expenses are encrypted and were not unlocked yet.
+func IsLocked() (bool, error) {
+	return files.IsLocked()
+}
+
+// Unlock gives the passphrase the encrypted expenses, accounts, rules and backups are read and written with.
+func Unlock(passphrase string) error {
+	err := files.Unlock([]byte(passphrase))
+	if errors.Is(err, encryption.ErrWrongPassphrase) {
+		return ErrWrongPassphrase
+	}
+
+	return err
+}
+
+// EnableEncryption seals the expenses, the accounts, the rules and the backups with a key derived from the passphrase.
+func EnableEncryption(passphrase string) error {
+	settings, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("Error loading settings: %w", err)
+	}
+
+	if settings.Storage != "" && settings.Storage != config.JSONStorage {
+		return &EncryptionUnsupportedError{Storage: settings.Storage}
+	}
+
+	encrypted, err := files.IsEncrypted()
+	if err != nil {
+		return fmt.Errorf("Error reading expenses: %w", err)
+	}
+
+	if encrypted {
+		return ErrAlreadyEncrypted
+	}
+
+	key, err := newKey(passphrase)
+	if err != nil {
+		return err
+	}
+
+	return rekey(key)
+}
+
+// DisableEncryption writes the unlocked expenses, accounts, rules and backups back in plaintext.
+func DisableEncryption() error {
+	if err := ensureEncrypted(); err != nil {
+		return err
+	}
+
+	return rekey(nil)
+}
+
+// RotatePassphrase seals the unlocked expenses, accounts, rules and backups again with a key derived from a new passphrase.
+func RotatePassphrase(passphrase string) error {
+	if err := ensureEncrypted(); err != nil {
+		return err
+	}
+
+	key, err := newKey(passphrase)
+	if err != nil {
+		return err
+	}
+
+	return rekey(key)
+}
+
+func ensureEncrypted() error {
+	encrypted, err := files.IsEncrypted()
+	if err != nil {
+		return fmt.Errorf("Error reading expenses: %w", err)
+	}
+
+	if !encrypted {
+		return ErrNotEncrypted
+	}
+
+	return nil
+}
+
+func newKey(passphrase string) (*encryption.Key, error) {
+	if passphrase == "" {
+		return nil, ErrEmptyPassphrase
+	}
+
+	key, err := encryption.NewKey([]byte(passphrase))
+	if err != nil {
+		return nil, fmt.Errorf("Error deriving key: %w", err)
+	}
+
+	return key, nil
+}
+
+// rekey rewrites the expenses, the accounts, the rules and the backups sealed with key, or in plaintext when
+// it is nil. The settings stay in plaintext, they are read before the passphrase is asked for.
+func rekey(key *encryption.Key) error {
+	paths, err := backupFiles().Paths()
+	if err != nil {
+		return fmt.Errorf("Error listing backups: %w", err)
+	}
+
+	paths = append(paths, files.GetFilePath(accountsFileName), files.GetFilePath(rulesFileName))
+
+	if err = files.Rekey(key, paths...); err != nil {
+		return fmt.Errorf("Error rewriting expenses: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package expense
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"slices"
+	"time"
+)
+
+// forecastWindowMonths is the most months before the current one the spending patterns are learned from.
+const forecastWindowMonths = 3
+
+// Forecast projects the spending of a category by the end of the current month.
+type Forecast struct {
+	Category string
+	// Actual is what has been spent in the month so far.
+	Actual float64
+	// Recurring is the expected amount of items seen every month that did not come up yet.
+	Recurring float64
+	// Variable is what is usually spent on other things in the rest of the month.
+	Variable float64
+}
+
+// Projected returns the expected total by the end of the month.
+func (f Forecast) Projected() float64 {
+	return f.Actual + f.Recurring + f.Variable
+}
+
+// MonthForecast holds the forecasts of a month per top-level category, the largest projection first.
+type MonthForecast struct {
+	Year       int
+	Month      time.Month
+	Total      Forecast
+	Categories []Forecast
+}
+
+// recurringKey identifies an item coming back every month, e.g. the rent or a subscription.
+type recurringKey struct {
+	category string
+	payee    string
+}
+
+// getForecast projects the month of now to its end from the spending so far, the recurring items still to
+// come and what was usually spent in the rest of the month in the months before. Without earlier months
+// the spending so far is extended at its daily rate.
+func getForecast(storage domain.ExpenseStorage, now time.Time, loc *time.Location) (MonthForecast, error) {
+	expenses, err := querySpending(storage, domain.ExpenseFilter{})
+
+	if err != nil {
+		return MonthForecast{}, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	today := now.In(loc)
+	current := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	// months[0] is the current month, months[i] the i-th month before it
+	months := make([][]domain.Expense, forecastWindowMonths+1)
+	recorded := false
+
+	for _, e := range domain.SplitParts(expenses) {
+		spentAt := e.LocalTime(loc)
+		i := monthsBetween(spentAt, current)
+
+		if i < 0 || i > forecastWindowMonths {
+			if i > forecastWindowMonths {
+				recorded = true
+			}
+
+			continue
+		}
+
+		months[i] = append(months[i], e)
+	}
+
+	// months before the first expense ever recorded say nothing about the usual spending
+	history := months[1:]
+	for !recorded && len(history) > 0 && len(history[len(history)-1]) == 0 {
+		history = history[:len(history)-1]
+	}
+
+	byCategory := make(map[string]*Forecast)
+	forecastOf := func(category string) *Forecast {
+		if byCategory[category] == nil {
+			byCategory[category] = &Forecast{Category: category}
+		}
+
+		return byCategory[category]
+	}
+
+	seenThisMonth := make(map[recurringKey]struct{})
+
+	for _, e := range months[0] {
+		forecastOf(domain.CategoryAtDepth(e.Category, 1)).Actual += e.Amount
+		seenThisMonth[recurringKeyOf(e)] = struct{}{}
+	}
+
+	recurring := recurringItems(history)
+
+	for key, amount := range recurring {
+		if _, ok := seenThisMonth[key]; !ok {
+			forecastOf(key.category).Recurring += amount
+		}
+	}
+
+	if len(history) == 0 {
+		elapsed := float64(today.Day())
+		remaining := float64(daysIn(current) - today.Day())
+
+		for _, f := range byCategory {
+			f.Variable = f.Actual / elapsed * remaining
+		}
+	}
+
+	for _, month := range history {
+		for _, e := range month {
+			if _, ok := recurring[recurringKeyOf(e)]; ok || e.LocalTime(loc).Day() <= today.Day() {
+				continue
+			}
+
+			forecastOf(domain.CategoryAtDepth(e.Category, 1)).Variable += e.Amount / float64(len(history))
+		}
+	}
+
+	forecast := MonthForecast{Year: current.Year(), Month: current.Month(), Total: Forecast{Category: "Total"}}
+
+	for _, f := range byCategory {
+		forecast.Categories = append(forecast.Categories, *f)
+		forecast.Total.Actual += f.Actual
+		forecast.Total.Recurring += f.Recurring
+		forecast.Total.Variable += f.Variable
+	}
+
+	slices.SortFunc(forecast.Categories, func(a, b Forecast) int {
+		return cmp.Or(cmp.Compare(b.Projected(), a.Projected()), cmp.Compare(a.Category, b.Category))
+	})
+
+	return forecast, nil
+}
+
+// recurringItems finds the items that came up in every one of at least two months, with their median amount.
+func recurringItems(months [][]domain.Expense) map[recurringKey]float64 {
+	recurring := make(map[recurringKey]float64)
+
+	if len(months) < 2 {
+		return recurring
+	}
+
+	amounts := make(map[recurringKey][]float64)
+	presence := make(map[recurringKey]int)
+
+	for _, month := range months {
+		seen := make(map[recurringKey]struct{})
+
+		for _, e := range month {
+			key := recurringKeyOf(e)
+			amounts[key] = append(amounts[key], e.Amount)
+
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				presence[key]++
+			}
+		}
+	}
+
+	for key, count := range presence {
+		if count == len(months) && key.payee != "" && len(amounts[key]) == len(months) {
+			recurring[key] = median(amounts[key])
+		}
+	}
+
+	return recurring
+}
+
+func recurringKeyOf(e domain.Expense) recurringKey {
+	return recurringKey{category: domain.CategoryAtDepth(e.Category, 1), payee: domain.Payee(e.Description)}
+}
+
+func daysIn(month time.Time) int {
+	return time.Date(month.Year(), month.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,115 @@
+package expense
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense/mocks"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetForecast(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	now := time.Date(2024, 4, 15, 12, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name             string
+		storageFn        func(t *testing.T) domain.ExpenseStorage
+		expectedForecast MonthForecast
+		expectedErr      error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Recurring items and the usual rest of the month",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				var currentExpenses []domain.Expense
+
+				for month := time.January; month <= time.March; month++ {
+					id := len(currentExpenses)
+					currentExpenses = append(currentExpenses,
+						domain.Expense{Id: id + 1, Description: "Landlord", Category: "Housing", Amount: 1000, SpentAt: time.Date(2024, month, 1, 0, 0, 0, 0, time.UTC)},
+						domain.Expense{Id: id + 2, Description: "NETFLIX.COM", Category: "Subscriptions", Amount: 15, SpentAt: time.Date(2024, month, 20, 0, 0, 0, 0, time.UTC)},
+						domain.Expense{Id: id + 3, Description: "Groceries", Category: "Food:Groceries", Amount: 50, SpentAt: time.Date(2024, month, 10, 0, 0, 0, 0, time.UTC)},
+						domain.Expense{Id: id + 4, Description: "Groceries", Category: "Food:Groceries", Amount: 60, SpentAt: time.Date(2024, month, 25, 0, 0, 0, 0, time.UTC)},
+					)
+				}
+
+				currentExpenses = append(currentExpenses,
+					domain.Expense{Id: 13, Description: "Landlord", Category: "Housing", Amount: 1000, SpentAt: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+					domain.Expense{Id: 14, Description: "Groceries", Category: "Food:Groceries", Amount: 40, SpentAt: time.Date(2024, 4, 5, 0, 0, 0, 0, time.UTC)},
+				)
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			expectedForecast: MonthForecast{
+				Year:  2024,
+				Month: time.April,
+				Total: Forecast{Category: "Total", Actual: 1040, Recurring: 15, Variable: 60},
+				Categories: []Forecast{
+					{Category: "Housing", Actual: 1000},
+					{Category: "Food", Actual: 40, Variable: 60},
+					{Category: "Subscriptions", Recurring: 15},
+				},
+			},
+		},
+		{
+			name: "Daily rate without earlier months",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Description: "Groceries", Category: "Food", Amount: 30, SpentAt: time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC)},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			expectedForecast: MonthForecast{
+				Year:       2024,
+				Month:      time.April,
+				Total:      Forecast{Category: "Total", Actual: 30, Variable: 30},
+				Categories: []Forecast{{Category: "Food", Actual: 30, Variable: 30}},
+			},
+		},
+		{
+			name: "Load error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+				return result
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := tt.storageFn(t)
+			result, err := getForecast(mockStorage, now, time.UTC)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedForecast, result)
+			}
+		})
+	}
+}
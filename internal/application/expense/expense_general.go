@@ -7,23 +7,33 @@ import (
 	"time"
 )
 
-func addExpense(storage domain.ExpenseStorage, spentTime time.Time, description string, category string, amount float64) (domain.Expense, error) {
-	expenses, err := storage.Load()
+func addExpense(storage domain.ExpenseStorage, spentTime time.Time, description string, category string, amount float64, tags []string, account string, splits []domain.Split, sharing *domain.Sharing) (domain.Expense, error) {
+	newExpense, err := splitExpense(domain.Expense{
+		Description: description,
+		Category:    domain.NormalizeCategory(category),
+		Amount:      amount,
+		SpentAt:     spentTime,
+		Tags:        domain.NormalizeTags(tags),
+		Account:     account,
+	}, splits)
 
 	if err != nil {
-		return domain.Expense{}, fmt.Errorf("Error loading expenses: %w", err)
+		return domain.Expense{}, err
 	}
 
-	newExpense := domain.Expense{
-		Id:          getNextExpenseId(expenses),
-		Description: description,
-		Category:    category,
-		Amount:      amount,
-		SpentAt:     spentTime,
+	newExpense, err = shareExpense(newExpense, sharing)
+
+	if err != nil {
+		return domain.Expense{}, err
+	}
+
+	newExpense.Id, err = storage.NextID()
+
+	if err != nil {
+		return domain.Expense{}, fmt.Errorf("Error loading expenses: %w", err)
 	}
 
-	expenses = append(expenses, newExpense)
-	err = storage.Save(expenses)
+	err = storage.Insert(newExpense)
 
 	if err != nil {
 		return domain.Expense{}, fmt.Errorf("Error saving expenses: %w", err)
@@ -32,33 +42,33 @@ func addExpense(storage domain.ExpenseStorage, spentTime time.Time, description
 	return newExpense, nil
 }
 
-func updateExpense(storage domain.ExpenseStorage, id int, description string, category string, amount float64, spentAt time.Time) (domain.Expense, error) {
-	expenses, err := storage.Load()
+func updateExpense(storage domain.ExpenseStorage, id int, description string, category string, amount float64, spentAt time.Time, tags []string, account string, splits []domain.Split, sharing *domain.Sharing) (domain.Expense, error) {
+	updatedExpense, err := getExpense(storage, id)
 
 	if err != nil {
-		return domain.Expense{}, fmt.Errorf("Error loading expenses: %w", err)
+		return domain.Expense{}, err
 	}
 
-	var updatedExpense domain.Expense
-	found := false
+	updatedExpense.Description = description
+	updatedExpense.Amount = amount
+	updatedExpense.SpentAt = spentAt
+	updatedExpense.Category = domain.NormalizeCategory(category)
+	updatedExpense.Tags = domain.NormalizeTags(tags)
+	updatedExpense.Account = account
 
-	for i := range expenses {
-		if expenses[i].Id == id {
-			expenses[i].Description = description
-			expenses[i].Amount = amount
-			expenses[i].SpentAt = spentAt
-			expenses[i].Category = category
-			updatedExpense = expenses[i]
-			found = true
-			break
-		}
+	updatedExpense, err = splitExpense(updatedExpense, splits)
+
+	if err != nil {
+		return domain.Expense{}, err
 	}
 
-	if !found {
-		return domain.Expense{}, &ExpenseNotFoundError{ID: id}
+	updatedExpense, err = shareExpense(updatedExpense, sharing)
+
+	if err != nil {
+		return domain.Expense{}, err
 	}
 
-	err = storage.Save(expenses)
+	err = storage.Update(updatedExpense)
 
 	if err != nil {
 		return domain.Expense{}, fmt.Errorf("Error saving expenses: %w", err)
@@ -68,22 +78,11 @@ func updateExpense(storage domain.ExpenseStorage, id int, description string, ca
 }
 
 func deleteExpense(storage domain.ExpenseStorage, id int) error {
-	expenses, err := storage.Load()
-
-	if err != nil {
-		return fmt.Errorf("Error loading expenses: %w", err)
-	}
-
-	_, index, found := lo.FindIndexOf(expenses, func(e domain.Expense) bool {
-		return e.Id == id
-	})
-
-	if !found {
-		return &ExpenseNotFoundError{ID: id}
+	if _, err := getExpense(storage, id); err != nil {
+		return err
 	}
 
-	expenses = append(expenses[:index], expenses[index+1:]...)
-	err = storage.Save(expenses)
+	err := storage.Delete(id)
 
 	if err != nil {
 		return fmt.Errorf("Error saving expenses: %w", err)
@@ -93,16 +92,12 @@ func deleteExpense(storage domain.ExpenseStorage, id int) error {
 }
 
 func getExpense(storage domain.ExpenseStorage, id int) (domain.Expense, error) {
-	expenses, err := storage.Load()
+	expense, found, err := storage.Get(id)
 
 	if err != nil {
 		return domain.Expense{}, fmt.Errorf("Error loading expenses: %w", err)
 	}
 
-	expense, _, found := lo.FindIndexOf(expenses, func(e domain.Expense) bool {
-		return e.Id == id
-	})
-
 	if !found {
 		return domain.Expense{}, &ExpenseNotFoundError{ID: id}
 	}
@@ -111,7 +106,7 @@ func getExpense(storage domain.ExpenseStorage, id int) (domain.Expense, error) {
 }
 
 func getAllExpensesSummary(storage domain.ExpenseStorage) (float64, error) {
-	expenses, err := storage.Load()
+	expenses, err := querySpending(storage, domain.ExpenseFilter{})
 
 	if err != nil {
 		return 0, fmt.Errorf("Error loading expenses: %w", err)
@@ -122,22 +117,35 @@ func getAllExpensesSummary(storage domain.ExpenseStorage) (float64, error) {
 	}), nil
 }
 
-func getExpensesSummary(storage domain.ExpenseStorage, year int, month time.Month) (float64, error) {
-	expenses, err := storage.Load()
+func getExpensesSummary(storage domain.ExpenseStorage, year int, month time.Month, loc *time.Location) (float64, error) {
+	expenses, err := querySpending(storage, domain.MonthFilter(year, month, loc))
 
 	if err != nil {
 		return 0, fmt.Errorf("Error loading expenses: %w", err)
 	}
 
-	filteredExpenses := lo.Filter(expenses, func(e domain.Expense, _ int) bool {
-		return e.SpentAt.Year() == year && e.SpentAt.Month() == month
-	})
-
-	return lo.SumBy(filteredExpenses, func(e domain.Expense) float64 {
+	return lo.SumBy(expenses, func(e domain.Expense) float64 {
 		return e.Amount
 	}), nil
 }
 
+// getDailyTotals sums the expenses of a month per day of the month; days without expenses are left out.
+func getDailyTotals(storage domain.ExpenseStorage, year int, month time.Month, loc *time.Location) (map[int]float64, error) {
+	expenses, err := querySpending(storage, domain.MonthFilter(year, month, loc))
+
+	if err != nil {
+		return nil, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	totals := make(map[int]float64)
+
+	for _, e := range expenses {
+		totals[e.LocalTime(loc).Day()] += e.Amount
+	}
+
+	return totals, nil
+}
+
 func getNextExpenseId(expenses []domain.Expense) int {
 	if len(expenses) == 0 {
 		return 1
@@ -4,6 +4,7 @@ import (
 	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense/mocks"
 	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
 	"github.com/golang/mock/gomock"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
@@ -78,18 +79,9 @@ func TestAddExpense(t *testing.T) {
 			storageFn: func(t *testing.T, expense domain.Expense) domain.ExpenseStorage {
 				t.Helper()
 
-				currentExpenses := []domain.Expense{
-					{Id: 1, Description: "Coffee", Category: "Food", Amount: 3.5},
-					{Id: 2, Description: "Lunch", Category: "Food", Amount: 12.0},
-				}
-
-				resExpenses := make([]domain.Expense, len(currentExpenses))
-				copy(resExpenses, currentExpenses)
-				resExpenses = append(resExpenses, expense)
-
 				result := mocks.NewMockExpenseStorage(ctrl)
-				firstCall := result.EXPECT().Load().Return(currentExpenses, nil).Times(1)
-				result.EXPECT().Save(gomock.Eq(resExpenses)).Return(nil).Times(1).After(firstCall)
+				firstCall := result.EXPECT().NextID().Return(3, nil).Times(1)
+				result.EXPECT().Insert(expense).Return(nil).Times(1).After(firstCall)
 
 				return result
 			},
@@ -103,34 +95,73 @@ func TestAddExpense(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name: "Empty storage load",
+			name: "Addition with tags",
 			storageFn: func(t *testing.T, expense domain.Expense) domain.ExpenseStorage {
 				t.Helper()
 
-				currentExpenses := []domain.Expense{}
-				resExpenses := []domain.Expense{expense}
+				result := mocks.NewMockExpenseStorage(ctrl)
+				firstCall := result.EXPECT().NextID().Return(2, nil).Times(1)
+				result.EXPECT().Insert(expense).Return(nil).Times(1).After(firstCall)
+
+				return result
+			},
+			expectedExpense: domain.Expense{
+				Id:          2,
+				Description: "Hotel",
+				Category:    "Travel",
+				Amount:      300.0,
+				Tags:        []string{"trip-lisbon", "work"},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Split expense",
+			storageFn: func(t *testing.T, expense domain.Expense) domain.ExpenseStorage {
+				t.Helper()
 
 				result := mocks.NewMockExpenseStorage(ctrl)
-				firstCall := result.EXPECT().Load().Return(currentExpenses, nil).Times(1)
-				result.EXPECT().Save(gomock.Eq(resExpenses)).Return(nil).Times(1).After(firstCall)
+				firstCall := result.EXPECT().NextID().Return(3, nil).Times(1)
+				result.EXPECT().Insert(expense).Return(nil).Times(1).After(firstCall)
 
 				return result
 			},
 			expectedExpense: domain.Expense{
-				Id:          1,
-				Description: "Groceries",
-				Category:    "Food",
-				Amount:      45.0,
+				Id:          3,
+				Description: "Supermarket",
+				Category:    "Food:Groceries",
+				Amount:      50.0,
+				Splits: []domain.Split{
+					{Category: "Food:Groceries", Amount: 35.5},
+					{Category: "Household", Amount: 14.5, Note: "detergent"},
+				},
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "Splits not adding up",
+			storageFn: func(t *testing.T, expense domain.Expense) domain.ExpenseStorage {
+				t.Helper()
+
+				return mocks.NewMockExpenseStorage(ctrl)
+			},
+			expectedExpense: domain.Expense{
+				Description: "Supermarket",
+				Category:    "Food",
+				Amount:      50.0,
+				Splits: []domain.Split{
+					{Category: "Food", Amount: 30.0},
+					{Category: "Household", Amount: 10.0},
+				},
+			},
+			expectedErr: ErrInvalidSplits,
+		},
 		{
 			name: "Load error",
 			storageFn: func(t *testing.T, expense domain.Expense) domain.ExpenseStorage {
 				t.Helper()
 
 				result := mocks.NewMockExpenseStorage(ctrl)
-				result.EXPECT().Load().Return(nil, assert.AnError).Times(1)
+				result.EXPECT().NextID().Return(0, assert.AnError).Times(1)
 
 				return result
 			},
@@ -142,17 +173,9 @@ func TestAddExpense(t *testing.T) {
 			storageFn: func(t *testing.T, expense domain.Expense) domain.ExpenseStorage {
 				t.Helper()
 
-				currentExpenses := []domain.Expense{
-					{Id: 1, Description: "Subscription", Amount: 9.99},
-				}
-				resExpenses := []domain.Expense{
-					{Id: 1, Description: "Subscription", Amount: 9.99},
-					expense,
-				}
-
 				result := mocks.NewMockExpenseStorage(ctrl)
-				firstCall := result.EXPECT().Load().Return(currentExpenses, nil).Times(1)
-				result.EXPECT().Save(gomock.Eq(resExpenses)).Return(assert.AnError).Times(1).After(firstCall)
+				firstCall := result.EXPECT().NextID().Return(2, nil).Times(1)
+				result.EXPECT().Insert(expense).Return(assert.AnError).Times(1).After(firstCall)
 
 				return result
 			},
@@ -172,7 +195,7 @@ func TestAddExpense(t *testing.T) {
 			t.Parallel()
 
 			mockStorage := tt.storageFn(t, tt.expectedExpense)
-			result, err := addExpense(mockStorage, tt.expectedExpense.SpentAt, tt.expectedExpense.Description, tt.expectedExpense.Category, tt.expectedExpense.Amount)
+			result, err := addExpense(mockStorage, tt.expectedExpense.SpentAt, tt.expectedExpense.Description, tt.expectedExpense.Category, tt.expectedExpense.Amount, tt.expectedExpense.Tags, tt.expectedExpense.Account, tt.expectedExpense.Splits, tt.expectedExpense.Sharing)
 
 			if tt.expectedErr != nil {
 				assert.ErrorIs(t, err, tt.expectedErr)
@@ -201,19 +224,11 @@ func TestUpdateExpense(t *testing.T) {
 			storageFn: func(t *testing.T, expense domain.Expense) domain.ExpenseStorage {
 				t.Helper()
 
-				currentExpenses := []domain.Expense{
-					{Id: 1, Description: "Coffee", Category: "Food", Amount: 3.5, SpentAt: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)},
-					{Id: 2, Description: "Lunch", Category: "Food", Amount: 12.0, SpentAt: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)},
-				}
-
-				resExpenses := []domain.Expense{
-					currentExpenses[0],
-					expense,
-				}
+				currentExpense := domain.Expense{Id: 2, Description: "Lunch", Category: "Food", Amount: 12.0, SpentAt: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
 
 				result := mocks.NewMockExpenseStorage(ctrl)
-				firstCall := result.EXPECT().Load().Return(currentExpenses, nil).Times(1)
-				result.EXPECT().Save(gomock.Eq(resExpenses)).Return(nil).Times(1).After(firstCall)
+				firstCall := result.EXPECT().Get(2).Return(currentExpense, true, nil).Times(1)
+				result.EXPECT().Update(expense).Return(nil).Times(1).After(firstCall)
 
 				return result
 			},
@@ -231,12 +246,8 @@ func TestUpdateExpense(t *testing.T) {
 			storageFn: func(t *testing.T, expense domain.Expense) domain.ExpenseStorage {
 				t.Helper()
 
-				currentExpenses := []domain.Expense{
-					{Id: 1, Description: "Coffee", Amount: 3.5},
-				}
-
 				result := mocks.NewMockExpenseStorage(ctrl)
-				result.EXPECT().Load().Return(currentExpenses, nil).Times(1)
+				result.EXPECT().Get(2).Return(domain.Expense{}, false, nil).Times(1)
 
 				return result
 			},
@@ -249,7 +260,7 @@ func TestUpdateExpense(t *testing.T) {
 				t.Helper()
 
 				result := mocks.NewMockExpenseStorage(ctrl)
-				result.EXPECT().Load().Return(nil, assert.AnError).Times(1)
+				result.EXPECT().Get(1).Return(domain.Expense{}, false, assert.AnError).Times(1)
 
 				return result
 			},
@@ -261,18 +272,11 @@ func TestUpdateExpense(t *testing.T) {
 			storageFn: func(t *testing.T, expense domain.Expense) domain.ExpenseStorage {
 				t.Helper()
 
-				currentExpenses := []domain.Expense{
-					{Id: 1, Description: "Subscription", Amount: 9.99},
-					{Id: 2, Description: "Book", Amount: 15.0},
-				}
-				resExpenses := []domain.Expense{
-					currentExpenses[0],
-					expense,
-				}
+				currentExpense := domain.Expense{Id: 2, Description: "Book", Amount: 15.0}
 
 				result := mocks.NewMockExpenseStorage(ctrl)
-				firstCall := result.EXPECT().Load().Return(currentExpenses, nil).Times(1)
-				result.EXPECT().Save(gomock.Eq(resExpenses)).Return(assert.AnError).Times(1).After(firstCall)
+				firstCall := result.EXPECT().Get(2).Return(currentExpense, true, nil).Times(1)
+				result.EXPECT().Update(expense).Return(assert.AnError).Times(1).After(firstCall)
 
 				return result
 			},
@@ -289,16 +293,9 @@ func TestUpdateExpense(t *testing.T) {
 			storageFn: func(t *testing.T, expense domain.Expense) domain.ExpenseStorage {
 				t.Helper()
 
-				currentExpenses := []domain.Expense{
-					{Id: 1, Description: "Groceries", Category: "Food", Amount: 50.0, SpentAt: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)},
-				}
-				resExpenses := []domain.Expense{
-					expense,
-				}
-
 				result := mocks.NewMockExpenseStorage(ctrl)
-				firstCall := result.EXPECT().Load().Return(currentExpenses, nil).Times(1)
-				result.EXPECT().Save(gomock.Eq(resExpenses)).Return(nil).Times(1).After(firstCall)
+				firstCall := result.EXPECT().Get(1).Return(expense, true, nil).Times(1)
+				result.EXPECT().Update(expense).Return(nil).Times(1).After(firstCall)
 
 				return result
 			},
@@ -319,7 +316,7 @@ func TestUpdateExpense(t *testing.T) {
 			t.Parallel()
 
 			mockStorage := tt.storageFn(t, tt.expectedExpense)
-			result, err := updateExpense(mockStorage, tt.expectedExpense.Id, tt.expectedExpense.Description, tt.expectedExpense.Category, tt.expectedExpense.Amount, tt.expectedExpense.SpentAt)
+			result, err := updateExpense(mockStorage, tt.expectedExpense.Id, tt.expectedExpense.Description, tt.expectedExpense.Category, tt.expectedExpense.Amount, tt.expectedExpense.SpentAt, tt.expectedExpense.Tags, tt.expectedExpense.Account, tt.expectedExpense.Splits, tt.expectedExpense.Sharing)
 
 			if tt.expectedErr != nil {
 				assert.ErrorIs(t, err, tt.expectedErr)
@@ -348,22 +345,9 @@ func TestDeleteExpense(t *testing.T) {
 			storageFn: func(t *testing.T) domain.ExpenseStorage {
 				t.Helper()
 
-				currentExpenses := []domain.Expense{
-					{Id: 1, Description: "Coffee", Amount: 3.5},
-					{Id: 2, Description: "Lunch", Amount: 12.0},
-					{Id: 3, Description: "Dinner", Amount: 20.0},
-					{Id: 4, Description: "Snacks", Amount: 5.0},
-				}
-
-				resExpenses := []domain.Expense{
-					currentExpenses[0],
-					currentExpenses[2],
-					currentExpenses[3],
-				}
-
 				result := mocks.NewMockExpenseStorage(ctrl)
-				firstCall := result.EXPECT().Load().Return(currentExpenses, nil).Times(1)
-				result.EXPECT().Save(gomock.Eq(resExpenses)).Return(nil).Times(1).After(firstCall)
+				firstCall := result.EXPECT().Get(2).Return(domain.Expense{Id: 2, Description: "Lunch", Amount: 12.0}, true, nil).Times(1)
+				result.EXPECT().Delete(2).Return(nil).Times(1).After(firstCall)
 
 				return result
 			},
@@ -375,13 +359,9 @@ func TestDeleteExpense(t *testing.T) {
 			storageFn: func(t *testing.T) domain.ExpenseStorage {
 				t.Helper()
 
-				currentExpenses := []domain.Expense{
-					{Id: 1, Description: "Coffee", Amount: 3.5},
-					{Id: 2, Description: "Lunch", Amount: 12.0},
-				}
-
 				result := mocks.NewMockExpenseStorage(ctrl)
-				result.EXPECT().Load().Return(currentExpenses, nil).Times(1)
+				result.EXPECT().Get(54).Return(domain.Expense{}, false, nil).Times(1)
+				result.EXPECT().Delete(gomock.Any()).Times(0)
 
 				return result
 			},
@@ -394,7 +374,7 @@ func TestDeleteExpense(t *testing.T) {
 				t.Helper()
 
 				result := mocks.NewMockExpenseStorage(ctrl)
-				result.EXPECT().Load().Return(nil, assert.AnError).Times(1)
+				result.EXPECT().Get(1).Return(domain.Expense{}, false, assert.AnError).Times(1)
 
 				return result
 			},
@@ -406,42 +386,15 @@ func TestDeleteExpense(t *testing.T) {
 			storageFn: func(t *testing.T) domain.ExpenseStorage {
 				t.Helper()
 
-				currentExpenses := []domain.Expense{
-					{Id: 1, Description: "Subscription", Amount: 9.99},
-					{Id: 2, Description: "Book", Amount: 15.0},
-				}
-				resExpenses := []domain.Expense{
-					currentExpenses[0],
-				}
-
 				result := mocks.NewMockExpenseStorage(ctrl)
-				firstCall := result.EXPECT().Load().Return(currentExpenses, nil).Times(1)
-				result.EXPECT().Save(gomock.Eq(resExpenses)).Return(assert.AnError).Times(1).After(firstCall)
+				firstCall := result.EXPECT().Get(2).Return(domain.Expense{Id: 2, Description: "Book", Amount: 15.0}, true, nil).Times(1)
+				result.EXPECT().Delete(2).Return(assert.AnError).Times(1).After(firstCall)
 
 				return result
 			},
 			expenseId:   2,
 			expectedErr: assert.AnError,
 		},
-		{
-			name: "Delete the only expense",
-			storageFn: func(t *testing.T) domain.ExpenseStorage {
-				t.Helper()
-
-				currentExpenses := []domain.Expense{
-					{Id: 1, Description: "Groceries", Amount: 50.0},
-				}
-				resExpenses := []domain.Expense{}
-
-				result := mocks.NewMockExpenseStorage(ctrl)
-				firstCall := result.EXPECT().Load().Return(currentExpenses, nil).Times(1)
-				result.EXPECT().Save(gomock.Eq(resExpenses)).Return(nil).Times(1).After(firstCall)
-
-				return result
-			},
-			expenseId:   1,
-			expectedErr: nil,
-		},
 	}
 
 	for _, tt := range testCases {
@@ -479,13 +432,8 @@ func TestGetExpense(t *testing.T) {
 			storageFn: func(t *testing.T) domain.ExpenseStorage {
 				t.Helper()
 
-				currentExpenses := []domain.Expense{
-					{Id: 1, Description: "Coffee", Amount: 3.5},
-					{Id: 2, Description: "Lunch", Amount: 12.0},
-				}
-
 				result := mocks.NewMockExpenseStorage(ctrl)
-				result.EXPECT().Load().Return(currentExpenses, nil).Times(1)
+				result.EXPECT().Get(2).Return(domain.Expense{Id: 2, Description: "Lunch", Amount: 12.0}, true, nil).Times(1)
 
 				return result
 			},
@@ -502,12 +450,8 @@ func TestGetExpense(t *testing.T) {
 			storageFn: func(t *testing.T) domain.ExpenseStorage {
 				t.Helper()
 
-				currentExpenses := []domain.Expense{
-					{Id: 1, Description: "Coffee", Amount: 3.5},
-				}
-
 				result := mocks.NewMockExpenseStorage(ctrl)
-				result.EXPECT().Load().Return(currentExpenses, nil).Times(1)
+				result.EXPECT().Get(54).Return(domain.Expense{}, false, nil).Times(1)
 
 				return result
 			},
@@ -521,7 +465,7 @@ func TestGetExpense(t *testing.T) {
 				t.Helper()
 
 				result := mocks.NewMockExpenseStorage(ctrl)
-				result.EXPECT().Load().Return(nil, assert.AnError).Times(1)
+				result.EXPECT().Get(1).Return(domain.Expense{}, false, assert.AnError).Times(1)
 
 				return result
 			},
@@ -573,7 +517,7 @@ func TestExpenseFileStorage_Load(t *testing.T) {
 				}
 
 				result := mocks.NewMockExpenseStorage(ctrl)
-				result.EXPECT().Load().Return(currentExpenses, nil).Times(1)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
 
 				return result
 			},
@@ -588,7 +532,7 @@ func TestExpenseFileStorage_Load(t *testing.T) {
 				currentExpenses := []domain.Expense{}
 
 				result := mocks.NewMockExpenseStorage(ctrl)
-				result.EXPECT().Load().Return(currentExpenses, nil).Times(1)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
 
 				return result
 			},
@@ -601,7 +545,7 @@ func TestExpenseFileStorage_Load(t *testing.T) {
 				t.Helper()
 
 				result := mocks.NewMockExpenseStorage(ctrl)
-				result.EXPECT().Load().Return(nil, assert.AnError).Times(1)
+				result.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
 
 				return result
 			},
@@ -637,6 +581,7 @@ func TestGetExpensesSummary(t *testing.T) {
 		storageFn      func(t *testing.T) domain.ExpenseStorage
 		year           int
 		month          time.Month
+		location       *time.Location
 		expectedAmount float64
 		expectedErr    error
 	}
@@ -654,7 +599,7 @@ func TestGetExpensesSummary(t *testing.T) {
 				}
 
 				result := mocks.NewMockExpenseStorage(ctrl)
-				result.EXPECT().Load().Return(currentExpenses, nil).Times(1)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
 
 				return result
 			},
@@ -673,7 +618,7 @@ func TestGetExpensesSummary(t *testing.T) {
 				}
 
 				result := mocks.NewMockExpenseStorage(ctrl)
-				result.EXPECT().Load().Return(currentExpenses, nil).Times(1)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
 
 				return result
 			},
@@ -688,7 +633,7 @@ func TestGetExpensesSummary(t *testing.T) {
 				t.Helper()
 
 				result := mocks.NewMockExpenseStorage(ctrl)
-				result.EXPECT().Load().Return(nil, assert.AnError).Times(1)
+				result.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
 
 				return result
 			},
@@ -710,7 +655,7 @@ func TestGetExpensesSummary(t *testing.T) {
 				}
 
 				result := mocks.NewMockExpenseStorage(ctrl)
-				result.EXPECT().Load().Return(currentExpenses, nil).Times(1)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
 
 				return result
 			},
@@ -719,6 +664,67 @@ func TestGetExpensesSummary(t *testing.T) {
 			expectedAmount: 32.0,
 			expectedErr:    nil,
 		},
+		{
+			name: "Timed expenses counted in the month of the timezone",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					// 1 February 05:00 in UTC+9
+					{Id: 1, Description: "Late taxi", Amount: 30.0, SpentAt: time.Date(2024, 1, 31, 20, 0, 0, 0, time.UTC)},
+					// 31 January 19:00 in UTC+9
+					{Id: 2, Description: "Dinner", Amount: 20.0, SpentAt: time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			year:           2024,
+			month:          time.February,
+			location:       time.FixedZone("UTC+9", 9*60*60),
+			expectedAmount: 30.0,
+		},
+		{
+			name: "Date-only expenses keep their day in any timezone",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Description: "Rent", Amount: 500.0, SpentAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+					{Id: 2, Description: "Groceries", Amount: 40.0, SpentAt: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			year:           2024,
+			month:          time.February,
+			location:       time.FixedZone("UTC-5", -5*60*60),
+			expectedAmount: 500.0,
+		},
+		{
+			name: "Transfers are not spending",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Description: "Groceries", Amount: 40.0, SpentAt: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), Account: "Cash"},
+					{Id: 2, Description: "ATM", Amount: 100.0, SpentAt: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), Account: "Debit card", Kind: domain.TransferKind, ToAccount: "Cash"},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			year:           2024,
+			month:          time.January,
+			expectedAmount: 40.0,
+		},
 	}
 
 	for _, tt := range testCases {
@@ -726,8 +732,13 @@ func TestGetExpensesSummary(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
+			location := tt.location
+			if location == nil {
+				location = time.UTC
+			}
+
 			mockStorage := tt.storageFn(t)
-			result, err := getExpensesSummary(mockStorage, tt.year, tt.month)
+			result, err := getExpensesSummary(mockStorage, tt.year, tt.month, location)
 
 			if tt.expectedErr != nil {
 				assert.ErrorIs(t, err, tt.expectedErr)
@@ -738,3 +749,96 @@ func TestGetExpensesSummary(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDailyTotals(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	type testCase struct {
+		name           string
+		storageFn      func(t *testing.T) domain.ExpenseStorage
+		location       *time.Location
+		expectedTotals map[int]float64
+		expectedErr    error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Totals per day of the month",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Description: "Coffee", Amount: 3.5, SpentAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+					{Id: 2, Description: "Lunch", Amount: 12.0, SpentAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+					{Id: 3, Description: "Dinner", Amount: 20.0, SpentAt: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+					{Id: 4, Description: "Snacks", Amount: 5.0, SpentAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			location:       time.UTC,
+			expectedTotals: map[int]float64{1: 15.5, 15: 20.0},
+		},
+		{
+			name: "Timed expenses counted on the day of the timezone",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					// 11 January 01:00 in UTC+9
+					{Id: 1, Description: "Taxi", Amount: 30.0, SpentAt: time.Date(2024, 1, 10, 16, 0, 0, 0, time.UTC)},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			location:       time.FixedZone("UTC+9", 9*60*60),
+			expectedTotals: map[int]float64{11: 30.0},
+		},
+		{
+			name: "Load error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+				return result
+			},
+			location:    time.UTC,
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := tt.storageFn(t)
+			result, err := getDailyTotals(mockStorage, 2024, time.January, tt.location)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedTotals, result)
+			}
+		})
+	}
+}
+
+// queryOver answers queries the way a storage holding the given expenses would.
+func queryOver(expenses []domain.Expense) func(filter domain.ExpenseFilter) ([]domain.Expense, error) {
+	return func(filter domain.ExpenseFilter) ([]domain.Expense, error) {
+		return lo.Filter(expenses, func(e domain.Expense, _ int) bool {
+			return filter.Matches(e)
+		}), nil
+	}
+}
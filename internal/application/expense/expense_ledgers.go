@@ -0,0 +1,61 @@
+package expense
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/files"
+	"slices"
+)
+
+// DefaultLedger is the ledger the expenses are kept in unless another one is chosen.
+const DefaultLedger = files.DefaultLedger
+
+// GetLedgers returns the name of every ledger, the default one first.
+func GetLedgers() ([]string, error) {
+	ledgers, err := files.Ledgers()
+	if err != nil {
+		return nil, fmt.Errorf("Error listing ledgers: %w", err)
+	}
+
+	return ledgers, nil
+}
+
+// CurrentLedger returns the name of the ledger the expenses, settings, rules and backups are kept in.
+func CurrentLedger() string {
+	return files.CurrentLedger()
+}
+
+// SwitchLedger makes an existing ledger the one everything is read from and written to.
+func SwitchLedger(name string) error {
+	ledgers, err := GetLedgers()
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(ledgers, name) {
+		return &LedgerNotFoundError{Name: name}
+	}
+
+	return files.SetLedger(name)
+}
+
+// CreateLedger adds an empty ledger with its own expenses and settings, and switches to it.
+func CreateLedger(name string) error {
+	if !files.IsValidLedgerName(name) {
+		return &InvalidLedgerNameError{Name: name}
+	}
+
+	ledgers, err := GetLedgers()
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(ledgers, name) {
+		return &LedgerExistsError{Name: name}
+	}
+
+	if err = files.CreateLedger(name); err != nil {
+		return fmt.Errorf("Error creating ledger: %w", err)
+	}
+
+	return files.SetLedger(name)
+}
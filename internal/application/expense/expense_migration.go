@@ -0,0 +1,80 @@
+package expense
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/config"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/files"
+)
+
+// migrateExpenses copies every expense from source into target and returns how many were copied.
+// A target that already holds expenses is only overwritten when force is set.
+func migrateExpenses(source domain.ExpenseStorage, target domain.ExpenseStorage, force bool) (int, error) {
+	expenses, err := source.Query(domain.ExpenseFilter{})
+	if err != nil {
+		return 0, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	existing, err := target.Query(domain.ExpenseFilter{})
+	if err != nil {
+		return 0, fmt.Errorf("Error loading target storage: %w", err)
+	}
+
+	if len(existing) > 0 && !force {
+		return 0, &StorageNotEmptyError{Count: len(existing)}
+	}
+
+	if err = target.Replace(expenses...); err != nil {
+		return 0, fmt.Errorf("Error saving expenses: %w", err)
+	}
+
+	return len(expenses), nil
+}
+
+// MigrateStorage copies the expenses of the configured backend into the target one and switches the settings
+// to it. The old backend is left untouched.
+func MigrateStorage(target string, force bool) (int, error) {
+	settings, err := config.Load()
+	if err != nil {
+		return 0, fmt.Errorf("Error loading settings: %w", err)
+	}
+
+	source, ok := expenseStorageNamed(settings.Storage)
+	if !ok {
+		return 0, &UnknownStorageError{Name: settings.Storage}
+	}
+
+	destination, ok := expenseStorageNamed(target)
+	if !ok || target == "" {
+		return 0, &UnknownStorageError{Name: target}
+	}
+
+	if destination == source || target == settings.Storage {
+		return 0, ErrSameStorage
+	}
+
+	// the other backends would keep the expenses in plaintext
+	if destination != jsonExpenseStorage {
+		encrypted, err := files.IsEncrypted()
+		if err != nil {
+			return 0, fmt.Errorf("Error reading expenses: %w", err)
+		}
+
+		if encrypted {
+			return 0, &EncryptionUnsupportedError{Storage: target}
+		}
+	}
+
+	count, err := migrateExpenses(source, destination, force)
+	if err != nil {
+		return 0, err
+	}
+
+	settings.Storage = target
+
+	if err = config.Save(settings); err != nil {
+		return count, fmt.Errorf("Error saving settings: %w", err)
+	}
+
+	return count, nil
+}
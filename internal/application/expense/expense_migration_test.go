@@ -0,0 +1,150 @@
+package expense
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense/mocks"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMigrateExpenses(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	expenses := []domain.Expense{
+		{Id: 1, Description: "Coffee", Category: "Food", Amount: 3.5, SpentAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Id: 2, Description: "Taxi", Category: "Transport", Amount: 12, SpentAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}
+
+	type testCase struct {
+		name          string
+		sourceFn      func(t *testing.T) domain.ExpenseStorage
+		targetFn      func(t *testing.T) domain.ExpenseStorage
+		force         bool
+		expectedCount int
+		expectedErr   error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Copied into an empty target",
+			sourceFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(expenses)).Times(1)
+
+				return result
+			},
+			targetFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver([]domain.Expense{})).Times(1)
+				result.EXPECT().Replace(expenses[0], expenses[1]).Return(nil).Times(1)
+
+				return result
+			},
+			expectedCount: 2,
+		},
+		{
+			name: "Target not empty",
+			sourceFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(expenses)).Times(1)
+
+				return result
+			},
+			targetFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(expenses[:1])).Times(1)
+				result.EXPECT().Replace(gomock.Any()).Times(0)
+
+				return result
+			},
+			expectedErr: &StorageNotEmptyError{Count: 1},
+		},
+		{
+			name: "Target not empty with force",
+			sourceFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(expenses)).Times(1)
+
+				return result
+			},
+			targetFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(expenses[:1])).Times(1)
+				result.EXPECT().Replace(expenses[0], expenses[1]).Return(nil).Times(1)
+
+				return result
+			},
+			force:         true,
+			expectedCount: 2,
+		},
+		{
+			name: "Source load error",
+			sourceFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+				return result
+			},
+			targetFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				return mocks.NewMockExpenseStorage(ctrl)
+			},
+			expectedErr: assert.AnError,
+		},
+		{
+			name: "Target save error",
+			sourceFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(expenses)).Times(1)
+
+				return result
+			},
+			targetFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(nil)).Times(1)
+				result.EXPECT().Replace(expenses[0], expenses[1]).Return(assert.AnError).Times(1)
+
+				return result
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			count, err := migrateExpenses(tt.sourceFn(t), tt.targetFn(t), tt.force)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCount, count)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package expense
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/samber/lo"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QuickEntry is the interpretation of a single quick-add line such as "coffee 3.50 food yesterday".
+type QuickEntry struct {
+	Description string
+	Category    string
+	Amount      float64
+	Currency    string
+	SpentAt     time.Time
+
+	// Ambiguities lists what could not be understood with certainty. Ambiguous entries should be
+	// completed in the full form instead of being saved directly.
+	Ambiguities []string
+}
+
+// IsAmbiguous reports whether the entry needs a review before it is saved.
+func (q QuickEntry) IsAmbiguous() bool {
+	return len(q.Ambiguities) > 0
+}
+
+// Expense returns the expense the entry describes, keeping its currency as a tag.
+func (q QuickEntry) Expense() domain.Expense {
+	description := q.Description
+	if description == "" {
+		description = "-"
+	}
+
+	category := q.Category
+	if category == "" {
+		category = "-"
+	}
+
+	var tags []string
+	if q.Currency != "" {
+		tags = []string{q.Currency}
+	}
+
+	return domain.Expense{
+		Description: description,
+		Category:    category,
+		Amount:      q.Amount,
+		SpentAt:     q.SpentAt,
+		Tags:        domain.NormalizeTags(tags),
+	}
+}
+
+// Draft returns the expense for a review in the full form, leaving out the parts that were not found.
+func (q QuickEntry) Draft() domain.Expense {
+	draft := q.Expense()
+	draft.Description = q.Description
+	draft.Category = q.Category
+
+	return draft
+}
+
+var (
+	amountRegexp = regexp.MustCompile(`^([$€£¥₽₴]?)(\d+(?:[.,]\d{1,2})?)([$€£¥₽₴]|[a-z]{3})?$`)
+
+	currencySymbols = map[string]string{
+		"$": "usd",
+		"€": "eur",
+		"£": "gbp",
+		"¥": "jpy",
+		"₽": "rub",
+		"₴": "uah",
+	}
+
+	currencyCodes = []string{
+		"usd", "eur", "gbp", "jpy", "chf", "cad", "aud", "nzd", "cny", "rub",
+		"uah", "pln", "czk", "sek", "nok", "dkk", "huf", "try", "inr", "brl",
+	}
+
+	weekdays = map[string]time.Weekday{
+		"sun": time.Sunday, "sunday": time.Sunday,
+		"mon": time.Monday, "monday": time.Monday,
+		"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+		"wed": time.Wednesday, "wednesday": time.Wednesday,
+		"thu": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+		"fri": time.Friday, "friday": time.Friday,
+		"sat": time.Saturday, "saturday": time.Saturday,
+	}
+)
+
+// parseQuickAdd interprets a quick-add line against the categories already in use and the rules.
+func parseQuickAdd(storage domain.ExpenseStorage, ruleStorage domain.RuleStorage, line string, now time.Time) (QuickEntry, error) {
+	expenses, err := storage.Query(domain.ExpenseFilter{})
+
+	if err != nil {
+		return QuickEntry{}, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	rules, err := getRules(ruleStorage)
+	if err != nil {
+		return QuickEntry{}, err
+	}
+
+	categories := lo.Uniq(lo.FilterMap(expenses, func(e domain.Expense, _ int) (string, bool) {
+		return e.Category, !domain.IsUncategorized(e.Category)
+	}))
+
+	return parseQuickEntry(line, categories, rules, now), nil
+}
+
+// parseQuickEntry picks the amount, currency, date and a known category out of the words of a line;
+// the remaining words make up the description. A line without a known category is left for the full
+// form unless one of the rules will categorize it. Dates may be ISO (2024-01-31), "today", "yesterday",
+// a weekday ("fri", "last friday") or "N days/weeks ago". Missing dates default to today.
+func parseQuickEntry(line string, knownCategories []string, rules []domain.Rule, now time.Time) QuickEntry {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	entry := QuickEntry{SpentAt: today}
+
+	tokens := strings.Fields(line)
+	descriptionWords := make([]string, 0, len(tokens))
+	amounts, dates, categories := 0, 0, 0
+
+	for i := 0; i < len(tokens); i++ {
+		lower := strings.ToLower(tokens[i])
+
+		if date, consumed, ok := parseRelativeDate(tokens[i:], today); ok {
+			entry.SpentAt = date
+			dates++
+			i += consumed - 1
+			continue
+		}
+
+		if amount, currency, ok := parseAmount(lower); ok {
+			entry.Amount = amount
+			amounts++
+
+			if currency != "" {
+				entry.Currency = currency
+			}
+
+			continue
+		}
+
+		if slices.Contains(currencyCodes, lower) {
+			entry.Currency = lower
+			continue
+		}
+
+		if category, ok := matchCategory(tokens[i], knownCategories); ok {
+			entry.Category = category
+			categories++
+			continue
+		}
+
+		descriptionWords = append(descriptionWords, tokens[i])
+	}
+
+	entry.Description = strings.Join(descriptionWords, " ")
+
+	switch {
+	case amounts == 0:
+		entry.Ambiguities = append(entry.Ambiguities, "no amount found")
+	case amounts > 1:
+		entry.Ambiguities = append(entry.Ambiguities, "several amounts found")
+	}
+
+	if dates > 1 {
+		entry.Ambiguities = append(entry.Ambiguities, "several dates found")
+	}
+
+	_, ruleFound := findRule(rules, domain.Expense{Description: entry.Description, Amount: entry.Amount})
+
+	switch {
+	case categories == 0 && !ruleFound:
+		entry.Ambiguities = append(entry.Ambiguities, "no category found")
+	case categories > 1:
+		entry.Ambiguities = append(entry.Ambiguities, "several categories found")
+	}
+
+	if entry.Description == "" {
+		entry.Ambiguities = append(entry.Ambiguities, "no description found")
+	}
+
+	return entry
+}
+
+// parseAmount reads a number with an optional currency symbol or code glued to it, e.g. "3.50", "$3.50" or "3,50eur".
+func parseAmount(token string) (float64, string, bool) {
+	match := amountRegexp.FindStringSubmatch(token)
+	if match == nil {
+		return 0, "", false
+	}
+
+	currency := ""
+
+	for _, part := range []string{match[1], match[3]} {
+		if code, ok := currencySymbols[part]; ok {
+			currency = code
+		} else if part != "" && !slices.Contains(currencyCodes, part) {
+			return 0, "", false
+		} else if part != "" {
+			currency = part
+		}
+	}
+
+	amount, err := strconv.ParseFloat(strings.Replace(match[2], ",", ".", 1), 64)
+	if err != nil || amount <= 0 {
+		return 0, "", false
+	}
+
+	return amount, currency, true
+}
+
+// parseRelativeDate reads a date from the start of tokens and returns how many tokens it took.
+func parseRelativeDate(tokens []string, today time.Time) (time.Time, int, bool) {
+	first := strings.ToLower(tokens[0])
+
+	switch first {
+	case "today":
+		return today, 1, true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), 1, true
+	}
+
+	if date, err := time.Parse("2006-01-02", first); err == nil {
+		return date, 1, true
+	}
+
+	if weekday, ok := weekdays[first]; ok {
+		return lastWeekday(today, weekday, false), 1, true
+	}
+
+	if len(tokens) >= 2 && first == "last" {
+		if weekday, ok := weekdays[strings.ToLower(tokens[1])]; ok {
+			return lastWeekday(today, weekday, true), 2, true
+		}
+	}
+
+	if len(tokens) >= 3 && strings.ToLower(tokens[2]) == "ago" {
+		n, err := strconv.Atoi(first)
+		if err != nil || n < 0 {
+			return time.Time{}, 0, false
+		}
+
+		switch strings.ToLower(tokens[1]) {
+		case "day", "days":
+			return today.AddDate(0, 0, -n), 3, true
+		case "week", "weeks":
+			return today.AddDate(0, 0, -7*n), 3, true
+		}
+	}
+
+	return time.Time{}, 0, false
+}
+
+// lastWeekday returns the closest past date falling on weekday, today included unless strictlyBefore is set.
+func lastWeekday(today time.Time, weekday time.Weekday, strictlyBefore bool) time.Time {
+	days := (int(today.Weekday()) - int(weekday) + 7) % 7
+
+	if days == 0 && strictlyBefore {
+		days = 7
+	}
+
+	return today.AddDate(0, 0, -days)
+}
+
+// matchCategory finds a known category named by the word, either in full ("Food:Groceries") or by its
+// last level ("groceries") when that is unique. Words containing the separator are taken as new categories.
+func matchCategory(word string, knownCategories []string) (string, bool) {
+	for _, category := range knownCategories {
+		if strings.EqualFold(category, word) {
+			return category, true
+		}
+	}
+
+	byLastLevel := lo.Filter(knownCategories, func(category string, _ int) bool {
+		levels := domain.CategoryLevels(category)
+		return len(levels) > 1 && strings.EqualFold(levels[len(levels)-1], word)
+	})
+
+	if len(byLastLevel) == 1 {
+		return byLastLevel[0], true
+	}
+
+	if strings.Contains(word, domain.CategorySeparator) && domain.NormalizeCategory(word) != "" {
+		return domain.NormalizeCategory(word), true
+	}
+
+	return "", false
+}
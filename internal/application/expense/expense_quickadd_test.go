@@ -0,0 +1,195 @@
+package expense
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense/mocks"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseQuickEntry(t *testing.T) {
+	t.Parallel()
+
+	// a Wednesday
+	now := time.Date(2024, 6, 12, 18, 30, 0, 0, time.UTC)
+	today := time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC)
+	knownCategories := []string{"Food", "Food:Groceries", "Transport", "Fun:Cinema", "Work:Cinema"}
+	rules := []domain.Rule{{Id: 1, Kind: domain.SubstringRule, Pattern: "tesco", Category: "Food:Groceries"}}
+
+	type testCase struct {
+		name          string
+		line          string
+		expectedEntry QuickEntry
+	}
+
+	testCases := []testCase{
+		{
+			name: "Description, amount, category and yesterday",
+			line: "coffee 3.50 food yesterday",
+			expectedEntry: QuickEntry{
+				Description: "coffee",
+				Category:    "Food",
+				Amount:      3.5,
+				SpentAt:     today.AddDate(0, 0, -1),
+			},
+		},
+		{
+			name: "Currency symbol and last weekday",
+			line: "Taxi to airport €25 transport last fri",
+			expectedEntry: QuickEntry{
+				Description: "Taxi to airport",
+				Category:    "Transport",
+				Amount:      25,
+				Currency:    "eur",
+				SpentAt:     time.Date(2024, 6, 7, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Days ago, currency code and subcategory by last level",
+			line: "milk 2,40 usd groceries 3 days ago",
+			expectedEntry: QuickEntry{
+				Description: "milk",
+				Category:    "Food:Groceries",
+				Amount:      2.4,
+				Currency:    "usd",
+				SpentAt:     today.AddDate(0, 0, -3),
+			},
+		},
+		{
+			name: "Weekday of today",
+			line: "lunch 12 food wed",
+			expectedEntry: QuickEntry{
+				Description: "lunch",
+				Category:    "Food",
+				Amount:      12,
+				SpentAt:     today,
+			},
+		},
+		{
+			name: "New hierarchical category and ISO date",
+			line: "Gym 30gbp Health:Sport 2024-05-01",
+			expectedEntry: QuickEntry{
+				Description: "Gym",
+				Category:    "Health:Sport",
+				Amount:      30,
+				Currency:    "gbp",
+				SpentAt:     time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Ambiguous last level stays in the description",
+			line: "cinema 10",
+			expectedEntry: QuickEntry{
+				Description: "cinema",
+				Amount:      10,
+				SpentAt:     today,
+				Ambiguities: []string{"no category found"},
+			},
+		},
+		{
+			name: "Category left to a matching rule",
+			line: "TESCO STORES 12.50",
+			expectedEntry: QuickEntry{
+				Description: "TESCO STORES",
+				Amount:      12.5,
+				SpentAt:     today,
+			},
+		},
+		{
+			name: "Several amounts",
+			line: "2 coffees 7",
+			expectedEntry: QuickEntry{
+				Description: "coffees",
+				Amount:      7,
+				SpentAt:     today,
+				Ambiguities: []string{"several amounts found", "no category found"},
+			},
+		},
+		{
+			name: "Nothing but a category",
+			line: "food",
+			expectedEntry: QuickEntry{
+				Category:    "Food",
+				SpentAt:     today,
+				Ambiguities: []string{"no amount found", "no description found"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := parseQuickEntry(tt.line, knownCategories, rules, now)
+
+			assert.Equal(t, tt.expectedEntry, result)
+			assert.Equal(t, len(tt.expectedEntry.Ambiguities) > 0, result.IsAmbiguous())
+		})
+	}
+}
+
+func TestParseQuickAdd(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	now := time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Uses categories in use", func(t *testing.T) {
+		t.Parallel()
+
+		storage := mocks.NewMockExpenseStorage(ctrl)
+		storage.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver([]domain.Expense{{Id: 1, Category: "Transport"}, {Id: 2, Category: "-"}})).Times(1)
+		ruleStorage := mocks.NewMockRuleStorage(ctrl)
+		ruleStorage.EXPECT().Load().Return(nil, nil).Times(1)
+
+		result, err := parseQuickAdd(storage, ruleStorage, "bus 2 transport", now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, QuickEntry{Description: "bus", Category: "Transport", Amount: 2, SpentAt: now}, result)
+	})
+
+	t.Run("Category left to a matching rule", func(t *testing.T) {
+		t.Parallel()
+
+		storage := mocks.NewMockExpenseStorage(ctrl)
+		storage.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver([]domain.Expense{{Id: 1, Category: "Transport"}})).Times(1)
+		ruleStorage := mocks.NewMockRuleStorage(ctrl)
+		ruleStorage.EXPECT().Load().Return([]domain.Rule{
+			{Id: 1, Priority: 1, Kind: domain.SubstringRule, Pattern: "tesco", Category: "Food:Groceries"},
+			{Id: 2, Priority: 0, Kind: domain.SubstringRule, Pattern: "stores", MinAmount: 100, Category: "Home"},
+		}, nil).Times(1)
+
+		result, err := parseQuickAdd(storage, ruleStorage, "TESCO STORES 12.50", now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, QuickEntry{Description: "TESCO STORES", Amount: 12.5, SpentAt: now}, result)
+		assert.False(t, result.IsAmbiguous())
+		assert.Equal(t, "-", result.Expense().Category)
+	})
+
+	t.Run("Rule load error", func(t *testing.T) {
+		t.Parallel()
+
+		storage := mocks.NewMockExpenseStorage(ctrl)
+		storage.EXPECT().Query(gomock.Any()).Return(nil, nil).Times(1)
+		ruleStorage := mocks.NewMockRuleStorage(ctrl)
+		ruleStorage.EXPECT().Load().Return(nil, assert.AnError).Times(1)
+
+		_, err := parseQuickAdd(storage, ruleStorage, "bus 2", now)
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("Load error", func(t *testing.T) {
+		t.Parallel()
+
+		storage := mocks.NewMockExpenseStorage(ctrl)
+		storage.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+		_, err := parseQuickAdd(storage, mocks.NewMockRuleStorage(ctrl), "bus 2", now)
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,235 @@
+package expense
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/samber/lo"
+	"slices"
+	"strings"
+	"time"
+)
+
+// CategoryChange describes a category a rule would give to an existing expense.
+type CategoryChange struct {
+	Expense     domain.Expense
+	NewCategory string
+	RuleId      int
+}
+
+// getRules returns the rules in the order they are evaluated.
+func getRules(ruleStorage domain.RuleStorage) ([]domain.Rule, error) {
+	rules, err := ruleStorage.Load()
+
+	if err != nil {
+		return nil, fmt.Errorf("Error loading rules: %w", err)
+	}
+
+	sortRules(rules)
+	return rules, nil
+}
+
+func addRule(ruleStorage domain.RuleStorage, rule domain.Rule) (domain.Rule, error) {
+	rule = normalizeRule(rule)
+	if err := rule.Validate(); err != nil {
+		return domain.Rule{}, err
+	}
+
+	rules, err := ruleStorage.Load()
+
+	if err != nil {
+		return domain.Rule{}, fmt.Errorf("Error loading rules: %w", err)
+	}
+
+	rule.Id = getNextRuleId(rules)
+	rules = append(rules, rule)
+	err = ruleStorage.Save(rules)
+
+	if err != nil {
+		return domain.Rule{}, fmt.Errorf("Error saving rules: %w", err)
+	}
+
+	return rule, nil
+}
+
+func updateRule(ruleStorage domain.RuleStorage, rule domain.Rule) (domain.Rule, error) {
+	rule = normalizeRule(rule)
+	if err := rule.Validate(); err != nil {
+		return domain.Rule{}, err
+	}
+
+	rules, err := ruleStorage.Load()
+
+	if err != nil {
+		return domain.Rule{}, fmt.Errorf("Error loading rules: %w", err)
+	}
+
+	index := slices.IndexFunc(rules, func(r domain.Rule) bool {
+		return r.Id == rule.Id
+	})
+
+	if index < 0 {
+		return domain.Rule{}, &RuleNotFoundError{ID: rule.Id}
+	}
+
+	rules[index] = rule
+	err = ruleStorage.Save(rules)
+
+	if err != nil {
+		return domain.Rule{}, fmt.Errorf("Error saving rules: %w", err)
+	}
+
+	return rule, nil
+}
+
+func deleteRule(ruleStorage domain.RuleStorage, id int) error {
+	rules, err := ruleStorage.Load()
+
+	if err != nil {
+		return fmt.Errorf("Error loading rules: %w", err)
+	}
+
+	remaining := lo.Reject(rules, func(r domain.Rule, _ int) bool {
+		return r.Id == id
+	})
+
+	if len(remaining) == len(rules) {
+		return &RuleNotFoundError{ID: id}
+	}
+
+	err = ruleStorage.Save(remaining)
+
+	if err != nil {
+		return fmt.Errorf("Error saving rules: %w", err)
+	}
+
+	return nil
+}
+
+// addCategorizedExpense adds an expense, letting the rules pick its category when none was given.
+func addCategorizedExpense(storage domain.ExpenseStorage, ruleStorage domain.RuleStorage, spentTime time.Time, description string, category string, amount float64, tags []string, account string, splits []domain.Split, sharing *domain.Sharing) (domain.Expense, error) {
+	if domain.IsUncategorized(category) && len(splits) == 0 {
+		rules, err := getRules(ruleStorage)
+		if err != nil {
+			return domain.Expense{}, err
+		}
+
+		candidate := domain.Expense{Description: description, Amount: amount}
+		if rule, found := findRule(rules, candidate); found {
+			category = rule.Category
+		}
+	}
+
+	return addExpense(storage, spentTime, description, category, amount, tags, account, splits, sharing)
+}
+
+// previewRules lists the category changes re-applying the rules to existing expenses would make.
+func previewRules(storage domain.ExpenseStorage, ruleStorage domain.RuleStorage) ([]CategoryChange, error) {
+	expenses, err := querySpending(storage, domain.ExpenseFilter{})
+
+	if err != nil {
+		return nil, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	rules, err := getRules(ruleStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	return getCategoryChanges(expenses, rules), nil
+}
+
+// applyRules re-applies the rules to existing expenses and returns the changes made.
+func applyRules(storage domain.ExpenseStorage, ruleStorage domain.RuleStorage) ([]CategoryChange, error) {
+	expenses, err := querySpending(storage, domain.ExpenseFilter{})
+
+	if err != nil {
+		return nil, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	rules, err := getRules(ruleStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := getCategoryChanges(expenses, rules)
+	if len(changes) == 0 {
+		return changes, nil
+	}
+
+	newCategories := lo.SliceToMap(changes, func(c CategoryChange) (int, string) {
+		return c.Expense.Id, c.NewCategory
+	})
+
+	changed := make([]domain.Expense, 0, len(changes))
+
+	for _, e := range expenses {
+		if category, ok := newCategories[e.Id]; ok {
+			e.Category = category
+			changed = append(changed, e)
+		}
+	}
+
+	err = storage.Update(changed...)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error saving expenses: %w", err)
+	}
+
+	return changes, nil
+}
+
+func getCategoryChanges(expenses []domain.Expense, rules []domain.Rule) []CategoryChange {
+	changes := make([]CategoryChange, 0)
+
+	for _, e := range expenses {
+		if e.IsSplit() {
+			// the lines of a split expense were set by hand, a rule would collapse them into one category
+			continue
+		}
+
+		rule, found := findRule(rules, e)
+
+		if found && rule.Category != e.Category {
+			changes = append(changes, CategoryChange{Expense: e, NewCategory: rule.Category, RuleId: rule.Id})
+		}
+	}
+
+	return changes
+}
+
+// findRule returns the first of the sorted rules matching the expense.
+func findRule(rules []domain.Rule, e domain.Expense) (domain.Rule, bool) {
+	return lo.Find(rules, func(r domain.Rule) bool {
+		return r.Matches(e)
+	})
+}
+
+func sortRules(rules []domain.Rule) {
+	slices.SortStableFunc(rules, func(a, b domain.Rule) int {
+		if a.Priority != b.Priority {
+			return cmp.Compare(a.Priority, b.Priority)
+		}
+
+		return cmp.Compare(a.Id, b.Id)
+	})
+}
+
+func normalizeRule(rule domain.Rule) domain.Rule {
+	rule.Pattern = strings.TrimSpace(rule.Pattern)
+	rule.Category = domain.NormalizeCategory(rule.Category)
+
+	return rule
+}
+
+func getNextRuleId(rules []domain.Rule) int {
+	if len(rules) == 0 {
+		return 1
+	}
+
+	maxId := lo.MaxBy(rules, func(a, b domain.Rule) bool {
+		return a.Id > b.Id
+	}).Id
+
+	return maxId + 1
+}
@@ -0,0 +1,353 @@
+package expense
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense/mocks"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAddRule(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	type testCase struct {
+		name         string
+		storageFn    func(t *testing.T) domain.RuleStorage
+		rule         domain.Rule
+		expectedRule domain.Rule
+		expectErr    bool
+		expectedErr  error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Successful addition",
+			storageFn: func(t *testing.T) domain.RuleStorage {
+				t.Helper()
+
+				currentRules := []domain.Rule{{Id: 3, Kind: domain.SubstringRule, Pattern: "uber", Category: "Transport"}}
+				expectedRules := append(currentRules, domain.Rule{Id: 4, Priority: 1, Kind: domain.PayeeRule, Pattern: "TESCO STORES 1234", Category: "Food:Groceries"})
+
+				result := mocks.NewMockRuleStorage(ctrl)
+				result.EXPECT().Load().Return(currentRules, nil).Times(1)
+				result.EXPECT().Save(gomock.Eq(expectedRules)).Return(nil).Times(1)
+
+				return result
+			},
+			rule:         domain.Rule{Priority: 1, Kind: domain.PayeeRule, Pattern: " TESCO STORES 1234 ", Category: "Food : Groceries"},
+			expectedRule: domain.Rule{Id: 4, Priority: 1, Kind: domain.PayeeRule, Pattern: "TESCO STORES 1234", Category: "Food:Groceries"},
+		},
+		{
+			name: "Invalid regex",
+			storageFn: func(t *testing.T) domain.RuleStorage {
+				t.Helper()
+
+				return mocks.NewMockRuleStorage(ctrl)
+			},
+			rule:      domain.Rule{Kind: domain.RegexRule, Pattern: "uber(", Category: "Transport"},
+			expectErr: true,
+		},
+		{
+			name: "Inverted amount range",
+			storageFn: func(t *testing.T) domain.RuleStorage {
+				t.Helper()
+
+				return mocks.NewMockRuleStorage(ctrl)
+			},
+			rule:      domain.Rule{Kind: domain.SubstringRule, MinAmount: 50, MaxAmount: 10, Category: "Big"},
+			expectErr: true,
+		},
+		{
+			name: "Save error",
+			storageFn: func(t *testing.T) domain.RuleStorage {
+				t.Helper()
+
+				result := mocks.NewMockRuleStorage(ctrl)
+				result.EXPECT().Load().Return([]domain.Rule{}, nil).Times(1)
+				result.EXPECT().Save(gomock.Any()).Return(assert.AnError).Times(1)
+
+				return result
+			},
+			rule:        domain.Rule{Kind: domain.SubstringRule, Pattern: "uber", Category: "Transport"},
+			expectErr:   true,
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := tt.storageFn(t)
+			result, err := addRule(mockStorage, tt.rule)
+
+			if tt.expectErr {
+				assert.Error(t, err)
+
+				if tt.expectedErr != nil {
+					assert.ErrorIs(t, err, tt.expectedErr)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRule, result)
+			}
+		})
+	}
+}
+
+func TestDeleteRule(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	type testCase struct {
+		name        string
+		storageFn   func(t *testing.T) domain.RuleStorage
+		id          int
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Successful deletion",
+			storageFn: func(t *testing.T) domain.RuleStorage {
+				t.Helper()
+
+				currentRules := []domain.Rule{{Id: 1}, {Id: 2}}
+
+				result := mocks.NewMockRuleStorage(ctrl)
+				result.EXPECT().Load().Return(currentRules, nil).Times(1)
+				result.EXPECT().Save(gomock.Eq([]domain.Rule{{Id: 1}})).Return(nil).Times(1)
+
+				return result
+			},
+			id: 2,
+		},
+		{
+			name: "Rule not found",
+			storageFn: func(t *testing.T) domain.RuleStorage {
+				t.Helper()
+
+				result := mocks.NewMockRuleStorage(ctrl)
+				result.EXPECT().Load().Return([]domain.Rule{{Id: 1}}, nil).Times(1)
+
+				return result
+			},
+			id:          5,
+			expectedErr: &RuleNotFoundError{ID: 5},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := tt.storageFn(t)
+			err := deleteRule(mockStorage, tt.id)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAddCategorizedExpense(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	spentAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rules := []domain.Rule{
+		{Id: 1, Priority: 2, Kind: domain.SubstringRule, Pattern: "uber", Category: "Transport"},
+		{Id: 2, Priority: 1, Kind: domain.RegexRule, Pattern: `(?i)^uber \*eats`, Category: "Food:Delivery"},
+		{Id: 3, Priority: 3, Kind: domain.PayeeRule, Pattern: "TESCO STORES", Category: "Food:Groceries"},
+		{Id: 4, Priority: 4, Kind: domain.SubstringRule, MinAmount: 500, Category: "Big"},
+	}
+
+	type testCase struct {
+		name             string
+		ruleStorageFn    func(t *testing.T) domain.RuleStorage
+		description      string
+		category         string
+		amount           float64
+		expectedCategory string
+	}
+
+	testCases := []testCase{
+		{
+			name: "Substring rule",
+			ruleStorageFn: func(t *testing.T) domain.RuleStorage {
+				t.Helper()
+
+				result := mocks.NewMockRuleStorage(ctrl)
+				result.EXPECT().Load().Return(append([]domain.Rule(nil), rules...), nil).Times(1)
+
+				return result
+			},
+			description:      "UBER *TRIP",
+			category:         "-",
+			amount:           12,
+			expectedCategory: "Transport",
+		},
+		{
+			name: "Higher priority wins",
+			ruleStorageFn: func(t *testing.T) domain.RuleStorage {
+				t.Helper()
+
+				result := mocks.NewMockRuleStorage(ctrl)
+				result.EXPECT().Load().Return(append([]domain.Rule(nil), rules...), nil).Times(1)
+
+				return result
+			},
+			description:      "UBER *EATS",
+			category:         "",
+			amount:           25,
+			expectedCategory: "Food:Delivery",
+		},
+		{
+			name: "Payee rule",
+			ruleStorageFn: func(t *testing.T) domain.RuleStorage {
+				t.Helper()
+
+				result := mocks.NewMockRuleStorage(ctrl)
+				result.EXPECT().Load().Return(append([]domain.Rule(nil), rules...), nil).Times(1)
+
+				return result
+			},
+			description:      "TESCO STORES 1234",
+			category:         "-",
+			amount:           40,
+			expectedCategory: "Food:Groceries",
+		},
+		{
+			name: "Amount range rule",
+			ruleStorageFn: func(t *testing.T) domain.RuleStorage {
+				t.Helper()
+
+				result := mocks.NewMockRuleStorage(ctrl)
+				result.EXPECT().Load().Return(append([]domain.Rule(nil), rules...), nil).Times(1)
+
+				return result
+			},
+			description:      "Laptop",
+			category:         "-",
+			amount:           900,
+			expectedCategory: "Big",
+		},
+		{
+			name: "No matching rule",
+			ruleStorageFn: func(t *testing.T) domain.RuleStorage {
+				t.Helper()
+
+				result := mocks.NewMockRuleStorage(ctrl)
+				result.EXPECT().Load().Return(append([]domain.Rule(nil), rules...), nil).Times(1)
+
+				return result
+			},
+			description:      "Coffee",
+			category:         "-",
+			amount:           3,
+			expectedCategory: "-",
+		},
+		{
+			name: "Explicit category is kept",
+			ruleStorageFn: func(t *testing.T) domain.RuleStorage {
+				t.Helper()
+
+				return mocks.NewMockRuleStorage(ctrl)
+			},
+			description:      "UBER *TRIP",
+			category:         "Work",
+			amount:           12,
+			expectedCategory: "Work",
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			expectedExpense := domain.Expense{
+				Id:          1,
+				Description: tt.description,
+				Category:    tt.expectedCategory,
+				Amount:      tt.amount,
+				SpentAt:     spentAt,
+			}
+
+			mockStorage := mocks.NewMockExpenseStorage(ctrl)
+			mockStorage.EXPECT().NextID().Return(1, nil).Times(1)
+			mockStorage.EXPECT().Insert(expectedExpense).Return(nil).Times(1)
+
+			result, err := addCategorizedExpense(mockStorage, tt.ruleStorageFn(t), spentAt, tt.description, tt.category, tt.amount, nil, "", nil, nil)
+
+			assert.NoError(t, err)
+			assert.Equal(t, expectedExpense, result)
+		})
+	}
+}
+
+func TestApplyRules(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	currentExpenses := []domain.Expense{
+		{Id: 1, Description: "UBER *TRIP", Category: "-", Amount: 12},
+		{Id: 2, Description: "Uber ride", Category: "Transport", Amount: 9},
+		{Id: 3, Description: "Coffee", Category: "Food", Amount: 3},
+	}
+	rules := []domain.Rule{{Id: 7, Kind: domain.SubstringRule, Pattern: "uber", Category: "Transport"}}
+	expectedChanges := []CategoryChange{{Expense: currentExpenses[0], NewCategory: "Transport", RuleId: 7}}
+
+	t.Run("Dry run", func(t *testing.T) {
+		t.Parallel()
+
+		mockStorage := mocks.NewMockExpenseStorage(ctrl)
+		mockStorage.EXPECT().Query(gomock.Any()).Return(append([]domain.Expense(nil), currentExpenses...), nil).Times(1)
+
+		mockRuleStorage := mocks.NewMockRuleStorage(ctrl)
+		mockRuleStorage.EXPECT().Load().Return(rules, nil).Times(1)
+
+		result, err := previewRules(mockStorage, mockRuleStorage)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedChanges, result)
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		t.Parallel()
+
+		expectedExpenses := append([]domain.Expense(nil), currentExpenses...)
+		expectedExpenses[0].Category = "Transport"
+
+		mockStorage := mocks.NewMockExpenseStorage(ctrl)
+		mockStorage.EXPECT().Query(gomock.Any()).Return(append([]domain.Expense(nil), currentExpenses...), nil).Times(1)
+		mockStorage.EXPECT().Update(expectedExpenses[0]).Return(nil).Times(1)
+
+		mockRuleStorage := mocks.NewMockRuleStorage(ctrl)
+		mockRuleStorage.EXPECT().Load().Return(rules, nil).Times(1)
+
+		result, err := applyRules(mockStorage, mockRuleStorage)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedChanges, result)
+	})
+
+	t.Run("Load error", func(t *testing.T) {
+		t.Parallel()
+
+		mockStorage := mocks.NewMockExpenseStorage(ctrl)
+		mockStorage.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+		_, err := applyRules(mockStorage, mocks.NewMockRuleStorage(ctrl))
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,60 @@
+package expense
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"time"
+)
+
+// MonthlyTotal is the spending of one calendar month, in total and per category.
+type MonthlyTotal struct {
+	Year       int
+	Month      time.Month
+	Total      float64
+	ByCategory map[string]float64
+}
+
+// getMonthlySeries sums the expenses of the given number of months up to and including the month of until,
+// oldest first. Months without expenses are kept with zero totals, categories are rolled up to depth.
+func getMonthlySeries(storage domain.ExpenseStorage, until time.Time, months int, depth int, loc *time.Location) ([]MonthlyTotal, error) {
+	expenses, err := querySpending(storage, domain.ExpenseFilter{})
+
+	if err != nil {
+		return nil, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	return monthlySeries(expenses, until, months, depth, loc), nil
+}
+
+func monthlySeries(expenses []domain.Expense, until time.Time, months int, depth int, loc *time.Location) []MonthlyTotal {
+	if months <= 0 {
+		return nil
+	}
+
+	series := make([]MonthlyTotal, months)
+	first := time.Date(until.Year(), until.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -(months - 1), 0)
+
+	for i := range series {
+		month := first.AddDate(0, i, 0)
+		series[i] = MonthlyTotal{Year: month.Year(), Month: month.Month(), ByCategory: make(map[string]float64)}
+	}
+
+	for _, e := range domain.SplitParts(expenses) {
+		spentAt := e.LocalTime(loc)
+		i := monthsBetween(first, spentAt)
+
+		if i < 0 || i >= months {
+			continue
+		}
+
+		series[i].Total += e.Amount
+		series[i].ByCategory[domain.CategoryAtDepth(e.Category, depth)] += e.Amount
+	}
+
+	return series
+}
+
+// monthsBetween counts the calendar months from the month of from to the month of to.
+func monthsBetween(from time.Time, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
@@ -0,0 +1,96 @@
+package expense
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense/mocks"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetMonthlySeries(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	until := time.Date(2024, 2, 10, 12, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name           string
+		storageFn      func(t *testing.T) domain.ExpenseStorage
+		months         int
+		expectedSeries []MonthlyTotal
+		expectedErr    error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Months across a year boundary with rolled up categories",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Category: "Food:Groceries", Amount: 10, SpentAt: time.Date(2023, 12, 5, 0, 0, 0, 0, time.UTC)},
+					{Id: 2, Category: "Food:Restaurants", Amount: 20, SpentAt: time.Date(2023, 12, 20, 0, 0, 0, 0, time.UTC)},
+					{Id: 3, Category: "Transport", Amount: 5, SpentAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+					{Id: 4, Category: "Transport", Amount: 100, SpentAt: time.Date(2023, 11, 30, 0, 0, 0, 0, time.UTC)},
+					{Id: 5, Category: "Transport", Amount: 100, SpentAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			months: 3,
+			expectedSeries: []MonthlyTotal{
+				{Year: 2023, Month: time.December, Total: 30, ByCategory: map[string]float64{"Food": 30}},
+				{Year: 2024, Month: time.January, Total: 0, ByCategory: map[string]float64{}},
+				{Year: 2024, Month: time.February, Total: 5, ByCategory: map[string]float64{"Transport": 5}},
+			},
+		},
+		{
+			name: "No months",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(nil)).Times(1)
+
+				return result
+			},
+			months:         0,
+			expectedSeries: nil,
+		},
+		{
+			name: "Load error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+				return result
+			},
+			months:      12,
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := tt.storageFn(t)
+			result, err := getMonthlySeries(mockStorage, until, tt.months, 1, time.UTC)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedSeries, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package expense
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidSharing  = errors.New("invalid sharing")
+	ErrEmptyMemberName = errors.New("member name cannot be empty")
+	ErrSameMember      = errors.New("a settlement needs two different members")
+)
+
+// MemberBalance sums up the shared expenses of a member: what they paid, what they owe, settlements
+// included on both sides, and the difference. A positive balance is owed to the member, a negative one they owe.
+type MemberBalance struct {
+	Name    string
+	Paid    float64
+	Owed    float64
+	Balance float64
+}
+
+// Settlement is a payment evening out the balances of two members.
+type Settlement struct {
+	From   string
+	To     string
+	Amount float64
+}
+
+// shareExpense replaces the sharing of an expense with a checked copy of sharing. A nil sharing leaves the
+// expense unshared.
+func shareExpense(e domain.Expense, sharing *domain.Sharing) (domain.Expense, error) {
+	e.Sharing = nil
+
+	if sharing == nil {
+		return e, nil
+	}
+
+	shared := domain.Sharing{
+		PaidBy:       domain.NormalizeMember(sharing.PaidBy),
+		Method:       cmp.Or(sharing.Method, domain.EqualShare),
+		Participants: make([]domain.Participant, len(sharing.Participants)),
+	}
+
+	for i, p := range sharing.Participants {
+		shared.Participants[i] = domain.Participant{Name: domain.NormalizeMember(p.Name), Share: p.Share}
+
+		if shared.Method == domain.EqualShare {
+			shared.Participants[i].Share = 0
+		}
+	}
+
+	if err := shared.Validate(e.Amount); err != nil {
+		return domain.Expense{}, fmt.Errorf("%w: %w", ErrInvalidSharing, err)
+	}
+
+	e.Sharing = &shared
+
+	return e, nil
+}
+
+// getMemberBalances sums up the shared expenses and settlements per member, ordered by name.
+// Names differing only in case or spacing are counted as one member.
+func getMemberBalances(storage domain.ExpenseStorage) ([]MemberBalance, error) {
+	expenses, err := storage.Query(domain.ExpenseFilter{})
+
+	if err != nil {
+		return nil, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	balances := make([]MemberBalance, 0)
+	indexOf := func(name string) int {
+		index := slices.IndexFunc(balances, func(b MemberBalance) bool {
+			return domain.SameMember(b.Name, name)
+		})
+
+		if index < 0 {
+			balances = append(balances, MemberBalance{Name: name})
+			index = len(balances) - 1
+		}
+
+		return index
+	}
+
+	for _, e := range expenses {
+		if !e.IsShared() {
+			continue
+		}
+
+		balances[indexOf(e.Sharing.PaidBy)].Paid += e.Amount
+
+		for i, owed := range e.Sharing.Owed(e.Amount) {
+			balances[indexOf(e.Sharing.Participants[i].Name)].Owed += owed
+		}
+	}
+
+	for i := range balances {
+		balances[i].Balance = math.Round((balances[i].Paid-balances[i].Owed)*100) / 100
+	}
+
+	slices.SortFunc(balances, func(a, b MemberBalance) int {
+		return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+
+	return balances, nil
+}
+
+// SettleUp finds payments evening out the balances: the member owing the most pays the member owed the most,
+// until nobody owes anything. Every payment clears at least one balance, so there are fewer payments than members.
+func SettleUp(balances []MemberBalance) []Settlement {
+	type member struct {
+		name  string
+		cents int64
+	}
+
+	debtors, creditors := make([]member, 0), make([]member, 0)
+
+	for _, b := range balances {
+		cents := int64(math.Round(b.Balance * 100))
+
+		switch {
+		case cents < 0:
+			debtors = append(debtors, member{name: b.Name, cents: -cents})
+		case cents > 0:
+			creditors = append(creditors, member{name: b.Name, cents: cents})
+		}
+	}
+
+	byAmount := func(a, b member) int {
+		return cmp.Or(cmp.Compare(b.cents, a.cents), cmp.Compare(a.name, b.name))
+	}
+
+	slices.SortFunc(debtors, byAmount)
+	slices.SortFunc(creditors, byAmount)
+
+	settlements := make([]Settlement, 0)
+
+	for i, j := 0, 0; i < len(debtors) && j < len(creditors); {
+		cents := min(debtors[i].cents, creditors[j].cents)
+		settlements = append(settlements, Settlement{From: debtors[i].name, To: creditors[j].name, Amount: float64(cents) / 100})
+
+		debtors[i].cents -= cents
+		creditors[j].cents -= cents
+
+		if debtors[i].cents == 0 {
+			i++
+		}
+
+		if creditors[j].cents == 0 {
+			j++
+		}
+	}
+
+	return settlements
+}
+
+// settle records a payment from one member to another. It is kept as a settlement expense paid by from and
+// owed entirely by to, so it evens out their balances without being counted as spending.
+func settle(storage domain.ExpenseStorage, spentAt time.Time, from string, to string, amount float64) (domain.Expense, error) {
+	from, to = domain.NormalizeMember(from), domain.NormalizeMember(to)
+
+	if from == "" || to == "" {
+		return domain.Expense{}, ErrEmptyMemberName
+	}
+
+	if domain.SameMember(from, to) {
+		return domain.Expense{}, ErrSameMember
+	}
+
+	if amount <= 0 {
+		return domain.Expense{}, ErrInvalidAmount
+	}
+
+	id, err := storage.NextID()
+
+	if err != nil {
+		return domain.Expense{}, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	settlement := domain.Expense{
+		Id:          id,
+		SpentAt:     spentAt,
+		Description: fmt.Sprintf("%s pays %s back", from, to),
+		Amount:      amount,
+		Kind:        domain.SettlementKind,
+		Sharing: &domain.Sharing{
+			PaidBy:       from,
+			Method:       domain.ExactShare,
+			Participants: []domain.Participant{{Name: to, Share: amount}},
+		},
+	}
+
+	err = storage.Insert(settlement)
+
+	if err != nil {
+		return domain.Expense{}, fmt.Errorf("Error saving expenses: %w", err)
+	}
+
+	return settlement, nil
+}
@@ -0,0 +1,327 @@
+package expense
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense/mocks"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestShareExpense(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name            string
+		amount          float64
+		sharing         *domain.Sharing
+		expectedSharing *domain.Sharing
+		expectedErr     error
+	}
+
+	testCases := []testCase{
+		{
+			name:            "Not shared",
+			amount:          30,
+			sharing:         nil,
+			expectedSharing: nil,
+		},
+		{
+			name:   "Equal by default",
+			amount: 30,
+			sharing: &domain.Sharing{PaidBy: " alice ", Participants: []domain.Participant{
+				{Name: "alice", Share: 5},
+				{Name: "bob  smith"},
+			}},
+			expectedSharing: &domain.Sharing{PaidBy: "alice", Method: domain.EqualShare, Participants: []domain.Participant{
+				{Name: "alice"},
+				{Name: "bob smith"},
+			}},
+		},
+		{
+			name:   "Exact amounts",
+			amount: 30,
+			sharing: &domain.Sharing{PaidBy: "alice", Method: domain.ExactShare, Participants: []domain.Participant{
+				{Name: "alice", Share: 10},
+				{Name: "bob", Share: 20},
+			}},
+			expectedSharing: &domain.Sharing{PaidBy: "alice", Method: domain.ExactShare, Participants: []domain.Participant{
+				{Name: "alice", Share: 10},
+				{Name: "bob", Share: 20},
+			}},
+		},
+		{
+			name:   "Exact amounts not adding up",
+			amount: 30,
+			sharing: &domain.Sharing{PaidBy: "alice", Method: domain.ExactShare, Participants: []domain.Participant{
+				{Name: "alice", Share: 10},
+				{Name: "bob", Share: 15},
+			}},
+			expectedErr: ErrInvalidSharing,
+		},
+		{
+			name:   "Missing payer",
+			amount: 30,
+			sharing: &domain.Sharing{Participants: []domain.Participant{
+				{Name: "alice"},
+			}},
+			expectedErr: ErrInvalidSharing,
+		},
+		{
+			name:   "Member taking part twice",
+			amount: 30,
+			sharing: &domain.Sharing{PaidBy: "alice", Participants: []domain.Participant{
+				{Name: "Bob"},
+				{Name: "bob"},
+			}},
+			expectedErr: ErrInvalidSharing,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := shareExpense(domain.Expense{Id: 1, Amount: tt.amount}, tt.sharing)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedSharing, result.Sharing)
+			}
+		})
+	}
+}
+
+func TestGetMemberBalances(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	type testCase struct {
+		name             string
+		storageFn        func(t *testing.T) domain.ExpenseStorage
+		expectedBalances []MemberBalance
+		expectedErr      error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Equal, weighted and exact shares",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Amount: 90, Sharing: &domain.Sharing{PaidBy: "Alice", Method: domain.EqualShare, Participants: []domain.Participant{
+						{Name: "Alice"}, {Name: "Bob"}, {Name: "Carol"},
+					}}},
+					{Id: 2, Amount: 30, Sharing: &domain.Sharing{PaidBy: "bob", Method: domain.WeightedShare, Participants: []domain.Participant{
+						{Name: "alice", Share: 2}, {Name: "bob", Share: 1},
+					}}},
+					{Id: 3, Amount: 10, Sharing: &domain.Sharing{PaidBy: "Carol", Method: domain.ExactShare, Participants: []domain.Participant{
+						{Name: "Bob", Share: 10},
+					}}},
+					{Id: 4, Amount: 50},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			expectedBalances: []MemberBalance{
+				{Name: "Alice", Paid: 90, Owed: 50, Balance: 40},
+				{Name: "Bob", Paid: 30, Owed: 50, Balance: -20},
+				{Name: "Carol", Paid: 10, Owed: 30, Balance: -20},
+			},
+		},
+		{
+			name: "Settlements even out the balances",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Amount: 40, Sharing: &domain.Sharing{PaidBy: "Alice", Method: domain.EqualShare, Participants: []domain.Participant{
+						{Name: "Alice"}, {Name: "Bob"},
+					}}},
+					{Id: 2, Amount: 20, Kind: domain.SettlementKind, Sharing: &domain.Sharing{PaidBy: "Bob", Method: domain.ExactShare, Participants: []domain.Participant{
+						{Name: "Alice", Share: 20},
+					}}},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			expectedBalances: []MemberBalance{
+				{Name: "Alice", Paid: 40, Owed: 40, Balance: 0},
+				{Name: "Bob", Paid: 20, Owed: 20, Balance: 0},
+			},
+		},
+		{
+			name: "Load error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+				return result
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := getMemberBalances(tt.storageFn(t))
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedBalances, result)
+			}
+		})
+	}
+}
+
+func TestSettleUp(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name                string
+		balances            []MemberBalance
+		expectedSettlements []Settlement
+	}
+
+	testCases := []testCase{
+		{
+			name: "Largest debts paid to the largest creditors",
+			balances: []MemberBalance{
+				{Name: "Alice", Balance: 40},
+				{Name: "Bob", Balance: -25},
+				{Name: "Carol", Balance: -15},
+				{Name: "Dave", Balance: 0},
+			},
+			expectedSettlements: []Settlement{
+				{From: "Bob", To: "Alice", Amount: 25},
+				{From: "Carol", To: "Alice", Amount: 15},
+			},
+		},
+		{
+			name: "A debt split between two creditors",
+			balances: []MemberBalance{
+				{Name: "Alice", Balance: 10.5},
+				{Name: "Bob", Balance: 4.5},
+				{Name: "Carol", Balance: -15},
+			},
+			expectedSettlements: []Settlement{
+				{From: "Carol", To: "Alice", Amount: 10.5},
+				{From: "Carol", To: "Bob", Amount: 4.5},
+			},
+		},
+		{
+			name: "Everybody even",
+			balances: []MemberBalance{
+				{Name: "Alice"},
+				{Name: "Bob"},
+			},
+			expectedSettlements: []Settlement{},
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expectedSettlements, SettleUp(tt.balances))
+		})
+	}
+}
+
+func TestSettle(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	spentAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name               string
+		from               string
+		to                 string
+		amount             float64
+		expectedSettlement domain.Expense
+		expectedErr        error
+	}
+
+	testCases := []testCase{
+		{
+			name:   "Successful settlement",
+			from:   " Bob ",
+			to:     "Alice",
+			amount: 20,
+			expectedSettlement: domain.Expense{
+				Id:          4,
+				SpentAt:     spentAt,
+				Description: "Bob pays Alice back",
+				Amount:      20,
+				Kind:        domain.SettlementKind,
+				Sharing: &domain.Sharing{PaidBy: "Bob", Method: domain.ExactShare, Participants: []domain.Participant{
+					{Name: "Alice", Share: 20},
+				}},
+			},
+		},
+		{
+			name:        "Same member",
+			from:        "Bob",
+			to:          "bob",
+			amount:      20,
+			expectedErr: ErrSameMember,
+		},
+		{
+			name:        "Missing member",
+			from:        "",
+			to:          "Alice",
+			amount:      20,
+			expectedErr: ErrEmptyMemberName,
+		},
+		{
+			name:        "Non-positive amount",
+			from:        "Bob",
+			to:          "Alice",
+			amount:      0,
+			expectedErr: ErrInvalidAmount,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			storage := mocks.NewMockExpenseStorage(ctrl)
+
+			if tt.expectedErr == nil {
+				firstCall := storage.EXPECT().NextID().Return(4, nil).Times(1)
+				storage.EXPECT().Insert(tt.expectedSettlement).Return(nil).Times(1).After(firstCall)
+			}
+
+			result, err := settle(storage, spentAt, tt.from, tt.to, tt.amount)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedSettlement, result)
+			}
+		})
+	}
+}
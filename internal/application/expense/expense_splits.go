@@ -0,0 +1,38 @@
+package expense
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"strings"
+)
+
+var ErrInvalidSplits = errors.New("invalid split lines")
+
+// splitExpense replaces the split lines of an expense and books it under the category of the largest line.
+// Without lines the expense keeps its own category.
+func splitExpense(e domain.Expense, splits []domain.Split) (domain.Expense, error) {
+	e.Splits = nil
+
+	if len(splits) == 0 {
+		return e, nil
+	}
+
+	e.Splits = make([]domain.Split, len(splits))
+
+	for i, split := range splits {
+		e.Splits[i] = domain.Split{
+			Category: domain.NormalizeCategory(split.Category),
+			Amount:   split.Amount,
+			Note:     strings.TrimSpace(split.Note),
+		}
+	}
+
+	if err := e.ValidateSplits(); err != nil {
+		return domain.Expense{}, fmt.Errorf("%w: %w", ErrInvalidSplits, err)
+	}
+
+	e.Category = e.Splits[domain.MainSplit(e.Splits)].Category
+
+	return e, nil
+}
@@ -0,0 +1,142 @@
+package expense
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/samber/lo"
+	"slices"
+	"strings"
+	"time"
+)
+
+// suggestionHalfLifeDays is the age in days at which an expense counts half as much as one spent today.
+const suggestionHalfLifeDays = 30.0
+
+// Suggestions holds the known categories and descriptions, the most relevant first.
+type Suggestions struct {
+	Categories   []string
+	Descriptions []DescriptionSuggestion
+}
+
+// DescriptionSuggestion is a known description with the category and amount it usually comes with.
+type DescriptionSuggestion struct {
+	Description string
+	Category    string
+	Amount      float64
+}
+
+// FindDescription returns the suggestion for a description, ignoring case and surrounding spaces.
+func (s Suggestions) FindDescription(description string) (DescriptionSuggestion, bool) {
+	key := descriptionKey(description)
+
+	return lo.Find(s.Descriptions, func(d DescriptionSuggestion) bool {
+		return descriptionKey(d.Description) == key
+	})
+}
+
+type descriptionUsage struct {
+	description string
+	lastSpentAt time.Time
+	score       float64
+	categories  map[string]float64
+	amounts     []float64
+}
+
+// getSuggestions ranks categories and descriptions by how often and how recently they were used.
+func getSuggestions(storage domain.ExpenseStorage, now time.Time) (Suggestions, error) {
+	expenses, err := querySpending(storage, domain.ExpenseFilter{})
+
+	if err != nil {
+		return Suggestions{}, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	categoryScores := make(map[string]float64)
+	usages := make(map[string]*descriptionUsage)
+
+	for _, e := range expenses {
+		weight := suggestionWeight(e.SpentAt, now)
+
+		if e.Category != "" && e.Category != "-" {
+			categoryScores[e.Category] += weight
+		}
+
+		key := descriptionKey(e.Description)
+		if key == "" || key == "-" {
+			continue
+		}
+
+		usage, ok := usages[key]
+		if !ok {
+			usage = &descriptionUsage{categories: make(map[string]float64)}
+			usages[key] = usage
+		}
+
+		if usage.description == "" || !e.SpentAt.Before(usage.lastSpentAt) {
+			usage.description = strings.TrimSpace(e.Description)
+			usage.lastSpentAt = e.SpentAt
+		}
+
+		usage.score += weight
+		usage.categories[e.Category] += weight
+		usage.amounts = append(usage.amounts, e.Amount)
+	}
+
+	categories := lo.Keys(categoryScores)
+	slices.SortFunc(categories, func(a, b string) int {
+		return compareScores(categoryScores[a], categoryScores[b], a, b)
+	})
+
+	ranked := lo.Values(usages)
+	slices.SortFunc(ranked, func(a, b *descriptionUsage) int {
+		return compareScores(a.score, b.score, a.description, b.description)
+	})
+
+	descriptions := lo.Map(ranked, func(usage *descriptionUsage, _ int) DescriptionSuggestion {
+		category := lo.MaxBy(lo.Keys(usage.categories), func(a, b string) bool {
+			return compareScores(usage.categories[a], usage.categories[b], a, b) < 0
+		})
+
+		return DescriptionSuggestion{
+			Description: usage.description,
+			Category:    category,
+			Amount:      median(usage.amounts),
+		}
+	})
+
+	return Suggestions{Categories: categories, Descriptions: descriptions}, nil
+}
+
+// suggestionWeight gives recent expenses more weight, halving it every suggestionHalfLifeDays.
+func suggestionWeight(spentAt time.Time, now time.Time) float64 {
+	ageDays := max(now.Sub(spentAt).Hours()/24, 0)
+	return 1 / (1 + ageDays/suggestionHalfLifeDays)
+}
+
+// compareScores orders by the higher score first, then by name.
+func compareScores(scoreA float64, scoreB float64, nameA string, nameB string) int {
+	if scoreA != scoreB {
+		return cmp.Compare(scoreB, scoreA)
+	}
+
+	return cmp.Compare(nameA, nameB)
+}
+
+func descriptionKey(description string) string {
+	return strings.ToLower(strings.TrimSpace(description))
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := slices.Sorted(slices.Values(values))
+	middle := len(sorted) / 2
+
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+
+	return sorted[middle]
+}
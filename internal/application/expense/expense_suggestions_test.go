@@ -0,0 +1,106 @@
+package expense
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense/mocks"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetSuggestions(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	now := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name                string
+		storageFn           func(t *testing.T) domain.ExpenseStorage
+		expectedSuggestions Suggestions
+		expectedErr         error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Ranked by frequency and recency",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Description: "tesco", Category: "Groceries", Amount: 30, SpentAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+					{Id: 2, Description: "Tesco", Category: "Groceries", Amount: 40, SpentAt: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)},
+					{Id: 3, Description: "Tesco ", Category: "Household", Amount: 90, SpentAt: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)},
+					{Id: 4, Description: "Cinema", Category: "Fun", Amount: 12, SpentAt: time.Date(2024, 6, 29, 0, 0, 0, 0, time.UTC)},
+					{Id: 5, Description: "-", Category: "-", Amount: 5, SpentAt: time.Date(2024, 6, 29, 0, 0, 0, 0, time.UTC)},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			expectedSuggestions: Suggestions{
+				Categories: []string{"Fun", "Groceries", "Household"},
+				Descriptions: []DescriptionSuggestion{
+					{Description: "Cinema", Category: "Fun", Amount: 12},
+					{Description: "Tesco", Category: "Groceries", Amount: 40},
+				},
+			},
+		},
+		{
+			name: "Frequency wins at equal age",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				spentAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+				currentExpenses := []domain.Expense{
+					{Id: 1, Description: "Bus", Category: "Transport", Amount: 2, SpentAt: spentAt},
+					{Id: 2, Description: "Bus", Category: "Transport", Amount: 3, SpentAt: spentAt},
+					{Id: 3, Description: "Lunch", Category: "Food", Amount: 10, SpentAt: spentAt},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			expectedSuggestions: Suggestions{
+				Categories: []string{"Transport", "Food"},
+				Descriptions: []DescriptionSuggestion{
+					{Description: "Bus", Category: "Transport", Amount: 2.5},
+					{Description: "Lunch", Category: "Food", Amount: 10},
+				},
+			},
+		},
+		{
+			name: "Load error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+				return result
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := tt.storageFn(t)
+			result, err := getSuggestions(mockStorage, now)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedSuggestions, result)
+			}
+		})
+	}
+}
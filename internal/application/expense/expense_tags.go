@@ -0,0 +1,56 @@
+package expense
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/samber/lo"
+	"slices"
+	"time"
+)
+
+// getAllTags returns every tag in use, the most frequently used first.
+func getAllTags(storage domain.ExpenseStorage) ([]string, error) {
+	expenses, err := storage.Query(domain.ExpenseFilter{})
+
+	if err != nil {
+		return nil, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	counts := make(map[string]int)
+
+	for _, e := range expenses {
+		for _, tag := range e.Tags {
+			counts[tag]++
+		}
+	}
+
+	tags := lo.Keys(counts)
+	slices.SortFunc(tags, func(a, b string) int {
+		if counts[a] != counts[b] {
+			return counts[b] - counts[a]
+		}
+
+		return cmp.Compare(a, b)
+	})
+
+	return tags, nil
+}
+
+func getTagsSummary(storage domain.ExpenseStorage, year int, month time.Month, loc *time.Location) (map[string]float64, error) {
+	expenses, err := querySpending(storage, domain.MonthFilter(year, month, loc))
+
+	if err != nil {
+		return nil, fmt.Errorf("Error loading expenses: %w", err)
+	}
+
+	summary := make(map[string]float64)
+
+	for _, e := range expenses {
+		for _, tag := range e.Tags {
+			summary[tag] += e.Amount
+		}
+	}
+
+	return summary, nil
+}
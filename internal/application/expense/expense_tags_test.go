@@ -0,0 +1,157 @@
+package expense
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense/mocks"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetAllTags(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	type testCase struct {
+		name         string
+		storageFn    func(t *testing.T) domain.ExpenseStorage
+		expectedTags []string
+		expectedErr  error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Ordered by usage",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Description: "Hotel", Tags: []string{"trip-lisbon", "work"}},
+					{Id: 2, Description: "Taxi", Tags: []string{"reimbursable", "work"}},
+					{Id: 3, Description: "Dinner", Tags: []string{"trip-lisbon", "work"}},
+					{Id: 4, Description: "Coffee"},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			expectedTags: []string{"work", "trip-lisbon", "reimbursable"},
+		},
+		{
+			name: "No tags",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver([]domain.Expense{{Id: 1}})).Times(1)
+
+				return result
+			},
+			expectedTags: []string{},
+		},
+		{
+			name: "Load error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+				return result
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := tt.storageFn(t)
+			result, err := getAllTags(mockStorage)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedTags, result)
+			}
+		})
+	}
+}
+
+func TestGetTagsSummary(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	type testCase struct {
+		name            string
+		storageFn       func(t *testing.T) domain.ExpenseStorage
+		year            int
+		month           time.Month
+		expectedSummary map[string]float64
+		expectedErr     error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Totals per tag for the month",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				currentExpenses := []domain.Expense{
+					{Id: 1, Amount: 100, Tags: []string{"trip-lisbon", "work"}, SpentAt: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+					{Id: 2, Amount: 20, Tags: []string{"work"}, SpentAt: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+					{Id: 3, Amount: 7, SpentAt: time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)},
+					{Id: 4, Amount: 50, Tags: []string{"work"}, SpentAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+				}
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(currentExpenses)).Times(1)
+
+				return result
+			},
+			year:  2024,
+			month: time.January,
+			expectedSummary: map[string]float64{
+				"trip-lisbon": 100,
+				"work":        120,
+			},
+		},
+		{
+			name: "Load error",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).Return(nil, assert.AnError).Times(1)
+
+				return result
+			},
+			year:        2024,
+			month:       time.January,
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := tt.storageFn(t)
+			result, err := getTagsSummary(mockStorage, tt.year, tt.month, time.UTC)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedSummary, result)
+			}
+		})
+	}
+}
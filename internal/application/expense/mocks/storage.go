@@ -1,11 +1,12 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/Lexv0lk/expense-tracker-tui/internal/domain (interfaces: ExpenseStorage)
+// Source: github.com/Lexv0lk/expense-tracker-tui/internal/domain (interfaces: ExpenseStorage,RuleStorage,BackupStorage,AccountStorage)
 
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/Lexv0lk/expense-tracker-tui/internal/domain"
 	gomock "github.com/golang/mock/gomock"
@@ -34,23 +35,298 @@ func (m *MockExpenseStorage) EXPECT() *MockExpenseStorageMockRecorder {
 	return m.recorder
 }
 
+// Delete mocks base method.
+func (m *MockExpenseStorage) Delete(arg0 ...int) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockExpenseStorageMockRecorder) Delete(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockExpenseStorage)(nil).Delete), arg0...)
+}
+
+// Get mocks base method.
+func (m *MockExpenseStorage) Get(arg0 int) (domain.Expense, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(domain.Expense)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockExpenseStorageMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockExpenseStorage)(nil).Get), arg0)
+}
+
+// Insert mocks base method.
+func (m *MockExpenseStorage) Insert(arg0 ...domain.Expense) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Insert", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockExpenseStorageMockRecorder) Insert(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockExpenseStorage)(nil).Insert), arg0...)
+}
+
+// NextID mocks base method.
+func (m *MockExpenseStorage) NextID() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextID")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextID indicates an expected call of NextID.
+func (mr *MockExpenseStorageMockRecorder) NextID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextID", reflect.TypeOf((*MockExpenseStorage)(nil).NextID))
+}
+
+// Query mocks base method.
+func (m *MockExpenseStorage) Query(arg0 domain.ExpenseFilter) ([]domain.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", arg0)
+	ret0, _ := ret[0].([]domain.Expense)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockExpenseStorageMockRecorder) Query(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockExpenseStorage)(nil).Query), arg0)
+}
+
+// Replace mocks base method.
+func (m *MockExpenseStorage) Replace(arg0 ...domain.Expense) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Replace", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockExpenseStorageMockRecorder) Replace(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockExpenseStorage)(nil).Replace), arg0...)
+}
+
+// Update mocks base method.
+func (m *MockExpenseStorage) Update(arg0 ...domain.Expense) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Update", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockExpenseStorageMockRecorder) Update(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockExpenseStorage)(nil).Update), arg0...)
+}
+
+// MockRuleStorage is a mock of RuleStorage interface.
+type MockRuleStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockRuleStorageMockRecorder
+}
+
+// MockRuleStorageMockRecorder is the mock recorder for MockRuleStorage.
+type MockRuleStorageMockRecorder struct {
+	mock *MockRuleStorage
+}
+
+// NewMockRuleStorage creates a new mock instance.
+func NewMockRuleStorage(ctrl *gomock.Controller) *MockRuleStorage {
+	mock := &MockRuleStorage{ctrl: ctrl}
+	mock.recorder = &MockRuleStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRuleStorage) EXPECT() *MockRuleStorageMockRecorder {
+	return m.recorder
+}
+
 // Load mocks base method.
-func (m *MockExpenseStorage) Load() ([]domain.Expense, error) {
+func (m *MockRuleStorage) Load() ([]domain.Rule, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Load")
+	ret0, _ := ret[0].([]domain.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Load indicates an expected call of Load.
+func (mr *MockRuleStorageMockRecorder) Load() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockRuleStorage)(nil).Load))
+}
+
+// Save mocks base method.
+func (m *MockRuleStorage) Save(arg0 []domain.Rule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRuleStorageMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRuleStorage)(nil).Save), arg0)
+}
+
+// MockBackupStorage is a mock of BackupStorage interface.
+type MockBackupStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackupStorageMockRecorder
+}
+
+// MockBackupStorageMockRecorder is the mock recorder for MockBackupStorage.
+type MockBackupStorageMockRecorder struct {
+	mock *MockBackupStorage
+}
+
+// NewMockBackupStorage creates a new mock instance.
+func NewMockBackupStorage(ctrl *gomock.Controller) *MockBackupStorage {
+	mock := &MockBackupStorage{ctrl: ctrl}
+	mock.recorder = &MockBackupStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackupStorage) EXPECT() *MockBackupStorageMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBackupStorage) Create(arg0 []domain.Expense, arg1 time.Time) (domain.Backup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(domain.Backup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBackupStorageMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBackupStorage)(nil).Create), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockBackupStorage) List() ([]domain.Backup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]domain.Backup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockBackupStorageMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBackupStorage)(nil).List))
+}
+
+// Load mocks base method.
+func (m *MockBackupStorage) Load(arg0 string) ([]domain.Expense, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load", arg0)
 	ret0, _ := ret[0].([]domain.Expense)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Load indicates an expected call of Load.
-func (mr *MockExpenseStorageMockRecorder) Load() *gomock.Call {
+func (mr *MockBackupStorageMockRecorder) Load(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockBackupStorage)(nil).Load), arg0)
+}
+
+// Remove mocks base method.
+func (m *MockBackupStorage) Remove(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockBackupStorageMockRecorder) Remove(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockBackupStorage)(nil).Remove), arg0)
+}
+
+// MockAccountStorage is a mock of AccountStorage interface.
+type MockAccountStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountStorageMockRecorder
+}
+
+// MockAccountStorageMockRecorder is the mock recorder for MockAccountStorage.
+type MockAccountStorageMockRecorder struct {
+	mock *MockAccountStorage
+}
+
+// NewMockAccountStorage creates a new mock instance.
+func NewMockAccountStorage(ctrl *gomock.Controller) *MockAccountStorage {
+	mock := &MockAccountStorage{ctrl: ctrl}
+	mock.recorder = &MockAccountStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountStorage) EXPECT() *MockAccountStorageMockRecorder {
+	return m.recorder
+}
+
+// Load mocks base method.
+func (m *MockAccountStorage) Load() ([]domain.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load")
+	ret0, _ := ret[0].([]domain.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Load indicates an expected call of Load.
+func (mr *MockAccountStorageMockRecorder) Load() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockExpenseStorage)(nil).Load))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockAccountStorage)(nil).Load))
 }
 
 // Save mocks base method.
-func (m *MockExpenseStorage) Save(arg0 []domain.Expense) error {
+func (m *MockAccountStorage) Save(arg0 []domain.Account) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Save", arg0)
 	ret0, _ := ret[0].(error)
@@ -58,7 +334,7 @@ func (m *MockExpenseStorage) Save(arg0 []domain.Expense) error {
 }
 
 // Save indicates an expected call of Save.
-func (mr *MockExpenseStorageMockRecorder) Save(arg0 interface{}) *gomock.Call {
+func (mr *MockAccountStorageMockRecorder) Save(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockExpenseStorage)(nil).Save), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAccountStorage)(nil).Save), arg0)
 }
@@ -1,19 +1,282 @@
 package expense
 
 import (
+	"cmp"
+	"fmt"
 	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/backup"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/config"
 	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/files"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/journal"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/sqlite"
+	"github.com/samber/lo"
+	"slices"
+	"time"
 )
 
+const (
+	rulesFileName    = "rules.json"
+	accountsFileName = "accounts.json"
+)
+
+// loadSettings reads the settings of the current ledger. Unreadable settings are reported rather than
+// replaced with the defaults, which would count days in the wrong timezone or write to the wrong backend.
+func loadSettings() (config.Settings, error) {
+	settings, err := config.Load()
+	if err != nil {
+		return config.Settings{}, fmt.Errorf("Error loading settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// getLocation returns the timezone from the settings that days and months are counted in.
+func getLocation() (*time.Location, error) {
+	settings, err := loadSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	return settings.Location(), nil
+}
+
+// expenseStorage resolves the backend chosen in the settings for an operation. The settings are read
+// once per operation, so switching the backend applies right away. Unreadable settings or an unknown
+// backend are reported instead of falling back to an empty JSON file.
+func expenseStorage() (domain.ExpenseStorage, error) {
+	settings, err := loadSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	return configuredStorage(settings, defaultBackupStorage, time.Now)
+}
+
+func configuredStorage(settings config.Settings, backups domain.BackupStorage, now func() time.Time) (domain.ExpenseStorage, error) {
+	backend, ok := expenseStorageNamed(settings.Storage)
+	if !ok {
+		return nil, &UnknownStorageError{Name: settings.Storage}
+	}
+
+	return &configuredExpenseStorage{backend: backend, settings: settings, backups: backups, now: now}, nil
+}
+
+// configuredExpenseStorage forwards to the backend chosen in the settings, taking the daily backup
+// before its first change of the day.
+type configuredExpenseStorage struct {
+	backend  domain.ExpenseStorage
+	settings config.Settings
+	backups  domain.BackupStorage
+	now      func() time.Time
+}
+
+func (c *configuredExpenseStorage) Get(id int) (domain.Expense, bool, error) {
+	return c.backend.Get(id)
+}
+
+func (c *configuredExpenseStorage) Insert(expenses ...domain.Expense) error {
+	if err := c.backupBeforeWrite(); err != nil {
+		return err
+	}
+
+	return c.backend.Insert(expenses...)
+}
+
+func (c *configuredExpenseStorage) Update(expenses ...domain.Expense) error {
+	if err := c.backupBeforeWrite(); err != nil {
+		return err
+	}
+
+	return c.backend.Update(expenses...)
+}
+
+func (c *configuredExpenseStorage) Delete(ids ...int) error {
+	if err := c.backupBeforeWrite(); err != nil {
+		return err
+	}
+
+	return c.backend.Delete(ids...)
+}
+
+func (c *configuredExpenseStorage) Replace(expenses ...domain.Expense) error {
+	if err := c.backupBeforeWrite(); err != nil {
+		return err
+	}
+
+	return c.backend.Replace(expenses...)
+}
+
+func (c *configuredExpenseStorage) Query(filter domain.ExpenseFilter) ([]domain.Expense, error) {
+	return c.backend.Query(filter)
+}
+
+func (c *configuredExpenseStorage) NextID() (int, error) {
+	return c.backend.NextID()
+}
+
+// backupBeforeWrite takes the daily backup of the backend before its first change of the day.
+func (c *configuredExpenseStorage) backupBeforeWrite() error {
+	return ensureDailyBackup(c.backend, c.backups, c.now(), backupRetention(c.settings), c.settings.Location())
+}
+
+func backupRetention(settings config.Settings) BackupRetention {
+	days, months := settings.BackupRetention()
+	return BackupRetention{Days: days, Months: months}
+}
+
+// ledgerBackupStorage forwards to the backups of the current ledger.
+type ledgerBackupStorage struct {
+}
+
+var defaultBackupStorage domain.BackupStorage = &ledgerBackupStorage{}
+
+func (l *ledgerBackupStorage) Create(expenses []domain.Expense, at time.Time) (domain.Backup, error) {
+	return backupFiles().Create(expenses, at)
+}
+
+func (l *ledgerBackupStorage) List() ([]domain.Backup, error) {
+	return backupFiles().List()
+}
+
+func (l *ledgerBackupStorage) Load(name string) ([]domain.Expense, error) {
+	return backupFiles().Load(name)
+}
+
+func (l *ledgerBackupStorage) Remove(name string) error {
+	return backupFiles().Remove(name)
+}
+
+func backupFiles() *backup.Storage {
+	return backup.NewStorage(files.GetFilePath(backup.DirName))
+}
+
+var jsonExpenseStorage domain.ExpenseStorage = &expenseFileStorage{}
+
+// expenseStorageNamed returns the backend of a storage name of the settings, kept in the current ledger.
+func expenseStorageNamed(name string) (domain.ExpenseStorage, bool) {
+	switch name {
+	case "", config.JSONStorage:
+		return jsonExpenseStorage, true
+	case config.SQLiteStorage:
+		return sqlite.NewExpenseStorage(files.GetFilePath(sqlite.FileName)), true
+	case config.JournalStorage:
+		return journal.NewExpenseStorage(files.GetFilePath(journal.FileName), files.GetFilePath(journal.SnapshotFileName)), true
+	}
+
+	return nil, false
+}
+
+// expenseFileStorage adapts the JSON file, which can only be read and written as a whole, to the repository.
 type expenseFileStorage struct {
 }
 
-var defaultExpenseStorage domain.ExpenseStorage = &expenseFileStorage{}
+func (t *expenseFileStorage) Get(id int) (domain.Expense, bool, error) {
+	expenses, err := files.GetFromFile[[]domain.Expense]()
+	if err != nil {
+		return domain.Expense{}, false, err
+	}
+
+	expense, found := lo.Find(expenses, func(e domain.Expense) bool {
+		return e.Id == id
+	})
+
+	return expense, found, nil
+}
+
+func (t *expenseFileStorage) Insert(expenses ...domain.Expense) error {
+	stored, err := files.GetFromFile[[]domain.Expense]()
+	if err != nil {
+		return err
+	}
+
+	return files.SaveToFile(append(stored, expenses...))
+}
+
+func (t *expenseFileStorage) Update(expenses ...domain.Expense) error {
+	stored, err := files.GetFromFile[[]domain.Expense]()
+	if err != nil {
+		return err
+	}
+
+	updated := lo.SliceToMap(expenses, func(e domain.Expense) (int, domain.Expense) {
+		return e.Id, e
+	})
+
+	for i := range stored {
+		if e, ok := updated[stored[i].Id]; ok {
+			stored[i] = e
+		}
+	}
+
+	return files.SaveToFile(stored)
+}
+
+func (t *expenseFileStorage) Delete(ids ...int) error {
+	stored, err := files.GetFromFile[[]domain.Expense]()
+	if err != nil {
+		return err
+	}
+
+	deleted := lo.Keyify(ids)
+
+	return files.SaveToFile(lo.Reject(stored, func(e domain.Expense, _ int) bool {
+		_, ok := deleted[e.Id]
+		return ok
+	}))
+}
+
+func (t *expenseFileStorage) Replace(expenses ...domain.Expense) error {
+	return files.SaveToFile(expenses)
+}
+
+func (t *expenseFileStorage) Query(filter domain.ExpenseFilter) ([]domain.Expense, error) {
+	stored, err := files.GetFromFile[[]domain.Expense]()
+	if err != nil {
+		return nil, err
+	}
+
+	expenses := lo.Filter(stored, func(e domain.Expense, _ int) bool {
+		return filter.Matches(e)
+	})
+
+	slices.SortStableFunc(expenses, func(a, b domain.Expense) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+
+	return expenses, nil
+}
+
+func (t *expenseFileStorage) NextID() (int, error) {
+	stored, err := files.GetFromFile[[]domain.Expense]()
+	if err != nil {
+		return 0, err
+	}
+
+	return getNextExpenseId(stored), nil
+}
+
+type ruleFileStorage struct {
+}
+
+var defaultRuleStorage domain.RuleStorage = &ruleFileStorage{}
+
+func (r *ruleFileStorage) Save(rules []domain.Rule) error {
+	return files.SaveToNamedFile(rulesFileName, rules)
+}
+
+func (r *ruleFileStorage) Load() ([]domain.Rule, error) {
+	return files.GetFromNamedFile[[]domain.Rule](rulesFileName)
+}
+
+type accountFileStorage struct {
+}
+
+var defaultAccountStorage domain.AccountStorage = &accountFileStorage{}
 
-func (t *expenseFileStorage) Save(tasks []domain.Expense) error {
-	return files.SaveToFile(tasks)
+func (a *accountFileStorage) Save(accounts []domain.Account) error {
+	return files.SaveToNamedFile(accountsFileName, accounts)
 }
 
-func (t *expenseFileStorage) Load() ([]domain.Expense, error) {
-	return files.GetFromFile[[]domain.Expense]()
+func (a *accountFileStorage) Load() ([]domain.Account, error) {
+	return files.GetFromNamedFile[[]domain.Account](accountsFileName)
 }
@@ -0,0 +1,383 @@
+package expense
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense/mocks"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/config"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/files"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/journal"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/sqlite"
+	"github.com/golang/mock/gomock"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConfiguredStorage(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name        string
+		storage     string
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{name: "Default", storage: ""},
+		{name: "JSON", storage: config.JSONStorage},
+		{name: "SQLite", storage: config.SQLiteStorage},
+		{name: "Journal", storage: config.JournalStorage},
+		{name: "Unknown", storage: "sqlit", expectedErr: &UnknownStorageError{Name: "sqlit"}},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			storage, err := configuredStorage(config.Settings{Storage: tt.storage}, defaultBackupStorage, time.Now)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, storage)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, storage)
+			}
+		})
+	}
+}
+
+func TestConfiguredStorageBackup(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	stored := []domain.Expense{{Id: 1, Description: "Coffee", Category: "Food", Amount: 3}}
+	added := domain.Expense{Id: 2, Description: "Taxi", Category: "Transport", Amount: 12}
+
+	type testCase struct {
+		name        string
+		storageFn   func(t *testing.T) domain.ExpenseStorage
+		backupsFn   func(t *testing.T) domain.BackupStorage
+		write       func(storage domain.ExpenseStorage) error
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Insert takes the daily backup first",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				firstCall := result.EXPECT().Query(gomock.Any()).DoAndReturn(queryOver(stored)).Times(1)
+				result.EXPECT().Insert(added).Return(nil).Times(1).After(firstCall)
+
+				return result
+			},
+			backupsFn: func(t *testing.T) domain.BackupStorage {
+				t.Helper()
+
+				result := mocks.NewMockBackupStorage(ctrl)
+				firstCall := result.EXPECT().List().Return([]domain.Backup{backupAt(2026, 10, 18, 12)}, nil).Times(1)
+				secondCall := result.EXPECT().Create(stored, now).Return(backupAt(2026, 10, 19, 12), nil).Times(1).After(firstCall)
+				result.EXPECT().List().Return([]domain.Backup{backupAt(2026, 10, 19, 12), backupAt(2026, 10, 18, 12)}, nil).Times(1).After(secondCall)
+
+				return result
+			},
+			write: func(storage domain.ExpenseStorage) error {
+				return storage.Insert(added)
+			},
+		},
+		{
+			name: "Update after today's backup",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Query(gomock.Any()).Times(0)
+				result.EXPECT().Update(added).Return(nil).Times(1)
+
+				return result
+			},
+			backupsFn: func(t *testing.T) domain.BackupStorage {
+				t.Helper()
+
+				result := mocks.NewMockBackupStorage(ctrl)
+				result.EXPECT().List().Return([]domain.Backup{backupAt(2026, 10, 19, 9)}, nil).Times(1)
+				result.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+
+				return result
+			},
+			write: func(storage domain.ExpenseStorage) error {
+				return storage.Update(added)
+			},
+		},
+		{
+			name: "Replace after today's backup",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Replace(added).Return(nil).Times(1)
+
+				return result
+			},
+			backupsFn: func(t *testing.T) domain.BackupStorage {
+				t.Helper()
+
+				result := mocks.NewMockBackupStorage(ctrl)
+				result.EXPECT().List().Return([]domain.Backup{backupAt(2026, 10, 19, 9)}, nil).Times(1)
+
+				return result
+			},
+			write: func(storage domain.ExpenseStorage) error {
+				return storage.Replace(added)
+			},
+		},
+		{
+			name: "Backup error keeps the expenses as they were",
+			storageFn: func(t *testing.T) domain.ExpenseStorage {
+				t.Helper()
+
+				result := mocks.NewMockExpenseStorage(ctrl)
+				result.EXPECT().Delete(gomock.Any()).Times(0)
+
+				return result
+			},
+			backupsFn: func(t *testing.T) domain.BackupStorage {
+				t.Helper()
+
+				result := mocks.NewMockBackupStorage(ctrl)
+				result.EXPECT().List().Return(nil, assert.AnError).Times(1)
+
+				return result
+			},
+			write: func(storage domain.ExpenseStorage) error {
+				return storage.Delete(1)
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			storage := &configuredExpenseStorage{
+				backend: tt.storageFn(t),
+				backups: tt.backupsFn(t),
+				now:     func() time.Time { return now },
+			}
+
+			err := tt.write(storage)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestExpenseStorageSettings points the config directory elsewhere, so it does not run in parallel with
+// the other tests.
+func TestExpenseStorageSettings(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+
+	type testCase struct {
+		name             string
+		settings         string
+		expectedLocation *time.Location
+		expectedErr      error
+	}
+
+	testCases := []testCase{
+		{
+			name:             "No settings yet",
+			settings:         "",
+			expectedLocation: time.Local,
+		},
+		{
+			name:             "Timezone and backend",
+			settings:         `{"Timezone": "Europe/Berlin", "Storage": "journal"}`,
+			expectedLocation: berlin,
+		},
+		{
+			name:             "Unknown backend",
+			settings:         `{"Timezone": "Europe/Berlin", "Storage": "sqlit"}`,
+			expectedLocation: berlin,
+			expectedErr:      &UnknownStorageError{Name: "sqlit"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			assert.NoError(t, os.MkdirAll(filepath.Dir(files.GetFilePath("settings.json")), 0755))
+			assert.NoError(t, os.WriteFile(files.GetFilePath("settings.json"), []byte(tt.settings), 0600))
+
+			loc, err := getLocation()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedLocation, loc)
+
+			_, err = expenseStorage()
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("Unreadable settings", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		assert.NoError(t, os.MkdirAll(filepath.Dir(files.GetFilePath("settings.json")), 0755))
+		assert.NoError(t, os.WriteFile(files.GetFilePath("settings.json"), []byte(`{"Storage": "sql`), 0600))
+
+		_, err := getLocation()
+		assert.ErrorContains(t, err, "Error loading settings")
+
+		_, err = expenseStorage()
+		assert.ErrorContains(t, err, "Error loading settings")
+
+		_, err = GetMonthlyExpensesSummary(2026, time.October)
+		assert.ErrorContains(t, err, "Error loading settings")
+	})
+}
+
+// TestExpenseFileStorage points the config directory elsewhere, so it does not run in parallel with
+// the other tests.
+func TestExpenseFileStorage(t *testing.T) {
+	stored := []domain.Expense{
+		{Id: 1, Description: "Coffee", Category: "Food", Amount: 3},
+		{Id: 2, Description: "Taxi", Category: "Transport", Amount: 12},
+		{Id: 3, Description: "Cinema", Category: "Fun", Amount: 10},
+	}
+
+	type testCase struct {
+		name     string
+		write    func(storage domain.ExpenseStorage) error
+		expected []domain.Expense
+	}
+
+	testCases := []testCase{
+		{
+			name: "Insert",
+			write: func(storage domain.ExpenseStorage) error {
+				return storage.Insert(domain.Expense{Id: 4, Description: "Bread", Category: "Food", Amount: 2})
+			},
+			expected: append(append([]domain.Expense{}, stored...), domain.Expense{Id: 4, Description: "Bread", Category: "Food", Amount: 2}),
+		},
+		{
+			name: "Update",
+			write: func(storage domain.ExpenseStorage) error {
+				return storage.Update(domain.Expense{Id: 2, Description: "Taxi home", Category: "Transport", Amount: 15})
+			},
+			expected: []domain.Expense{stored[0], {Id: 2, Description: "Taxi home", Category: "Transport", Amount: 15}, stored[2]},
+		},
+		{
+			name: "Delete",
+			write: func(storage domain.ExpenseStorage) error {
+				return storage.Delete(1, 3, 7)
+			},
+			expected: []domain.Expense{stored[1]},
+		},
+		{
+			name: "Replace with fewer expenses",
+			write: func(storage domain.ExpenseStorage) error {
+				return storage.Replace(stored[2])
+			},
+			expected: []domain.Expense{stored[2]},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			assert.NoError(t, files.SaveToFile(stored))
+
+			storage := &expenseFileStorage{}
+			assert.NoError(t, tt.write(storage))
+
+			expenses, err := storage.Query(domain.ExpenseFilter{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, expenses)
+
+			nextID, err := storage.NextID()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected[len(tt.expected)-1].Id+1, nextID)
+		})
+	}
+}
+
+// TestBackendsQuery points the config directory elsewhere, so it does not run in parallel with the other tests.
+func TestBackendsQuery(t *testing.T) {
+	// categories carried over from older files may not be trimmed
+	stored := []domain.Expense{
+		{Id: 1, Description: "Coffee", Category: "Food ", Amount: 3, SpentAt: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{Id: 2, Description: "Bread", Category: " Food : Groceries", Amount: 2, SpentAt: time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)},
+		{Id: 3, Description: "Taxi", Category: "Transport", Amount: 12, SpentAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{Id: 4, Description: "Market", Category: "Home", Amount: 30, SpentAt: time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC),
+			Splits: []domain.Split{{Category: "Home", Amount: 20}, {Category: "Food:Groceries ", Amount: 10}}},
+		{Id: 5, Description: "Foodie magazine", Category: "Foodie", Amount: 5, SpentAt: time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC)},
+	}
+
+	type testCase struct {
+		name        string
+		filter      domain.ExpenseFilter
+		expectedIds []int
+	}
+
+	testCases := []testCase{
+		{name: "Everything", filter: domain.ExpenseFilter{}, expectedIds: []int{1, 2, 3, 4, 5}},
+		{name: "Category with its subcategories", filter: domain.ExpenseFilter{Category: "Food"}, expectedIds: []int{1, 2, 4}},
+		{name: "Subcategory", filter: domain.ExpenseFilter{Category: "Food: Groceries "}, expectedIds: []int{2, 4}},
+		{name: "Ids", filter: domain.ExpenseFilter{Ids: []int{2, 3, 9}}, expectedIds: []int{2, 3}},
+		{name: "Month and category", filter: domain.ExpenseFilter{
+			Category: "Food",
+			From:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:       time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		}, expectedIds: []int{1, 2}},
+	}
+
+	backends := map[string]func(dir string) domain.ExpenseStorage{
+		config.JSONStorage: func(dir string) domain.ExpenseStorage {
+			return &expenseFileStorage{}
+		},
+		config.SQLiteStorage: func(dir string) domain.ExpenseStorage {
+			return sqlite.NewExpenseStorage(filepath.Join(dir, sqlite.FileName))
+		},
+		config.JournalStorage: func(dir string) domain.ExpenseStorage {
+			return journal.NewExpenseStorage(filepath.Join(dir, journal.FileName), filepath.Join(dir, journal.SnapshotFileName))
+		},
+	}
+
+	for name, backendFn := range backends {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("XDG_CONFIG_HOME", dir)
+
+			storage := backendFn(dir)
+			assert.NoError(t, storage.Replace(stored...))
+
+			for _, tt := range testCases {
+				t.Run(tt.name, func(t *testing.T) {
+					expenses, err := storage.Query(tt.filter)
+					assert.NoError(t, err)
+					assert.Equal(t, tt.expectedIds, lo.Map(expenses, func(e domain.Expense, _ int) int {
+						return e.Id
+					}))
+				})
+			}
+		})
+	}
+}
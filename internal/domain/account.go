@@ -0,0 +1,27 @@
+package domain
+
+import "strings"
+
+// Account is where the money of an expense comes from, e.g. cash, a debit card or a shared wallet.
+// OpeningBalance is what the account held before the first expense recorded against it.
+type Account struct {
+	Name           string
+	OpeningBalance float64
+}
+
+type AccountStorage interface {
+	Save(accounts []Account) error
+	Load() ([]Account, error)
+}
+
+// NormalizeAccount trims the name of an account and collapses its inner spaces.
+func NormalizeAccount(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// SameAccount reports whether two names refer to the same account, ignoring case. An empty name
+// is no account at all and matches nothing.
+func SameAccount(a string, b string) bool {
+	a, b = NormalizeAccount(a), NormalizeAccount(b)
+	return a != "" && strings.EqualFold(a, b)
+}
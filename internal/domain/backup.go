@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// Backup is a copy of every expense taken at CreatedAt, identified by Name. Count is only known once the
+// backup has been read, Unreadable marks a backup that could not be.
+type Backup struct {
+	Name       string
+	CreatedAt  time.Time
+	Count      int
+	Unreadable bool
+}
+
+type BackupStorage interface {
+	Create(expenses []Expense, at time.Time) (Backup, error)
+	// List returns the backups, the newest first, without reading them, so Count is left empty.
+	List() ([]Backup, error)
+	Load(name string) ([]Expense, error)
+	Remove(name string) error
+}
@@ -0,0 +1,60 @@
+package domain
+
+import "strings"
+
+// CategorySeparator divides the levels of a hierarchical category, e.g. "Food:Groceries".
+const CategorySeparator = ":"
+
+// CategoryLevels splits a hierarchical category into its trimmed, non-empty levels.
+func CategoryLevels(category string) []string {
+	levels := make([]string, 0, 1)
+
+	for _, level := range strings.Split(category, CategorySeparator) {
+		if level = strings.TrimSpace(level); level != "" {
+			levels = append(levels, level)
+		}
+	}
+
+	return levels
+}
+
+// NormalizeCategory trims every level of a hierarchical category and drops the empty ones.
+func NormalizeCategory(category string) string {
+	return strings.Join(CategoryLevels(category), CategorySeparator)
+}
+
+// CategoryDepth returns the number of levels of a category.
+func CategoryDepth(category string) int {
+	return len(CategoryLevels(category))
+}
+
+// CategoryAtDepth rolls a category up to its ancestor at the given depth, 1 being the top level.
+// Categories not deeper than depth, or a non-positive depth, are returned as they are.
+func CategoryAtDepth(category string, depth int) string {
+	levels := CategoryLevels(category)
+
+	if depth <= 0 || len(levels) <= depth {
+		return strings.Join(levels, CategorySeparator)
+	}
+
+	return strings.Join(levels[:depth], CategorySeparator)
+}
+
+// IsCategoryWithin reports whether category is parent itself or one of its descendants.
+func IsCategoryWithin(category string, parent string) bool {
+	category = NormalizeCategory(category)
+	parent = NormalizeCategory(parent)
+
+	return category == parent || strings.HasPrefix(category, parent+CategorySeparator)
+}
+
+// ReparentCategory moves a category that lies within oldParent under newParent,
+// keeping the levels below oldParent. Categories outside oldParent are returned unchanged.
+func ReparentCategory(category string, oldParent string, newParent string) string {
+	if !IsCategoryWithin(category, oldParent) {
+		return category
+	}
+
+	rest := CategoryLevels(category)[CategoryDepth(oldParent):]
+	return strings.Join(append(CategoryLevels(newParent), rest...), CategorySeparator)
+}
@@ -1,6 +1,23 @@
 package domain
 
-import "time"
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// ExpenseKind tells whether an expense spends money or only moves it between accounts.
+type ExpenseKind string
+
+const (
+	// SpendingKind is money spent, it is the kind of every expense recorded before kinds existed.
+	SpendingKind ExpenseKind = ""
+	// TransferKind moves the amount from Account to ToAccount, it is not counted as spending.
+	TransferKind ExpenseKind = "transfer"
+	// SettlementKind is a payment between members settling their shared expenses, it is not counted as spending.
+	// Its Sharing has the paying member as PaidBy and the member paid back as the only participant.
+	SettlementKind ExpenseKind = "settlement"
+)
 
 type Expense struct {
 	Id          int
@@ -8,9 +25,179 @@ type Expense struct {
 	Description string
 	Category    string
 	Amount      float64
+	Tags        []string    `json:",omitempty"`
+	Account     string      `json:",omitempty"`
+	Kind        ExpenseKind `json:",omitempty"`
+	ToAccount   string      `json:",omitempty"`
+	// Splits divide the amount between categories. Category then holds the category of the largest line.
+	Splits []Split `json:",omitempty"`
+	// Sharing divides the expense between members, nil when it is not shared.
+	Sharing *Sharing `json:",omitempty"`
 }
 
+// ExpenseStorage is the repository the expenses are kept in. Backends only touch the expenses asked for,
+// so a single change does not have to rewrite all of them.
 type ExpenseStorage interface {
-	Save(expenses []Expense) error
-	Load() ([]Expense, error)
+	// Get returns the expense with the given id, found is false when there is none.
+	Get(id int) (expense Expense, found bool, err error)
+	// Insert stores new expenses under the ids they carry.
+	Insert(expenses ...Expense) error
+	// Update replaces the stored expenses having the same ids. Unknown ids are skipped.
+	Update(expenses ...Expense) error
+	// Delete removes the expenses with the given ids. Unknown ids are skipped.
+	Delete(ids ...int) error
+	// Replace swaps every stored expense for the given ones in a single write, so a failure leaves
+	// the stored expenses as they were.
+	Replace(expenses ...Expense) error
+	// Query returns the expenses matching the filter, ordered by id.
+	Query(filter ExpenseFilter) ([]Expense, error)
+	// NextID returns the id the next inserted expense should get.
+	NextID() (int, error)
+}
+
+// ExpenseFilter narrows down a query. Zero fields match every expense.
+type ExpenseFilter struct {
+	Ids []int
+	// Category keeps the expenses of the category and its subcategories, or with a split line within it.
+	Category string
+	// Account keeps the expenses paid from the account and the transfers into it.
+	Account string
+	// From and To keep the expenses made from From and before To, as seen in Location.
+	From     time.Time
+	To       time.Time
+	Location *time.Location
+}
+
+// MonthFilter matches the expenses made in the given month of loc.
+func MonthFilter(year int, month time.Month, loc *time.Location) ExpenseFilter {
+	from := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	return ExpenseFilter{From: from, To: from.AddDate(0, 1, 0), Location: loc}
+}
+
+// Matches reports whether the expense passes every condition of the filter.
+func (f ExpenseFilter) Matches(e Expense) bool {
+	if f.Ids != nil && !slices.Contains(f.Ids, e.Id) {
+		return false
+	}
+
+	if f.Category != "" && !e.IsWithinAnyCategory(f.Category) {
+		return false
+	}
+
+	if f.Account != "" && !e.UsesAccount(f.Account) {
+		return false
+	}
+
+	loc := f.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	spentAt := e.LocalTime(loc)
+
+	if !f.From.IsZero() && spentAt.Before(f.From) {
+		return false
+	}
+
+	return f.To.IsZero() || spentAt.Before(f.To)
+}
+
+// IsDateOnly reports whether t carries a calendar date without a time of day. Such dates are stored
+// as midnight UTC, while expenses entered with a time of day keep the offset of the zone they were made in.
+func IsDateOnly(t time.Time) bool {
+	return t.Location() == time.UTC && t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+}
+
+// LocalTime returns when the expense was made as seen in loc. Expenses without a time of day keep their date.
+func (e Expense) LocalTime(loc *time.Location) time.Time {
+	if IsDateOnly(e.SpentAt) {
+		return time.Date(e.SpentAt.Year(), e.SpentAt.Month(), e.SpentAt.Day(), 0, 0, 0, 0, loc)
+	}
+
+	return e.SpentAt.In(loc)
+}
+
+// IsInMonth reports whether the expense was made in the given month of loc.
+func (e Expense) IsInMonth(year int, month time.Month, loc *time.Location) bool {
+	spentAt := e.LocalTime(loc)
+	return spentAt.Year() == year && spentAt.Month() == month
+}
+
+// IsOnDate reports whether the expense was made on the calendar day of date, as seen in loc.
+func (e Expense) IsOnDate(date time.Time, loc *time.Location) bool {
+	spentAt := e.LocalTime(loc)
+	return spentAt.Year() == date.Year() && spentAt.Month() == date.Month() && spentAt.Day() == date.Day()
+}
+
+// IsTransfer reports whether the expense moves money between two accounts instead of spending it.
+func (e Expense) IsTransfer() bool {
+	return e.Kind == TransferKind
+}
+
+// UsesAccount reports whether the expense was paid from the account or, for a transfer, went into it.
+func (e Expense) UsesAccount(account string) bool {
+	return SameAccount(e.Account, account) || (e.IsTransfer() && SameAccount(e.ToAccount, account))
+}
+
+// BalanceChange returns how much the expense adds to the balance of the account, negative when it is paid from it.
+func (e Expense) BalanceChange(account string) float64 {
+	change := 0.0
+
+	if SameAccount(e.Account, account) {
+		change -= e.Amount
+	}
+
+	if e.IsTransfer() && SameAccount(e.ToAccount, account) {
+		change += e.Amount
+	}
+
+	return change
+}
+
+// Spending leaves out the transfers and settlements, keeping the expenses that count as money spent.
+func Spending(expenses []Expense) []Expense {
+	spending := make([]Expense, 0, len(expenses))
+
+	for _, e := range expenses {
+		if !e.IsTransfer() && !e.IsSettlement() {
+			spending = append(spending, e)
+		}
+	}
+
+	return spending
+}
+
+// HasTag reports whether the expense is marked with the given tag.
+func (e Expense) HasTag(tag string) bool {
+	return slices.Contains(e.Tags, NormalizeTag(tag))
+}
+
+// NormalizeTag brings a tag to its stored form: trimmed, lower-cased and with inner spaces replaced by dashes.
+func NormalizeTag(tag string) string {
+	return strings.Join(strings.Fields(strings.ToLower(tag)), "-")
+}
+
+// NormalizeTags normalizes every tag and returns them sorted without duplicates and empty entries.
+func NormalizeTags(tags []string) []string {
+	result := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		if tag = NormalizeTag(tag); tag != "" {
+			result = append(result, tag)
+		}
+	}
+
+	slices.Sort(result)
+	result = slices.Compact(result)
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
+
+// ParseTags splits a comma-separated list of tags and normalizes it.
+func ParseTags(s string) []string {
+	return NormalizeTags(strings.Split(s, ","))
 }
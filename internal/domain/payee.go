@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Payee extracts the merchant from a bank-style description by dropping the details after a '*'
+// and any words containing digits, e.g. "UBER *TRIP" becomes "uber" and "TESCO STORES 1234" becomes "tesco stores".
+func Payee(description string) string {
+	description, _, _ = strings.Cut(description, "*")
+
+	words := make([]string, 0)
+
+	for _, word := range strings.Fields(strings.ToLower(description)) {
+		if !strings.ContainsFunc(word, unicode.IsDigit) {
+			words = append(words, word)
+		}
+	}
+
+	return strings.Join(words, " ")
+}
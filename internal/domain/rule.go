@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RuleKind tells how the pattern of a Rule is matched against an expense description.
+type RuleKind string
+
+const (
+	// SubstringRule matches descriptions containing the pattern, ignoring case.
+	SubstringRule RuleKind = "substring"
+	// RegexRule matches descriptions against the pattern as a regular expression.
+	RegexRule RuleKind = "regex"
+	// PayeeRule matches descriptions whose Payee equals the payee of the pattern.
+	PayeeRule RuleKind = "payee"
+)
+
+var RuleKinds = []RuleKind{SubstringRule, RegexRule, PayeeRule}
+
+// Rule assigns a category to expenses matching its pattern and amount range. An empty pattern matches
+// every description and a zero bound leaves that side of the range open, so a rule with only bounds
+// categorizes by amount alone. Rules with a lower Priority are evaluated first.
+type Rule struct {
+	Id        int
+	Priority  int
+	Kind      RuleKind
+	Pattern   string
+	MinAmount float64 `json:",omitempty"`
+	MaxAmount float64 `json:",omitempty"`
+	Category  string
+}
+
+type RuleStorage interface {
+	Save(rules []Rule) error
+	Load() ([]Rule, error)
+}
+
+// Validate checks that the rule can be evaluated.
+func (r Rule) Validate() error {
+	switch r.Kind {
+	case SubstringRule, PayeeRule:
+	case RegexRule:
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("invalid regular expression: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown rule kind %q", r.Kind)
+	}
+
+	if r.MinAmount < 0 || r.MaxAmount < 0 {
+		return fmt.Errorf("amount bounds cannot be negative")
+	}
+
+	if r.MaxAmount != 0 && r.MinAmount > r.MaxAmount {
+		return fmt.Errorf("minimum amount is greater than the maximum")
+	}
+
+	if NormalizeCategory(r.Category) == "" {
+		return fmt.Errorf("rule category cannot be empty")
+	}
+
+	return nil
+}
+
+// Matches reports whether the expense satisfies both the pattern and the amount range of the rule.
+func (r Rule) Matches(e Expense) bool {
+	if e.Amount < r.MinAmount || (r.MaxAmount != 0 && e.Amount > r.MaxAmount) {
+		return false
+	}
+
+	if r.Pattern == "" {
+		return true
+	}
+
+	switch r.Kind {
+	case SubstringRule:
+		return strings.Contains(strings.ToLower(e.Description), strings.ToLower(r.Pattern))
+	case RegexRule:
+		re, err := regexp.Compile(r.Pattern)
+		return err == nil && re.MatchString(e.Description)
+	case PayeeRule:
+		return Payee(e.Description) == Payee(r.Pattern)
+	}
+
+	return false
+}
+
+// IsUncategorized reports whether a category was left out, either empty or the "-" placeholder.
+func IsUncategorized(category string) bool {
+	category = NormalizeCategory(category)
+	return category == "" || category == "-"
+}
@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ShareMethod tells how the amount of a shared expense is divided between its participants.
+type ShareMethod string
+
+const (
+	// EqualShare divides the amount evenly.
+	EqualShare ShareMethod = "equal"
+	// WeightedShare divides the amount in proportion to the shares of the participants, e.g. 2 and 1.
+	WeightedShare ShareMethod = "shares"
+	// ExactShare gives every participant the exact amount they owe.
+	ExactShare ShareMethod = "exact"
+)
+
+var ShareMethods = []ShareMethod{EqualShare, WeightedShare, ExactShare}
+
+// Participant is a member taking part in a shared expense. Share is the weight of the member for WeightedShare
+// and the amount they owe for ExactShare, EqualShare does not use it.
+type Participant struct {
+	Name  string
+	Share float64 `json:",omitempty"`
+}
+
+// Sharing records who paid a shared expense and how it is divided between the members taking part.
+// The payer only owes a part when they are one of the participants.
+type Sharing struct {
+	PaidBy       string
+	Method       ShareMethod
+	Participants []Participant
+}
+
+// IsSettlement reports whether the expense is a payment between members settling their shared expenses.
+func (e Expense) IsSettlement() bool {
+	return e.Kind == SettlementKind
+}
+
+// IsShared reports whether the expense is divided between members.
+func (e Expense) IsShared() bool {
+	return e.Sharing != nil
+}
+
+// NormalizeMember brings a member name to its stored form, trimmed with inner spaces collapsed.
+func NormalizeMember(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// SameMember reports whether two names refer to the same member, ignoring case and spacing.
+func SameMember(a string, b string) bool {
+	a, b = NormalizeMember(a), NormalizeMember(b)
+	return a != "" && strings.EqualFold(a, b)
+}
+
+// Validate checks that the sharing names its payer and participants and that an exact division adds up
+// to the amount of the expense, to the cent.
+func (s Sharing) Validate(amount float64) error {
+	if NormalizeMember(s.PaidBy) == "" {
+		return fmt.Errorf("a shared expense needs the member who paid it")
+	}
+
+	if len(s.Participants) == 0 {
+		return fmt.Errorf("a shared expense needs at least one participant")
+	}
+
+	if !slices.Contains(ShareMethods, s.Method) {
+		return fmt.Errorf("unknown share method %q", s.Method)
+	}
+
+	total := 0.0
+
+	for i, p := range s.Participants {
+		if NormalizeMember(p.Name) == "" {
+			return fmt.Errorf("participant %d has no name", i+1)
+		}
+
+		if slices.ContainsFunc(s.Participants[:i], func(other Participant) bool {
+			return SameMember(other.Name, p.Name)
+		}) {
+			return fmt.Errorf("%s takes part twice", p.Name)
+		}
+
+		switch {
+		case s.Method == WeightedShare && p.Share <= 0:
+			return fmt.Errorf("%s should have a positive share", p.Name)
+		case s.Method == ExactShare && p.Share < 0:
+			return fmt.Errorf("%s cannot owe a negative amount", p.Name)
+		}
+
+		total += p.Share
+	}
+
+	if s.Method == ExactShare && math.Abs(total-amount) >= 0.005 {
+		return fmt.Errorf("participants owe %.2f instead of %.2f", total, amount)
+	}
+
+	return nil
+}
+
+// Owed returns what each participant owes of amount, in the order of the participants. Amounts are
+// rounded to cents, the cents left over by the rounding go to the first participants.
+func (s Sharing) Owed(amount float64) []float64 {
+	owed := make([]float64, len(s.Participants))
+
+	if len(owed) == 0 {
+		return owed
+	}
+
+	if s.Method == ExactShare {
+		for i, p := range s.Participants {
+			owed[i] = p.Share
+		}
+
+		return owed
+	}
+
+	weights := make([]float64, len(s.Participants))
+	totalWeight := 0.0
+
+	for i, p := range s.Participants {
+		weights[i] = 1
+		if s.Method == WeightedShare {
+			weights[i] = p.Share
+		}
+
+		totalWeight += weights[i]
+	}
+
+	totalCents := int64(math.Round(amount * 100))
+	leftCents := totalCents
+
+	cents := make([]int64, len(weights))
+
+	for i, weight := range weights {
+		cents[i] = int64(math.Floor(float64(totalCents) * weight / totalWeight))
+		leftCents -= cents[i]
+	}
+
+	for i := 0; leftCents > 0; i = (i + 1) % len(cents) {
+		cents[i]++
+		leftCents--
+	}
+
+	for i := range cents {
+		owed[i] = float64(cents[i]) / 100
+	}
+
+	return owed
+}
+
+// ParseParticipants reads a comma-separated list of participants, each optionally followed by its share,
+// e.g. "alice 2, bob 1".
+func ParseParticipants(s string) []Participant {
+	participants := make([]Participant, 0)
+
+	for _, entry := range strings.Split(s, ",") {
+		fields := strings.Fields(entry)
+		if len(fields) == 0 {
+			continue
+		}
+
+		participant := Participant{Name: strings.Join(fields, " ")}
+
+		if len(fields) > 1 {
+			if share, err := strconv.ParseFloat(fields[len(fields)-1], 64); err == nil {
+				participant = Participant{Name: strings.Join(fields[:len(fields)-1], " "), Share: share}
+			}
+		}
+
+		participants = append(participants, participant)
+	}
+
+	return participants
+}
+
+// FormatParticipants writes participants back in the form ParseParticipants reads.
+func FormatParticipants(participants []Participant) string {
+	entries := make([]string, len(participants))
+
+	for i, p := range participants {
+		entries[i] = p.Name
+
+		if p.Share != 0 {
+			entries[i] += " " + strconv.FormatFloat(p.Share, 'f', -1, 64)
+		}
+	}
+
+	return strings.Join(entries, ", ")
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Split is a line of an expense booked under its own category, like the household items of a
+// supermarket receipt that is otherwise groceries.
+type Split struct {
+	Category string
+	Amount   float64
+	Note     string `json:",omitempty"`
+}
+
+// IsSplit reports whether the expense is divided into split lines.
+func (e Expense) IsSplit() bool {
+	return len(e.Splits) > 0
+}
+
+// Parts returns a copy of the expense for every split line, carrying the category and amount of the line.
+// An expense without split lines is its only part.
+func (e Expense) Parts() []Expense {
+	if !e.IsSplit() {
+		return []Expense{e}
+	}
+
+	parts := make([]Expense, len(e.Splits))
+
+	for i, split := range e.Splits {
+		part := e
+		part.Category = split.Category
+		part.Amount = split.Amount
+		part.Splits = nil
+		parts[i] = part
+	}
+
+	return parts
+}
+
+// Categories returns the categories the expense is booked under, one per split line.
+func (e Expense) Categories() []string {
+	if !e.IsSplit() {
+		return []string{e.Category}
+	}
+
+	categories := make([]string, len(e.Splits))
+
+	for i, split := range e.Splits {
+		categories[i] = split.Category
+	}
+
+	return categories
+}
+
+// SplitParts replaces every split expense by its parts, so each split line is counted under its own category.
+func SplitParts(expenses []Expense) []Expense {
+	parts := make([]Expense, 0, len(expenses))
+
+	for _, e := range expenses {
+		parts = append(parts, e.Parts()...)
+	}
+
+	return parts
+}
+
+// MainSplit returns the index of the largest split line, the first one on a tie. The expense is shown
+// under its category where a single category is needed.
+func MainSplit(splits []Split) int {
+	main := 0
+
+	for i, split := range splits {
+		if split.Amount > splits[main].Amount {
+			main = i
+		}
+	}
+
+	return main
+}
+
+// ValidateSplits checks that every split line has a category and a positive amount and that the lines
+// add up to the amount of the expense, to the cent.
+func (e Expense) ValidateSplits() error {
+	if !e.IsSplit() {
+		return nil
+	}
+
+	if e.IsTransfer() {
+		return fmt.Errorf("a transfer cannot be split")
+	}
+
+	total := 0.0
+
+	for i, split := range e.Splits {
+		if IsUncategorized(split.Category) {
+			return fmt.Errorf("split line %d has no category", i+1)
+		}
+
+		if split.Amount <= 0 {
+			return fmt.Errorf("split line %d should have a positive amount", i+1)
+		}
+
+		total += split.Amount
+	}
+
+	if math.Abs(total-e.Amount) >= 0.005 {
+		return fmt.Errorf("split lines add up to %.2f instead of %.2f", total, e.Amount)
+	}
+
+	return nil
+}
+
+// IsWithinAnyCategory reports whether any part of the expense is booked under the category or its subcategories.
+func (e Expense) IsWithinAnyCategory(category string) bool {
+	return slices.ContainsFunc(e.Categories(), func(c string) bool {
+		return IsCategoryWithin(c, category)
+	})
+}
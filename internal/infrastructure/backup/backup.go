@@ -0,0 +1,153 @@
+package backup
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/files"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// DirName is the directory of the application directory the backups are kept in.
+	DirName = "backups"
+
+	filePrefix = "expenses-"
+	fileSuffix = ".json"
+	timeLayout = "20060102-150405.000"
+)
+
+// Storage keeps every backup as a JSON file named after the UTC time it was taken,
+// e.g. expenses-20261019-125339.123.json.
+type Storage struct {
+	dir string
+}
+
+func NewStorage(dir string) *Storage {
+	return &Storage{dir: dir}
+}
+
+func (s *Storage) Create(expenses []domain.Expense, at time.Time) (domain.Backup, error) {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return domain.Backup{}, err
+	}
+
+	at = at.UTC().Truncate(time.Millisecond)
+	name := filePrefix + at.Format(timeLayout) + fileSuffix
+
+	// written like the expenses file, so backups are upgraded along with it and the ones of encrypted
+	// expenses are sealed with the same key
+	data, err := files.EncodeExpenses(expenses)
+	if err != nil {
+		return domain.Backup{}, err
+	}
+
+	// written under a temporary name first, so a listed backup is always complete
+	tmp := filepath.Join(s.dir, name+".tmp")
+	if err = os.WriteFile(tmp, data, 0600); err != nil {
+		return domain.Backup{}, err
+	}
+
+	if err = os.Rename(tmp, filepath.Join(s.dir, name)); err != nil {
+		return domain.Backup{}, err
+	}
+
+	return domain.Backup{Name: name, CreatedAt: at, Count: len(expenses)}, nil
+}
+
+// List returns the backups with the time they were taken read from their names, the files are not opened.
+func (s *Storage) List() ([]domain.Backup, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return []domain.Backup{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	backups := make([]domain.Backup, 0, len(entries))
+
+	for _, entry := range entries {
+		createdAt, ok := parseName(entry.Name())
+		if !ok || entry.IsDir() {
+			continue
+		}
+
+		backups = append(backups, domain.Backup{Name: entry.Name(), CreatedAt: createdAt})
+	}
+
+	slices.SortFunc(backups, func(a, b domain.Backup) int {
+		return cmp.Compare(b.Name, a.Name)
+	})
+
+	return backups, nil
+}
+
+func (s *Storage) Load(name string) ([]domain.Expense, error) {
+	if _, ok := parseName(name); !ok {
+		return nil, fmt.Errorf("%q is not a backup: %w", name, os.ErrNotExist)
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, name))
+	if err != nil {
+		return nil, err
+	}
+
+	expenses, err := files.DecodeExpenses[[]domain.Expense](data)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading backup %s: %w", name, err)
+	}
+
+	return expenses, nil
+}
+
+// Paths returns where every backup is kept, oldest first.
+func (s *Storage) Paths() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		if _, ok := parseName(entry.Name()); ok && !entry.IsDir() {
+			paths = append(paths, filepath.Join(s.dir, entry.Name()))
+		}
+	}
+
+	return paths, nil
+}
+
+func (s *Storage) Remove(name string) error {
+	if _, ok := parseName(name); !ok {
+		return fmt.Errorf("%q is not a backup: %w", name, os.ErrNotExist)
+	}
+
+	return os.Remove(filepath.Join(s.dir, name))
+}
+
+// parseName reads the time a backup was taken from its file name. Other files are not backups.
+func parseName(name string) (time.Time, bool) {
+	stamp, ok := strings.CutPrefix(name, filePrefix)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	stamp, ok = strings.CutSuffix(stamp, fileSuffix)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	createdAt, err := time.Parse(timeLayout, stamp)
+	return createdAt, err == nil
+}
@@ -0,0 +1,104 @@
+package backup
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStorage(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), DirName)
+	storage := NewStorage(dir)
+
+	backups, err := storage.List()
+	assert.NoError(t, err)
+	assert.Empty(t, backups)
+
+	expenses := []domain.Expense{
+		{Id: 1, Description: "Coffee", Category: "Food", Amount: 3.5, SpentAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Id: 2, Description: "Taxi", Category: "Transport", Amount: 12, SpentAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}
+
+	older, err := storage.Create(expenses[:1], time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, "expenses-20261018-090000.000.json", older.Name)
+
+	newer, err := storage.Create(expenses, time.Date(2026, 10, 19, 9, 30, 0, 0, time.FixedZone("", 2*60*60)))
+	assert.NoError(t, err)
+	assert.Equal(t, "expenses-20261019-073000.000.json", newer.Name)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a backup"), 0644))
+
+	corrupt := domain.Backup{Name: "expenses-20200101-000000.000.json", CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, corrupt.Name), []byte("garbage"), 0644))
+
+	// listing reads the names only, so a corrupt backup does not get in the way
+	backups, err = storage.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Backup{
+		{Name: newer.Name, CreatedAt: newer.CreatedAt},
+		{Name: older.Name, CreatedAt: older.CreatedAt},
+		corrupt,
+	}, backups)
+
+	_, err = storage.Load(corrupt.Name)
+	assert.Error(t, err)
+	assert.NoError(t, storage.Remove(corrupt.Name))
+
+	loaded, err := storage.Load(newer.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, expenses, loaded)
+
+	_, err = storage.Load("notes.txt")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	paths, err := storage.Paths()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, older.Name), filepath.Join(dir, newer.Name)}, paths)
+
+	assert.NoError(t, storage.Remove(older.Name))
+
+	backups, err = storage.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Backup{{Name: newer.Name, CreatedAt: newer.CreatedAt}}, backups)
+}
+
+func TestStorageSchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), DirName)
+	storage := NewStorage(dir)
+	expenses := []domain.Expense{
+		{Id: 1, Description: "Coffee", Category: "Food", Amount: 3.5, SpentAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	created, err := storage.Create(expenses, time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	info, err := os.Stat(filepath.Join(dir, created.Name))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	data, err := os.ReadFile(filepath.Join(dir, created.Name))
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"version": 1`)
+
+	// a backup taken before the expenses were versioned is upgraded when it is read
+	old := "expenses-20240101-000000.000.json"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, old), []byte(`[{"Id":1,"Description":"Coffee","Category":"Food","Amount":3.5,"SpentAt":"2024-01-01T00:00:00Z"}]`), 0600))
+
+	loaded, err := storage.Load(old)
+	assert.NoError(t, err)
+	assert.Equal(t, expenses, loaded)
+
+	newer := "expenses-20240102-000000.000.json"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, newer), []byte(`{"version": 99, "expenses": []}`), 0600))
+
+	_, err = storage.Load(newer)
+	assert.ErrorContains(t, err, "schema version 99")
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/files"
+	"time"
+)
+
+const settingsFileName = "settings.json"
+
+// Storage backends the expenses can be kept in.
+const (
+	JSONStorage    = "json"
+	SQLiteStorage  = "sqlite"
+	JournalStorage = "journal"
+)
+
+// Backups kept when no retention is configured.
+const (
+	DefaultBackupDays   = 7
+	DefaultBackupMonths = 6
+)
+
+// Settings holds the user preferences kept in settings.json next to the expenses.
+type Settings struct {
+	// DateFormat is the order dates are typed and shown in: "iso" (2026-10-18), "dmy" (18.10.2026)
+	// or "mdy" (10/18/2026). Empty means "iso".
+	DateFormat string `json:",omitempty"`
+
+	// Timezone is the IANA name of the zone days and months are counted in, e.g. "Europe/Berlin".
+	// Empty means the zone of the system.
+	Timezone string `json:",omitempty"`
+
+	// Storage is the backend the expenses are kept in: "json" (expenses.json), "sqlite" (expenses.db)
+	// or "journal" (expenses.journal with expenses.snapshot.json). Empty means "json".
+	Storage string `json:",omitempty"`
+
+	// BackupDays and BackupMonths are how many of the latest days and months keep their last backup.
+	// Zero means the defaults, a negative value keeps none.
+	BackupDays   int `json:",omitempty"`
+	BackupMonths int `json:",omitempty"`
+}
+
+// Location returns the configured timezone, falling back to the system one when it is empty or unknown.
+func (s Settings) Location() *time.Location {
+	if s.Timezone == "" {
+		return time.Local
+	}
+
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.Local
+	}
+
+	return loc
+}
+
+// BackupRetention returns how many daily and monthly backups are kept.
+func (s Settings) BackupRetention() (int, int) {
+	days, months := s.BackupDays, s.BackupMonths
+
+	if days == 0 {
+		days = DefaultBackupDays
+	}
+
+	if months == 0 {
+		months = DefaultBackupMonths
+	}
+
+	return max(days, 0), max(months, 0)
+}
+
+// Load reads the settings, giving the defaults when none were saved yet.
+func Load() (Settings, error) {
+	return files.GetObjectFromNamedFile[Settings](settingsFileName)
+}
+
+func Save(settings Settings) error {
+	return files.SaveObjectToNamedFile(settingsFileName, settings)
+}
@@ -4,15 +4,10 @@ import (
 	"encoding/csv"
 	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/files"
 	"io"
-	"os"
 	"path/filepath"
 )
 
-const (
-	saveFileName    = "expenses.csv"
-	defaultSavePath = "%AppData%"
-	appName         = "expense-tracker"
-)
+const saveFileName = "expenses.csv"
 
 func SaveToCSV(data [][]string) error {
 	file, err := files.OpenOrCreateFile(getSaveDir(), saveFileName)
@@ -43,12 +38,7 @@ func saveToCSV(file io.WriteCloser, data [][]string) error {
 	return w.Error()
 }
 
+// getSaveDir returns the directory of the current ledger, so every ledger is exported next to its expenses.
 func getSaveDir() string {
-	path, err := os.UserConfigDir()
-
-	if err != nil {
-		path = defaultSavePath
-	}
-
-	return filepath.Join(path, appName)
+	return filepath.Dir(files.GetFilePath(saveFileName))
 }
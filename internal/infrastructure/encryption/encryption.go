@@ -0,0 +1,205 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"sync"
+)
+
+const (
+	// Cipher is the AEAD sealed files are encrypted with.
+	Cipher = "aes-256-gcm"
+	// Argon2id is the function keys are derived from passphrases with.
+	Argon2id = "argon2id"
+
+	keySize  = 32
+	saltSize = 16
+	// maxMemory keeps a damaged file from asking for more than 4 GiB to derive its key.
+	maxMemory = 4 * 1024 * 1024
+)
+
+var (
+	ErrWrongPassphrase = errors.New("wrong passphrase")
+	ErrEmptyPassphrase = errors.New("passphrase cannot be empty")
+)
+
+// defaultKDF are the Argon2id parameters new keys are derived with, as recommended by RFC 9106
+// for memory-constrained environments.
+var defaultKDF = KDF{Name: Argon2id, Time: 3, Memory: 64 * 1024, Threads: 4}
+
+// KDF describes how the key of a sealed file was derived from the passphrase.
+type KDF struct {
+	Name    string `json:"name"`
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+}
+
+func (k KDF) derive(passphrase []byte) ([]byte, error) {
+	if k.Name != Argon2id {
+		return nil, fmt.Errorf("unknown key derivation %q", k.Name)
+	}
+
+	if k.Time == 0 || k.Threads == 0 || k.Memory > maxMemory {
+		return nil, fmt.Errorf("invalid key derivation parameters time=%d memory=%d threads=%d", k.Time, k.Memory, k.Threads)
+	}
+
+	return argon2.IDKey(passphrase, k.Salt, k.Time, k.Memory, k.Threads, keySize), nil
+}
+
+// Sealed is the JSON document an encrypted file is written as. The plaintext cannot be read or changed
+// without the passphrase, the parameters needed to derive the key again are kept next to it.
+type Sealed struct {
+	Cipher string `json:"cipher"`
+	KDF    KDF    `json:"kdf"`
+	Nonce  []byte `json:"nonce"`
+	Data   []byte `json:"data"`
+}
+
+// Parse reads the sealed document at the start of data. ok is false for files that are not encrypted.
+func Parse(data []byte) (sealed Sealed, ok bool) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		return Sealed{}, false
+	}
+
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(&sealed); err != nil {
+		return Sealed{}, false
+	}
+
+	return sealed, sealed.Cipher != ""
+}
+
+// Key seals files with a key derived from a passphrase. The passphrase is kept to open files sealed under
+// another salt, such as the ones written before the key was created.
+type Key struct {
+	passphrase []byte
+	kdf        KDF
+
+	mu      sync.Mutex
+	derived map[string][]byte
+}
+
+// NewKey derives a key from the passphrase with a new random salt.
+func NewKey(passphrase []byte) (*Key, error) {
+	return newKey(passphrase, defaultKDF)
+}
+
+// KeyFor derives the key a file was sealed with, so the files written afterwards keep the same parameters.
+func KeyFor(passphrase []byte, sealed Sealed) (*Key, error) {
+	if len(passphrase) == 0 {
+		return nil, ErrEmptyPassphrase
+	}
+
+	k := &Key{passphrase: bytes.Clone(passphrase), kdf: sealed.KDF, derived: make(map[string][]byte)}
+	if _, err := k.keyFor(sealed.KDF); err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+func newKey(passphrase []byte, kdf KDF) (*Key, error) {
+	if len(passphrase) == 0 {
+		return nil, ErrEmptyPassphrase
+	}
+
+	kdf.Salt = make([]byte, saltSize)
+	if _, err := rand.Read(kdf.Salt); err != nil {
+		return nil, err
+	}
+
+	return KeyFor(passphrase, Sealed{KDF: kdf})
+}
+
+// Seal encrypts plaintext into a sealed document.
+func (k *Key) Seal(plaintext []byte) ([]byte, error) {
+	aead, err := k.aead(k.kdf)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := Sealed{
+		Cipher: Cipher,
+		KDF:    k.kdf,
+		Nonce:  nonce,
+		Data:   aead.Seal(nil, nonce, plaintext, nil),
+	}
+
+	data, err := json.MarshalIndent(sealed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
+// Open decrypts a sealed document. ErrWrongPassphrase is returned when the passphrase does not match,
+// which is also what a file changed by someone else looks like.
+func (k *Key) Open(sealed Sealed) ([]byte, error) {
+	if sealed.Cipher != Cipher {
+		return nil, fmt.Errorf("unknown cipher %q", sealed.Cipher)
+	}
+
+	aead, err := k.aead(sealed.KDF)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce of %d bytes", len(sealed.Nonce))
+	}
+
+	plaintext, err := aead.Open(nil, sealed.Nonce, sealed.Data, nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	return plaintext, nil
+}
+
+func (k *Key) aead(kdf KDF) (cipher.AEAD, error) {
+	key, err := k.keyFor(kdf)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// keyFor derives the key of kdf once, deriving is slow on purpose.
+func (k *Key) keyFor(kdf KDF) ([]byte, error) {
+	id := fmt.Sprintf("%s/%x/%d/%d/%d", kdf.Name, kdf.Salt, kdf.Time, kdf.Memory, kdf.Threads)
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if key, ok := k.derived[id]; ok {
+		return key, nil
+	}
+
+	key, err := kdf.derive(k.passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	k.derived[id] = key
+	return key, nil
+}
@@ -0,0 +1,148 @@
+package encryption
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// testKDF keeps the tests fast, the parameters are far too weak for real files.
+var testKDF = KDF{Name: Argon2id, Time: 1, Memory: 64, Threads: 1}
+
+func newTestKey(t *testing.T, passphrase string) *Key {
+	t.Helper()
+
+	key, err := newKey([]byte(passphrase), testKDF)
+	assert.NoError(t, err)
+
+	return key
+}
+
+func TestSealAndOpen(t *testing.T) {
+	t.Parallel()
+
+	key := newTestKey(t, "correct horse")
+	plaintext := []byte(`{"version": 1, "expenses": []}`)
+
+	data, err := key.Seal(plaintext)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "expenses")
+
+	sealed, ok := Parse(data)
+	assert.True(t, ok)
+	assert.Equal(t, Cipher, sealed.Cipher)
+	assert.Equal(t, Argon2id, sealed.KDF.Name)
+	assert.Len(t, sealed.KDF.Salt, saltSize)
+
+	opened, err := key.Open(sealed)
+	assert.NoError(t, err)
+	assert.Equal(t, plaintext, opened)
+
+	again, err := key.Seal(plaintext)
+	assert.NoError(t, err)
+	assert.NotEqual(t, data, again, "every seal uses a new nonce")
+}
+
+func TestOpenWithAnotherKey(t *testing.T) {
+	t.Parallel()
+
+	data, err := newTestKey(t, "correct horse").Seal([]byte("secret"))
+	assert.NoError(t, err)
+
+	sealed, _ := Parse(data)
+
+	type testCase struct {
+		name        string
+		keyFn       func(t *testing.T) (*Key, error)
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			name: "Same passphrase under another salt",
+			keyFn: func(t *testing.T) (*Key, error) {
+				return newTestKey(t, "correct horse"), nil
+			},
+		},
+		{
+			name: "Key derived from the file",
+			keyFn: func(t *testing.T) (*Key, error) {
+				return KeyFor([]byte("correct horse"), sealed)
+			},
+		},
+		{
+			name: "Wrong passphrase",
+			keyFn: func(t *testing.T) (*Key, error) {
+				return KeyFor([]byte("battery staple"), sealed)
+			},
+			expectedErr: ErrWrongPassphrase,
+		},
+		{
+			name: "Empty passphrase",
+			keyFn: func(t *testing.T) (*Key, error) {
+				return KeyFor(nil, sealed)
+			},
+			expectedErr: ErrEmptyPassphrase,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, err := tt.keyFn(t)
+			if err == nil {
+				_, err = key.Open(sealed)
+			}
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOpenTampered(t *testing.T) {
+	t.Parallel()
+
+	key := newTestKey(t, "correct horse")
+
+	data, err := key.Seal([]byte("secret"))
+	assert.NoError(t, err)
+
+	sealed, _ := Parse(data)
+	sealed.Data[0] ^= 1
+
+	_, err = key.Open(sealed)
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		data     string
+		expected bool
+	}
+
+	testCases := []testCase{
+		{name: "Sealed", data: `{"cipher": "aes-256-gcm", "kdf": {"name": "argon2id"}}`, expected: true},
+		{name: "Versioned expenses", data: `{"version": 1, "expenses": []}`, expected: false},
+		{name: "Bare array", data: `[]`, expected: false},
+		{name: "Empty", data: ``, expected: false},
+		{name: "Broken", data: `{"cipher": `, expected: false},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, ok := Parse([]byte(tt.data))
+			assert.Equal(t, tt.expected, ok)
+		})
+	}
+}
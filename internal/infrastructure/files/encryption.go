@@ -0,0 +1,252 @@
+package files
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/encryption"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrLocked is returned when an encrypted file is read before the passphrase was given to Unlock.
+var ErrLocked = errors.New("The expenses are encrypted, the passphrase is needed to read them")
+
+// keys seal the expenses file, the named files and the files written through Seal of each ledger, there
+// is none for a ledger kept in plaintext.
+var (
+	keyMu sync.RWMutex
+	keys  = make(map[string]*encryption.Key)
+)
+
+// IsEncrypted reports whether the expenses file is sealed with a passphrase.
+func IsEncrypted() (bool, error) {
+	data, err := os.ReadFile(GetFilePath(saveFileName))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := encryption.Parse(data)
+	return ok, nil
+}
+
+// IsLocked reports whether the expenses file of the current ledger is encrypted and no passphrase was
+// given to Unlock for it yet.
+func IsLocked() (bool, error) {
+	if currentKey() != nil {
+		return false, nil
+	}
+
+	return IsEncrypted()
+}
+
+// Unlock derives the key of the expenses file from the passphrase. The encrypted files can be read
+// afterwards, and the files written are sealed with the same key. Plaintext expenses need no passphrase.
+func Unlock(passphrase []byte) error {
+	data, err := os.ReadFile(GetFilePath(saveFileName))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	sealed, ok := encryption.Parse(data)
+	if !ok {
+		return nil
+	}
+
+	k, err := encryption.KeyFor(passphrase, sealed)
+	if err != nil {
+		return err
+	}
+
+	if _, err = k.Open(sealed); err != nil {
+		return err
+	}
+
+	setKey(k)
+	return nil
+}
+
+// Seal encrypts data with the key of the expenses when they are encrypted, and leaves it as is otherwise.
+func Seal(data []byte) ([]byte, error) {
+	k := currentKey()
+	if k == nil {
+		return data, nil
+	}
+
+	return k.Seal(data)
+}
+
+// Open decrypts data written by Seal. Plaintext is returned as is.
+func Open(data []byte) ([]byte, error) {
+	sealed, ok := encryption.Parse(data)
+	if !ok {
+		return data, nil
+	}
+
+	k := currentKey()
+	if k == nil {
+		return nil, ErrLocked
+	}
+
+	return k.Open(sealed)
+}
+
+// rekeyedFile is a file rewritten by Rekey under a temporary name, along with its content before.
+type rekeyedFile struct {
+	path     string
+	tmp      string
+	original []byte
+}
+
+// Rekey rewrites the files at paths and the expenses file sealed with newKey, or in plaintext when newKey is
+// nil. Paths that do not exist are skipped. Every file is written under a temporary name first, so none is
+// replaced unless all could be read.
+// The expenses file is replaced last and the files replaced before are put back when a replacement fails,
+// so the expenses and the files at paths stay readable with a single key.
+func Rekey(newKey *encryption.Key, paths ...string) error {
+	if err := ensureDirExists(getSaveDir()); err != nil {
+		return err
+	}
+
+	paths = append(slices.Clone(paths), GetFilePath(saveFileName))
+	rekeyed := make([]rekeyedFile, 0, len(paths))
+
+	defer func() {
+		for _, f := range rekeyed {
+			os.Remove(f.tmp)
+		}
+	}()
+
+	for i, path := range paths {
+		isExpenses := i == len(paths)-1
+
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) && !isExpenses {
+			continue
+		}
+
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		var plaintext []byte
+
+		// the expenses file is decoded and written anew, so nothing but its document is sealed; one that was
+		// never written gets an empty document, so whether it is encrypted is known
+		if isExpenses {
+			plaintext, err = reencodeVersionedFile(data)
+		} else {
+			plaintext, err = Open(data)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if newKey != nil {
+			if plaintext, err = newKey.Seal(plaintext); err != nil {
+				return err
+			}
+		}
+
+		tmp, err := writeTemp(path, plaintext)
+		if err != nil {
+			return err
+		}
+
+		rekeyed = append(rekeyed, rekeyedFile{path: path, tmp: tmp, original: data})
+	}
+
+	for i, f := range rekeyed {
+		if err := os.Rename(f.tmp, f.path); err != nil {
+			return putBack(rekeyed[:i], err)
+		}
+	}
+
+	setKey(newKey)
+	return nil
+}
+
+// reencodeVersionedFile reads the expenses file content and encodes its records again in plaintext.
+func reencodeVersionedFile(data []byte) ([]byte, error) {
+	records, err := DecodeExpenses[[]json.RawMessage](data)
+	if err != nil {
+		return nil, err
+	}
+
+	return MarshalVersioned(records)
+}
+
+// writeTemp writes data next to path under a temporary name only the user can read, as it may be the
+// decrypted content, and returns that name.
+func writeTemp(path string, data []byte) (string, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", err
+	}
+
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+
+	return tmp.Name(), nil
+}
+
+// putBack restores the content the replaced files had before Rekey. The files that could not be put back
+// are named in the error, they are left under the new key.
+func putBack(replaced []rekeyedFile, cause error) error {
+	failed := make([]string, 0)
+
+	for _, f := range replaced {
+		err := replaceFile(filepath.Dir(f.path), filepath.Base(f.path), func(file io.WriteCloser) error {
+			defer file.Close()
+
+			_, err := file.Write(f.original)
+			return err
+		})
+
+		if err != nil {
+			failed = append(failed, f.path)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("Error replacing files: %w; these files could not be put back and are left with the new key: %s", cause, strings.Join(failed, ", "))
+	}
+
+	return fmt.Errorf("Error replacing files, every file was put back as it was: %w", cause)
+}
+
+func currentKey() *encryption.Key {
+	keyMu.RLock()
+	defer keyMu.RUnlock()
+
+	return keys[CurrentLedger()]
+}
+
+func setKey(k *encryption.Key) {
+	keyMu.Lock()
+	defer keyMu.Unlock()
+
+	if k == nil {
+		delete(keys, CurrentLedger())
+		return
+	}
+
+	keys[CurrentLedger()] = k
+}
@@ -0,0 +1,198 @@
+package files
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/encryption"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/files/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestSealedVersionedFile sets the key of the package, so it does not run in parallel with the other tests.
+func TestSealedVersionedFile(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	k, err := encryption.NewKey([]byte("correct horse"))
+	assert.NoError(err)
+
+	setKey(k)
+	defer setKey(nil)
+
+	expenses := []domain.Expense{
+		{Id: 1, Description: "Expense 1", Amount: 10},
+		{Id: 2, Description: "Expense 2", Amount: 20},
+	}
+
+	var written bytes.Buffer
+
+	writer := mocks.NewMockWriteCloser(ctrl)
+	firstCall := writer.EXPECT().Write(gomock.Any()).DoAndReturn(written.Write).Times(1)
+	writer.EXPECT().Close().Times(1).After(firstCall)
+
+	assert.NoError(saveToVersionedFile(writer, expenses))
+	assert.NotContains(written.String(), "Expense 1")
+
+	_, sealed := encryption.Parse(written.Bytes())
+	assert.True(sealed)
+
+	read := func() ([]domain.Expense, error) {
+		reader := bytes.NewReader(written.Bytes())
+
+		file := mocks.NewMockReadCloser(ctrl)
+		file.EXPECT().Read(gomock.Any()).DoAndReturn(reader.Read).MinTimes(1)
+		file.EXPECT().Close().Times(1)
+
+		return getFromVersionedFile[[]domain.Expense](file)
+	}
+
+	loaded, err := read()
+	assert.NoError(err)
+	assert.Equal(expenses, loaded)
+
+	setKey(nil)
+
+	_, err = read()
+	assert.ErrorIs(err, ErrLocked)
+}
+
+// TestRekeyAfterShrink sets the key of the package and the config directory, so it does not run in parallel
+// with the other tests.
+func TestRekeyAfterShrink(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	expenses := []domain.Expense{
+		{Id: 1, Description: "Expense 1", Amount: 10},
+		{Id: 2, Description: "Expense 2", Amount: 20},
+		{Id: 3, Description: "Expense 3", Amount: 30},
+	}
+
+	// a restore of an older backup writes fewer expenses than were there
+	assert.NoError(SaveToFile(expenses))
+	assert.NoError(SaveToFile(expenses[:1]))
+
+	data, err := os.ReadFile(GetFilePath(saveFileName))
+	assert.NoError(err)
+	assert.True(json.Valid(data))
+
+	backupPath := filepath.Join(t.TempDir(), "expenses-20261019-120000.000.json")
+	assert.NoError(os.WriteFile(backupPath, []byte(`[{"Id": 1, "Description": "Expense 1", "Amount": 10}]`), 0644))
+
+	k, err := encryption.NewKey([]byte("correct horse"))
+	assert.NoError(err)
+
+	assert.NoError(Rekey(k, backupPath))
+	defer setKey(nil)
+
+	encrypted, err := IsEncrypted()
+	assert.NoError(err)
+	assert.True(encrypted)
+
+	loaded, err := GetFromFile[[]domain.Expense]()
+	assert.NoError(err)
+	assert.Equal(expenses[:1], loaded)
+
+	assert.NoError(Rekey(nil, backupPath))
+
+	loaded, err = GetFromFile[[]domain.Expense]()
+	assert.NoError(err)
+	assert.Equal(expenses[:1], loaded)
+
+	backup, err := os.ReadFile(backupPath)
+	assert.NoError(err)
+	assert.True(json.Valid(backup))
+
+	tmps, err := filepath.Glob(filepath.Join(getSaveDir(), "*.tmp"))
+	assert.NoError(err)
+	assert.Empty(tmps)
+}
+
+// TestRekeyNamedFiles sets the key of the package and the config directory, so it does not run in parallel
+// with the other tests.
+func TestRekeyNamedFiles(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	type account struct {
+		Name           string
+		OpeningBalance float64
+	}
+
+	accounts := []account{{Name: "Cash", OpeningBalance: 50}}
+	assert.NoError(SaveToNamedFile("accounts.json", accounts))
+
+	k, err := encryption.NewKey([]byte("correct horse"))
+	assert.NoError(err)
+
+	// a ledger without rules yet has no rules file to rewrite
+	assert.NoError(Rekey(k, GetFilePath("accounts.json"), GetFilePath("rules.json")))
+	defer setKey(nil)
+
+	_, err = os.Stat(GetFilePath("rules.json"))
+	assert.ErrorIs(err, os.ErrNotExist)
+
+	data, err := os.ReadFile(GetFilePath("accounts.json"))
+	assert.NoError(err)
+	assert.NotContains(string(data), "Cash")
+
+	_, sealed := encryption.Parse(data)
+	assert.True(sealed)
+
+	loaded, err := GetFromNamedFile[[]account]("accounts.json")
+	assert.NoError(err)
+	assert.Equal(accounts, loaded)
+
+	// files written while the expenses are encrypted are sealed as well
+	accounts = append(accounts, account{Name: "Savings"})
+	assert.NoError(SaveToNamedFile("accounts.json", accounts))
+
+	data, err = os.ReadFile(GetFilePath("accounts.json"))
+	assert.NoError(err)
+	assert.NotContains(string(data), "Savings")
+
+	setKey(nil)
+
+	_, err = GetFromNamedFile[[]account]("accounts.json")
+	assert.ErrorIs(err, ErrLocked)
+
+	setKey(k)
+	assert.NoError(Rekey(nil, GetFilePath("accounts.json")))
+
+	data, err = os.ReadFile(GetFilePath("accounts.json"))
+	assert.NoError(err)
+	assert.True(json.Valid(data))
+
+	loaded, err = GetFromNamedFile[[]account]("accounts.json")
+	assert.NoError(err)
+	assert.Equal(accounts, loaded)
+}
+
+func TestPutBack(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "expenses-20261019-120000.000.json")
+	// a file cannot be written below another file
+	unwritable := filepath.Join(path, "expenses.json")
+
+	assert.NoError(t, os.WriteFile(path, []byte("sealed with the new key"), 0644))
+
+	err := putBack([]rekeyedFile{{path: path, original: []byte("sealed with the old key")}}, assert.AnError)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Contains(t, err.Error(), "every file was put back")
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "sealed with the old key", string(data))
+
+	err = putBack([]rekeyedFile{{path: unwritable, original: []byte("[]")}}, assert.AnError)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Contains(t, err.Error(), unwritable)
+}
@@ -1,6 +1,7 @@
 package files
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
 	"os"
@@ -13,18 +14,34 @@ const (
 	appName         = "expense-tracker"
 )
 
+// SaveToFile saves the expenses file in the envelope of the current schema version.
 func SaveToFile[T ~[]E, E any](data T) error {
+	return replaceFile(getSaveDir(), saveFileName, func(file io.WriteCloser) error {
+		return saveToVersionedFile(file, data)
+	})
+}
+
+// GetFromFile reads the expenses file, upgrading files written with an older schema version.
+func GetFromFile[T ~[]E, E any]() (T, error) {
 	saveFile, err := OpenOrCreateFile(getSaveDir(), saveFileName)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return saveToFile(saveFile, data)
+	return getFromVersionedFile[T](saveFile)
 }
 
-func GetFromFile[T ~[]E, E any]() (T, error) {
-	saveFile, err := OpenOrCreateFile(getSaveDir(), saveFileName)
+// SaveToNamedFile saves data to the given file of the application directory, sealed when the expenses are encrypted.
+func SaveToNamedFile[T ~[]E, E any](filename string, data T) error {
+	return replaceFile(getSaveDir(), filename, func(file io.WriteCloser) error {
+		return saveToFile(file, data)
+	})
+}
+
+// GetFromNamedFile reads data from the given file of the application directory.
+func GetFromNamedFile[T ~[]E, E any](filename string) (T, error) {
+	saveFile, err := OpenOrCreateFile(getSaveDir(), filename)
 
 	if err != nil {
 		return nil, err
@@ -33,30 +50,93 @@ func GetFromFile[T ~[]E, E any]() (T, error) {
 	return getFromFile[T](saveFile)
 }
 
+// SaveObjectToNamedFile saves a single value, such as the settings, to the given file of the application directory.
+func SaveObjectToNamedFile[T any](filename string, data T) error {
+	return replaceFile(getSaveDir(), filename, func(file io.WriteCloser) error {
+		defer file.Close()
+
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	})
+}
+
+// GetObjectFromNamedFile reads a single value from the given file of the application directory.
+// An empty file gives the zero value.
+func GetObjectFromNamedFile[T any](filename string) (T, error) {
+	var data T
+	saveFile, err := OpenOrCreateFile(getSaveDir(), filename)
+
+	if err != nil {
+		return data, err
+	}
+
+	defer saveFile.Close()
+
+	err = json.NewDecoder(saveFile).Decode(&data)
+
+	if err == io.EOF {
+		err = nil
+	}
+
+	return data, err
+}
+
+// saveToFile writes data sealed like the expenses file, as the named files of a ledger such as its accounts
+// and rules are encrypted along with it.
 func saveToFile[T ~[]E, E any](file io.WriteCloser, data T) error {
 	defer file.Close()
 
-	encoder := json.NewEncoder(file)
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
 	encoder.SetIndent("", "  ")
-	return encoder.Encode(data)
+
+	if err := encoder.Encode(data); err != nil {
+		return err
+	}
+
+	content, err := Seal(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(content)
+	return err
 }
 
 func getFromFile[T ~[]E, E any](file io.ReadCloser) (T, error) {
 	defer file.Close()
 
-	var data T
-	decoder := json.NewDecoder(file)
-	err := decoder.Decode(&data)
+	var content json.RawMessage
+	err := json.NewDecoder(file).Decode(&content)
 
-	if err == io.EOF {
-		err = nil
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
 
-		if data == nil {
-			data = make(T, 0)
+	plaintext, err := Open(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var data T
+
+	if len(bytes.TrimSpace(plaintext)) > 0 {
+		if err = json.Unmarshal(plaintext, &data); err != nil {
+			return nil, err
 		}
 	}
 
-	return data, err
+	if data == nil {
+		data = make(T, 0)
+	}
+
+	return data, nil
+}
+
+// GetFilePath returns where the given file of the current ledger is kept.
+func GetFilePath(filename string) string {
+	return filepath.Join(getSaveDir(), filename)
 }
 
 func OpenOrCreateFile(dir string, filename string) (*os.File, error) {
@@ -73,6 +153,28 @@ func OpenOrCreateFile(dir string, filename string) (*os.File, error) {
 	return f, nil
 }
 
+// replaceFile has save write the given file of dir under a temporary name, closing it, and renames it over
+// the file once save succeeded. A failed save leaves the file as it was, and a shorter content leaves nothing
+// of the previous one behind.
+func replaceFile(dir string, filename string, save func(file io.WriteCloser) error) error {
+	if err := ensureDirExists(dir); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, filename+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if err = save(tmp); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filepath.Join(dir, filename))
+}
+
 func ensureDirExists(dir string) error {
 	_, err := os.Stat(dir)
 
@@ -83,7 +185,12 @@ func ensureDirExists(dir string) error {
 	return err
 }
 
+// getSaveDir returns the directory of the current ledger.
 func getSaveDir() string {
+	return getLedgerDir(CurrentLedger())
+}
+
+func getAppDir() string {
 	path, err := os.UserConfigDir()
 
 	if err != nil {
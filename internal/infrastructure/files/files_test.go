@@ -2,6 +2,7 @@
 package files
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
@@ -120,12 +121,10 @@ func TestGetFromFile(t *testing.T) {
 				correctJson, _ := json.MarshalIndent(expenses, "", "  ")
 				correctJson = append(correctJson, '\n')
 
+				reader := bytes.NewReader(correctJson)
+
 				result := mocks.NewMockReadCloser(ctrl)
-				firstCall := result.EXPECT().Read(gomock.Any()).DoAndReturn(
-					func(p []byte) (n int, err error) {
-						copy(p, correctJson)
-						return len(correctJson), nil
-					}).Times(1)
+				firstCall := result.EXPECT().Read(gomock.Any()).DoAndReturn(reader.Read).MinTimes(1)
 				result.EXPECT().Close().Times(1).After(firstCall)
 
 				return result
@@ -0,0 +1,89 @@
+package files
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sync"
+)
+
+const (
+	// DefaultLedger keeps its files right in the application directory, where they were before ledgers existed.
+	DefaultLedger = "default"
+
+	ledgersDirName = "ledgers"
+)
+
+// ledgerNamePattern keeps ledger names usable as directory names on every system.
+var ledgerNamePattern = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N} _-]{0,39}$`)
+
+// ledger is the name of the ledger every file is read from and written to.
+var (
+	ledgerMu sync.RWMutex
+	ledger   = DefaultLedger
+)
+
+// IsValidLedgerName reports whether name can be used for a ledger: up to 40 letters, digits, spaces,
+// dashes and underscores, starting with a letter or a digit.
+func IsValidLedgerName(name string) bool {
+	return ledgerNamePattern.MatchString(name)
+}
+
+// CurrentLedger returns the name of the ledger the files are kept in.
+func CurrentLedger() string {
+	ledgerMu.RLock()
+	defer ledgerMu.RUnlock()
+
+	return ledger
+}
+
+// SetLedger switches every file that is read and written afterwards to the given ledger.
+func SetLedger(name string) error {
+	if !IsValidLedgerName(name) {
+		return fmt.Errorf("invalid ledger name %q", name)
+	}
+
+	ledgerMu.Lock()
+	defer ledgerMu.Unlock()
+
+	ledger = name
+	return nil
+}
+
+// CreateLedger makes the directory of a new ledger, so it is listed before anything is written to it.
+func CreateLedger(name string) error {
+	if !IsValidLedgerName(name) {
+		return fmt.Errorf("invalid ledger name %q", name)
+	}
+
+	return ensureDirExists(getLedgerDir(name))
+}
+
+// Ledgers returns the name of every ledger, the default one first and the others sorted.
+func Ledgers() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(getAppDir(), ledgersDirName))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	ledgers := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() != DefaultLedger && IsValidLedgerName(entry.Name()) {
+			ledgers = append(ledgers, entry.Name())
+		}
+	}
+
+	slices.Sort(ledgers)
+	return append([]string{DefaultLedger}, ledgers...), nil
+}
+
+func getLedgerDir(name string) string {
+	if name == DefaultLedger {
+		return getAppDir()
+	}
+
+	return filepath.Join(getAppDir(), ledgersDirName, name)
+}
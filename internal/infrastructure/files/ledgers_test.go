@@ -0,0 +1,37 @@
+package files
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIsValidLedgerName(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		ledger   string
+		expected bool
+	}
+
+	testCases := []testCase{
+		{name: "Word", ledger: "household", expected: true},
+		{name: "Spaces, dashes and underscores", ledger: "Side business_2-flat", expected: true},
+		{name: "Letters beyond ASCII", ledger: "Wohngemeinschaft München", expected: true},
+		{name: "Empty", ledger: "", expected: false},
+		{name: "Path separator", ledger: "flat/2026", expected: false},
+		{name: "Parent directory", ledger: "..", expected: false},
+		{name: "Hidden directory", ledger: ".flat", expected: false},
+		{name: "Leading space", ledger: " flat", expected: false},
+		{name: "Too long", ledger: "a1234567890123456789012345678901234567890", expected: false},
+	}
+
+	for _, tt := range testCases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, IsValidLedgerName(tt.ledger))
+		})
+	}
+}
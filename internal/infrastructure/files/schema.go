@@ -0,0 +1,173 @@
+package files
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// migration upgrades the records of the expenses file from one schema version to the next. Records are kept
+// as raw objects with json.Number values, so fields the current code no longer knows can still be read.
+type migration func(records []map[string]any) ([]map[string]any, error)
+
+// migrations[v] upgrades a file of version v to version v+1. The schema version written by SaveToFile, the
+// backups and the journal is the number of migrations, so a change to the stored fields only needs its step
+// appended here.
+var migrations = []migration{
+	migrateBareArray,
+}
+
+// versionedFile is the envelope the expenses file is written in.
+type versionedFile[T any] struct {
+	Version  int `json:"version"`
+	Expenses T   `json:"expenses"`
+}
+
+// migrateBareArray upgrades version 0, a bare array of expenses without a version marker, to the envelope.
+// The records themselves did not change, the envelope is added when the file is written back.
+func migrateBareArray(records []map[string]any) ([]map[string]any, error) {
+	return records, nil
+}
+
+func saveToVersionedFile[T ~[]E, E any](file io.WriteCloser, data T) error {
+	defer file.Close()
+
+	content, err := EncodeExpenses(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(content)
+	return err
+}
+
+// EncodeExpenses returns the content of an expenses file holding data, such as a backup: the envelope of
+// the current schema version, sealed when the expenses are encrypted.
+func EncodeExpenses[T ~[]E, E any](data T) ([]byte, error) {
+	content, err := MarshalVersioned(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return Seal(content)
+}
+
+// DecodeExpenses reads content written by EncodeExpenses, upgrading content of an older schema version.
+func DecodeExpenses[T ~[]E, E any](content []byte) (T, error) {
+	return getFromVersionedFile[T](io.NopCloser(bytes.NewReader(content)))
+}
+
+// SchemaVersion returns the schema version expenses are written with.
+func SchemaVersion() int {
+	return len(migrations)
+}
+
+// MarshalVersioned writes data in the envelope of the current schema version, in plaintext.
+func MarshalVersioned[T ~[]E, E any](data T) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(versionedFile[T]{Version: SchemaVersion(), Expenses: data}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func getFromVersionedFile[T ~[]E, E any](file io.ReadCloser) (T, error) {
+	defer file.Close()
+
+	var content json.RawMessage
+	err := json.NewDecoder(file).Decode(&content)
+
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	plaintext, err := Open(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return UnmarshalVersioned[T](plaintext)
+}
+
+// UnmarshalVersioned reads plaintext written by MarshalVersioned, upgrading content of an older schema version.
+func UnmarshalVersioned[T ~[]E, E any](content []byte) (T, error) {
+	expenses, err := upgrade(content, migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	var data T
+	if err = json.Unmarshal(expenses, &data); err != nil {
+		return nil, err
+	}
+
+	if data == nil {
+		data = make(T, 0)
+	}
+
+	return data, nil
+}
+
+// UpgradeRecords reads expense records stored outside of the envelope along with the schema version they
+// were written with, such as the events of a journal, upgrading them to the current one.
+func UpgradeRecords[T ~[]E, E any](version int, records json.RawMessage) (T, error) {
+	content, err := json.Marshal(versionedFile[json.RawMessage]{Version: version, Expenses: records})
+	if err != nil {
+		return nil, err
+	}
+
+	return UnmarshalVersioned[T](content)
+}
+
+// upgrade returns the expenses of the file content brought to the last version of steps. An empty file
+// holds no expenses.
+func upgrade(content []byte, steps []migration) (json.RawMessage, error) {
+	content = bytes.TrimSpace(content)
+
+	if len(content) == 0 {
+		return json.RawMessage("[]"), nil
+	}
+
+	var file versionedFile[json.RawMessage]
+
+	if content[0] == '[' {
+		file.Expenses = content
+	} else if err := json.Unmarshal(content, &file); err != nil {
+		return nil, err
+	}
+
+	if file.Version > len(steps) {
+		return nil, fmt.Errorf("The expenses file has schema version %d, this version supports up to %d", file.Version, len(steps))
+	}
+
+	if len(file.Expenses) == 0 {
+		return json.RawMessage("[]"), nil
+	}
+
+	if file.Version == len(steps) {
+		return file.Expenses, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(file.Expenses))
+	decoder.UseNumber()
+
+	var records []map[string]any
+	if err := decoder.Decode(&records); err != nil {
+		return nil, err
+	}
+
+	for version := file.Version; version < len(steps); version++ {
+		var err error
+
+		if records, err = steps[version](records); err != nil {
+			return nil, fmt.Errorf("Error migrating the expenses file from version %d: %w", version, err)
+		}
+	}
+
+	return json.Marshal(records)
+}
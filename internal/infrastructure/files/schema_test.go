@@ -0,0 +1,216 @@
+package files
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/files/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSaveToVersionedFile(t *testing.T) {
+	t.Parallel()
+
+	assert := assert.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expenses := []domain.Expense{
+		{Id: 1, Description: "Expense 1", Amount: 10},
+		{Id: 2, Description: "Expense 2", Amount: 20},
+	}
+
+	correctJson, _ := json.MarshalIndent(versionedFile[[]domain.Expense]{Version: len(migrations), Expenses: expenses}, "", "  ")
+	correctJson = append(correctJson, '\n')
+
+	writer := mocks.NewMockWriteCloser(ctrl)
+	firstCall := writer.EXPECT().Write(gomock.Eq(correctJson)).Times(1)
+	writer.EXPECT().Close().Times(1).After(firstCall)
+
+	err := saveToVersionedFile(writer, expenses)
+
+	assert.NoError(err)
+	assert.Contains(string(correctJson), fmt.Sprintf(`"version": %d`, len(migrations)))
+}
+
+func TestGetFromVersionedFile(t *testing.T) {
+	t.Parallel()
+
+	assert := assert.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expenses := []domain.Expense{
+		{Id: 1, SpentAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), Description: "Expense 1", Category: "Food", Amount: 10.5},
+		{Id: 2, SpentAt: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), Description: "Expense 2", Amount: 20, Tags: []string{"work"}},
+	}
+
+	bareArray, _ := json.MarshalIndent(expenses, "", "  ")
+	envelope, _ := json.MarshalIndent(versionedFile[[]domain.Expense]{Version: len(migrations), Expenses: expenses}, "", "  ")
+
+	type TestCase struct {
+		name             string
+		content          string
+		expectedExpenses []domain.Expense
+		expectedErr      bool
+	}
+
+	tests := []TestCase{
+		{
+			name:             "Current version",
+			content:          string(envelope),
+			expectedExpenses: expenses,
+		},
+		{
+			name:             "Bare array of version 0",
+			content:          string(bareArray),
+			expectedExpenses: expenses,
+		},
+		{
+			name:             "Trailing bytes of a longer earlier write",
+			content:          string(envelope) + "\n  }\n]\n",
+			expectedExpenses: expenses,
+		},
+		{
+			name:             "Empty file",
+			content:          "",
+			expectedExpenses: []domain.Expense{},
+		},
+		{
+			name:             "Envelope without expenses",
+			content:          fmt.Sprintf(`{"version": %d}`, len(migrations)),
+			expectedExpenses: []domain.Expense{},
+		},
+		{
+			name:        "Newer version",
+			content:     fmt.Sprintf(`{"version": %d, "expenses": []}`, len(migrations)+1),
+			expectedErr: true,
+		},
+		{
+			name:        "Broken file",
+			content:     `{"version": `,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			reader := bytes.NewReader([]byte(tt.content))
+
+			file := mocks.NewMockReadCloser(ctrl)
+			file.EXPECT().Read(gomock.Any()).DoAndReturn(reader.Read).MinTimes(1)
+			file.EXPECT().Close().Times(1)
+
+			result, err := getFromVersionedFile[[]domain.Expense](file)
+
+			if tt.expectedErr {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+				assert.Equal(tt.expectedExpenses, result)
+			}
+		})
+	}
+}
+
+func TestUpgrade(t *testing.T) {
+	t.Parallel()
+
+	assert := assert.New(t)
+
+	renameDate := func(records []map[string]any) ([]map[string]any, error) {
+		for _, r := range records {
+			r["SpentAt"] = r["Date"]
+			delete(r, "Date")
+		}
+
+		return records, nil
+	}
+
+	addCurrency := func(records []map[string]any) ([]map[string]any, error) {
+		for _, r := range records {
+			r["Currency"] = "EUR"
+		}
+
+		return records, nil
+	}
+
+	failing := func(records []map[string]any) ([]map[string]any, error) {
+		return nil, fmt.Errorf("test error")
+	}
+
+	type TestCase struct {
+		name        string
+		content     string
+		steps       []migration
+		expected    string
+		expectedErr bool
+	}
+
+	tests := []TestCase{
+		{
+			name:     "Runs every step from the file version in order",
+			content:  `[{"Id": 1, "Date": "2026-10-18T00:00:00Z", "Amount": 10.10}]`,
+			steps:    []migration{renameDate, addCurrency},
+			expected: `[{"Id": 1, "SpentAt": "2026-10-18T00:00:00Z", "Amount": 10.10, "Currency": "EUR"}]`,
+		},
+		{
+			name:     "Skips the steps older than the file",
+			content:  `{"version": 1, "expenses": [{"Id": 1, "Date": "2026-10-18T00:00:00Z"}]}`,
+			steps:    []migration{renameDate, addCurrency},
+			expected: `[{"Id": 1, "Date": "2026-10-18T00:00:00Z", "Currency": "EUR"}]`,
+		},
+		{
+			name:     "Keeps numbers as written",
+			content:  `[{"Id": 12345678901234567, "Amount": 0.1}]`,
+			steps:    []migration{addCurrency},
+			expected: `[{"Id": 12345678901234567, "Amount": 0.1, "Currency": "EUR"}]`,
+		},
+		{
+			name:     "Current version is left as is",
+			content:  `{"version": 2, "expenses": [{"Id": 1}]}`,
+			steps:    []migration{renameDate, addCurrency},
+			expected: `[{"Id": 1}]`,
+		},
+		{
+			name:        "Failing step",
+			content:     `[{"Id": 1}]`,
+			steps:       []migration{failing},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := upgrade([]byte(tt.content), tt.steps)
+
+			if tt.expectedErr {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+				assert.JSONEq(tt.expected, string(result))
+			}
+		})
+	}
+}
+
+func TestMigrateBareArray(t *testing.T) {
+	t.Parallel()
+
+	assert := assert.New(t)
+
+	records := []map[string]any{
+		{"Id": json.Number("1"), "SpentAt": "2026-10-18T00:00:00Z", "Description": "Expense 1", "Amount": json.Number("10")},
+	}
+
+	result, err := migrateBareArray(records)
+
+	assert.NoError(err)
+	assert.Equal(records, result)
+}
@@ -0,0 +1,352 @@
+package journal
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/files"
+	"github.com/samber/lo"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+const (
+	// FileName is the journal kept in the application directory.
+	FileName = "expenses.journal"
+	// SnapshotFileName holds the expenses as they were at the last compaction.
+	SnapshotFileName = "expenses.snapshot.json"
+	// DefaultCompactSize is the journal size in bytes past which it is folded into the snapshot.
+	DefaultCompactSize = 1 << 20
+)
+
+// Op is the kind of change an event records.
+type Op string
+
+const (
+	InsertOp Op = "insert"
+	UpdateOp Op = "update"
+	DeleteOp Op = "delete"
+	// ReplaceOp drops every expense before inserting the ones of the event.
+	ReplaceOp Op = "replace"
+)
+
+// Event is one line of the journal. Version is the schema version of its expenses, they are upgraded
+// like the ones of the expenses file when the journal is read by a later version.
+type Event struct {
+	Op       Op
+	At       time.Time
+	Version  int              `json:",omitempty"`
+	Expenses []domain.Expense `json:",omitempty"`
+	Ids      []int            `json:",omitempty"`
+}
+
+// eventLine is an event as read from the journal, its expenses kept raw until they are upgraded.
+type eventLine struct {
+	Event
+	Expenses json.RawMessage `json:",omitempty"`
+}
+
+// ExpenseStorage keeps the expenses as a snapshot and a JSON Lines journal of the changes made since.
+// Every write appends a single line; the state is rebuilt by replaying the journal over the snapshot.
+type ExpenseStorage struct {
+	path         string
+	snapshotPath string
+	compactSize  int64
+}
+
+func NewExpenseStorage(path string, snapshotPath string) *ExpenseStorage {
+	return &ExpenseStorage{path: path, snapshotPath: snapshotPath, compactSize: DefaultCompactSize}
+}
+
+func (s *ExpenseStorage) Get(id int) (domain.Expense, bool, error) {
+	state, err := s.replay()
+	if err != nil {
+		return domain.Expense{}, false, err
+	}
+
+	expense, found := state[id]
+	return expense, found, nil
+}
+
+func (s *ExpenseStorage) Insert(expenses ...domain.Expense) error {
+	if len(expenses) == 0 {
+		return nil
+	}
+
+	return s.append(Event{Op: InsertOp, Expenses: expenses})
+}
+
+func (s *ExpenseStorage) Update(expenses ...domain.Expense) error {
+	if len(expenses) == 0 {
+		return nil
+	}
+
+	return s.append(Event{Op: UpdateOp, Expenses: expenses})
+}
+
+func (s *ExpenseStorage) Delete(ids ...int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return s.append(Event{Op: DeleteOp, Ids: ids})
+}
+
+// Replace records the replacement as a single event and folds it into the snapshot right away, as it
+// makes the changes recorded before it irrelevant.
+func (s *ExpenseStorage) Replace(expenses ...domain.Expense) error {
+	if err := s.append(Event{Op: ReplaceOp, Expenses: expenses}); err != nil {
+		return err
+	}
+
+	return s.Compact()
+}
+
+func (s *ExpenseStorage) Query(filter domain.ExpenseFilter) ([]domain.Expense, error) {
+	state, err := s.replay()
+	if err != nil {
+		return nil, err
+	}
+
+	expenses := lo.Filter(sorted(state), func(e domain.Expense, _ int) bool {
+		return filter.Matches(e)
+	})
+
+	return expenses, nil
+}
+
+func (s *ExpenseStorage) NextID() (int, error) {
+	state, err := s.replay()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(state) == 0 {
+		return 1, nil
+	}
+
+	return slices.Max(slices.Collect(maps.Keys(state))) + 1, nil
+}
+
+// Events returns the changes recorded since the last compaction, oldest first.
+func (s *ExpenseStorage) Events() ([]Event, error) {
+	return readEvents(s.path)
+}
+
+// Compact folds the journal into the snapshot and empties it. Replaying the journal over a snapshot that
+// already contains it gives the same state, so a crash between both steps loses nothing.
+func (s *ExpenseStorage) Compact() error {
+	state, err := s.replay()
+	if err != nil {
+		return err
+	}
+
+	data, err := files.MarshalVersioned(sorted(state))
+	if err != nil {
+		return err
+	}
+
+	if err = writeFileAtomic(s.snapshotPath, data); err != nil {
+		return fmt.Errorf("Error writing snapshot: %w", err)
+	}
+
+	return os.Truncate(s.path, 0)
+}
+
+func (s *ExpenseStorage) append(event Event) error {
+	event.At = time.Now().UTC()
+	event.Version = files.SchemaVersion()
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	if err = dropTornLine(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return err
+	}
+
+	if info.Size() > s.compactSize {
+		return s.Compact()
+	}
+
+	return nil
+}
+
+// replay rebuilds the expenses from the snapshot and the journal.
+func (s *ExpenseStorage) replay() (map[int]domain.Expense, error) {
+	state := make(map[int]domain.Expense)
+
+	snapshot, err := os.ReadFile(s.snapshotPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	// snapshots written before the schema was versioned are a bare array, upgraded like the expenses file
+	expenses, err := files.UnmarshalVersioned[[]domain.Expense](snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading snapshot %s: %w", s.snapshotPath, err)
+	}
+
+	for _, e := range expenses {
+		state[e.Id] = e
+	}
+
+	events, err := readEvents(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range events {
+		apply(state, event)
+	}
+
+	return state, nil
+}
+
+func apply(state map[int]domain.Expense, event Event) {
+	switch event.Op {
+	case InsertOp:
+		for _, e := range event.Expenses {
+			state[e.Id] = e
+		}
+	case UpdateOp:
+		for _, e := range event.Expenses {
+			if _, ok := state[e.Id]; ok {
+				state[e.Id] = e
+			}
+		}
+	case DeleteOp:
+		for _, id := range event.Ids {
+			delete(state, id)
+		}
+	case ReplaceOp:
+		clear(state)
+
+		for _, e := range event.Expenses {
+			state[e.Id] = e
+		}
+	}
+}
+
+// readEvents reads the journal at path. A broken last line is the trace of a write cut short and is skipped,
+// a broken line anywhere else means the journal is damaged.
+func readEvents(path string) ([]Event, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	lines := bytes.Split(bytes.TrimRight(data, "\n"), []byte("\n"))
+	events := make([]Event, 0, len(lines))
+
+	for i, line := range lines {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var event eventLine
+		if err := json.Unmarshal(line, &event); err != nil {
+			if i == len(lines)-1 {
+				break
+			}
+
+			return nil, fmt.Errorf("Error reading line %d of %s: %w", i+1, path, err)
+		}
+
+		if len(event.Expenses) > 0 {
+			if event.Event.Expenses, err = files.UpgradeRecords[[]domain.Expense](event.Version, event.Expenses); err != nil {
+				return nil, fmt.Errorf("Error reading line %d of %s: %w", i+1, path, err)
+			}
+		}
+
+		events = append(events, event.Event)
+	}
+
+	return events, nil
+}
+
+// dropTornLine cuts off a last line left without its line break by an interrupted write, so the next event
+// starts on a line of its own.
+func dropTornLine(f *os.File) error {
+	info, err := f.Stat()
+	if err != nil || info.Size() == 0 {
+		return err
+	}
+
+	last := make([]byte, 1)
+	if _, err = f.ReadAt(last, info.Size()-1); err != nil {
+		return err
+	}
+
+	if last[0] == '\n' {
+		return nil
+	}
+
+	data := make([]byte, info.Size())
+	if _, err = f.ReadAt(data, 0); err != nil {
+		return err
+	}
+
+	return f.Truncate(int64(bytes.LastIndexByte(data, '\n') + 1))
+}
+
+func sorted(state map[int]domain.Expense) []domain.Expense {
+	return slices.SortedFunc(maps.Values(state), func(a, b domain.Expense) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+}
+
+// writeFileAtomic writes data under a temporary name only the user can read and renames it over path.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,210 @@
+package journal
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestStorage(t *testing.T) *ExpenseStorage {
+	t.Helper()
+
+	dir := t.TempDir()
+	return NewExpenseStorage(filepath.Join(dir, FileName), filepath.Join(dir, SnapshotFileName))
+}
+
+func TestExpenseStorage(t *testing.T) {
+	t.Parallel()
+
+	storage := newTestStorage(t)
+
+	expenses := []domain.Expense{
+		{Id: 1, Description: "Coffee", Category: "Food", Amount: 3.5, SpentAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Id: 2, Description: "Taxi", Category: "Transport", Amount: 12, SpentAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Tags: []string{"work"}},
+		{Id: 3, Description: "Bread", Category: "Food:Groceries", Amount: 2, SpentAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	id, err := storage.NextID()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+
+	assert.NoError(t, storage.Insert(expenses...))
+
+	updated := expenses[0]
+	updated.Amount = 4
+	assert.NoError(t, storage.Update(updated, domain.Expense{Id: 42}))
+	assert.NoError(t, storage.Delete(2))
+
+	loaded, err := storage.Query(domain.ExpenseFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Expense{updated, expenses[2]}, loaded)
+
+	loaded, err = storage.Query(domain.ExpenseFilter{Category: "Food:Groceries"})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Expense{expenses[2]}, loaded)
+
+	_, found, err := storage.Get(2)
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	id, err = storage.NextID()
+	assert.NoError(t, err)
+	assert.Equal(t, 4, id)
+
+	events, err := storage.Events()
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+	assert.Equal(t, []Op{InsertOp, UpdateOp, DeleteOp}, []Op{events[0].Op, events[1].Op, events[2].Op})
+}
+
+func TestCompaction(t *testing.T) {
+	t.Parallel()
+
+	storage := newTestStorage(t)
+	storage.compactSize = 300
+
+	for i := 1; i <= 5; i++ {
+		assert.NoError(t, storage.Insert(domain.Expense{Id: i, Description: "Lunch", Category: "Food", Amount: float64(i)}))
+	}
+
+	assert.NoError(t, storage.Delete(1))
+
+	events, err := storage.Events()
+	assert.NoError(t, err)
+	assert.Less(t, len(events), 6)
+
+	_, err = os.Stat(storage.snapshotPath)
+	assert.NoError(t, err)
+
+	loaded, err := storage.Query(domain.ExpenseFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, loaded, 4)
+	assert.Equal(t, 2, loaded[0].Id)
+
+	// a crash after the snapshot was written but before the journal was emptied replays it once more
+	assert.NoError(t, storage.Compact())
+	assert.NoError(t, storage.Update(domain.Expense{Id: 2, Description: "Dinner", Category: "Food", Amount: 20}))
+	assert.NoError(t, storage.Delete(3))
+
+	journal, err := os.ReadFile(storage.path)
+	assert.NoError(t, err)
+	assert.NoError(t, storage.Compact())
+	assert.NoError(t, os.WriteFile(storage.path, journal, 0644))
+
+	replayed, err := storage.Query(domain.ExpenseFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, replayed, 3)
+	assert.Equal(t, "Dinner", replayed[0].Description)
+}
+
+func TestReplace(t *testing.T) {
+	t.Parallel()
+
+	storage := newTestStorage(t)
+
+	assert.NoError(t, storage.Insert(
+		domain.Expense{Id: 1, Description: "Coffee", Amount: 3},
+		domain.Expense{Id: 2, Description: "Tea", Amount: 2},
+	))
+	assert.NoError(t, storage.Delete(1))
+
+	replacement := []domain.Expense{
+		{Id: 1, Description: "Lunch", Amount: 12},
+		{Id: 3, Description: "Bus", Amount: 2},
+	}
+
+	assert.NoError(t, storage.Replace(replacement...))
+
+	loaded, err := storage.Query(domain.ExpenseFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, replacement, loaded)
+
+	events, err := storage.Events()
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	// a crash before the journal was emptied replays the replacement over its own snapshot
+	assert.NoError(t, os.WriteFile(storage.path, []byte(`{"Op":"insert","Expenses":[{"Id":2,"Description":"Tea","Amount":2}]}
+{"Op":"replace","Expenses":[{"Id":1,"Description":"Lunch","Amount":12},{"Id":3,"Description":"Bus","Amount":2}]}
+`), 0644))
+
+	loaded, err = storage.Query(domain.ExpenseFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, loaded, 2)
+	assert.Equal(t, []int{1, 3}, []int{loaded[0].Id, loaded[1].Id})
+}
+
+func TestTornLine(t *testing.T) {
+	t.Parallel()
+
+	storage := newTestStorage(t)
+
+	assert.NoError(t, storage.Insert(domain.Expense{Id: 1, Description: "Coffee", Amount: 3}))
+
+	f, err := os.OpenFile(storage.path, os.O_WRONLY|os.O_APPEND, 0644)
+	assert.NoError(t, err)
+	_, err = f.WriteString(`{"Op":"insert","Expen`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	loaded, err := storage.Query(domain.ExpenseFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, loaded, 1)
+
+	assert.NoError(t, storage.Insert(domain.Expense{Id: 2, Description: "Tea", Amount: 2}))
+
+	loaded, err = storage.Query(domain.ExpenseFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, loaded, 2)
+}
+
+func TestSchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	storage := newTestStorage(t)
+
+	// a snapshot and events written before the schema was versioned are upgraded when they are replayed
+	assert.NoError(t, os.WriteFile(storage.snapshotPath, []byte(`[{"Id": 1, "Description": "Coffee", "Category": "Food", "Amount": 3.5}]`), 0600))
+	assert.NoError(t, os.WriteFile(storage.path, []byte(`{"Op": "insert", "Expenses": [{"Id": 2, "Description": "Taxi", "Category": "Transport", "Amount": 12}]}`+"\n"), 0600))
+
+	loaded, err := storage.Query(domain.ExpenseFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Expense{
+		{Id: 1, Description: "Coffee", Category: "Food", Amount: 3.5},
+		{Id: 2, Description: "Taxi", Category: "Transport", Amount: 12},
+	}, loaded)
+
+	assert.NoError(t, storage.Delete(1))
+
+	events, err := storage.Events()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, events[0].Version)
+	assert.Equal(t, 1, events[1].Version)
+
+	assert.NoError(t, storage.Compact())
+
+	snapshot, err := os.ReadFile(storage.snapshotPath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(snapshot), `"version": 1`)
+
+	info, err := os.Stat(storage.snapshotPath)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	tmps, err := filepath.Glob(filepath.Join(filepath.Dir(storage.snapshotPath), "*.tmp"))
+	assert.NoError(t, err)
+	assert.Empty(t, tmps)
+
+	loaded, err = storage.Query(domain.ExpenseFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Expense{{Id: 2, Description: "Taxi", Category: "Transport", Amount: 12}}, loaded)
+
+	// expenses of a later version are not read as if this version knew them
+	assert.NoError(t, os.WriteFile(storage.path, []byte(`{"Op": "insert", "Version": 99, "Expenses": [{"Id": 3}]}`+"\n"+`{"Op": "delete", "Ids": [3]}`+"\n"), 0600))
+
+	_, err = storage.Query(domain.ExpenseFilter{})
+	assert.ErrorContains(t, err, "schema version 99")
+}
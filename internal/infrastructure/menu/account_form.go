@@ -0,0 +1,184 @@
+package menu
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/menu/constants"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"strconv"
+	"strings"
+)
+
+const accountFormTitle = "Account"
+
+type accountFormModel struct {
+	focusIndex  int
+	inputs      []textinput.Model
+	editingName *string
+
+	//help
+	helpModel      help.Model
+	navigationKeys NavigationKeyMap
+}
+
+func (m accountFormModel) Init() tea.Cmd { return nil }
+
+func (m accountFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, constants.Keymap.Enter, constants.Keymap.Up, constants.Keymap.Down):
+			if key.Matches(msg, constants.Keymap.Enter) {
+				if m.focusIndex == len(m.inputs) {
+					hasError := false
+
+					for i := range m.inputs {
+						err := m.inputs[i].Err
+
+						if err != nil {
+							m.inputs[i].SetValue(err.Error())
+							hasError = true
+						}
+					}
+
+					if hasError {
+						return m, nil
+					}
+
+					opening, err := parseOptionalFloat(m.inputs[1].Value())
+					if err != nil {
+						return m, errorCmd(err, goToAccountsCmd())
+					}
+
+					account := domain.Account{Name: m.inputs[0].Value(), OpeningBalance: opening}
+
+					if m.editingName == nil {
+						_, err = expense.AddAccount(account)
+					} else {
+						_, err = expense.UpdateAccount(*m.editingName, account)
+					}
+
+					if err != nil {
+						return m, errorCmd(err, goToAccountsCmd())
+					}
+
+					return m, goToAccountsCmd()
+				} else {
+					m.focusIndex++
+				}
+			} else {
+				if key.Matches(msg, constants.Keymap.Up) {
+					m.focusIndex--
+				} else if key.Matches(msg, constants.Keymap.Down) {
+					m.focusIndex++
+				}
+			}
+
+			if m.focusIndex > len(m.inputs) {
+				m.focusIndex = 0
+			} else if m.focusIndex < 0 {
+				m.focusIndex = len(m.inputs)
+			}
+
+			cmds := make([]tea.Cmd, len(m.inputs))
+
+			for i := 0; i < len(m.inputs); i++ {
+				if i == m.focusIndex {
+					// Set focused state
+					cmds[i] = m.inputs[i].Focus()
+					m.inputs[i].PromptStyle = focusedStyle
+					m.inputs[i].TextStyle = focusedStyle
+				} else {
+					// Remove focused state
+					m.inputs[i].Blur()
+					m.inputs[i].PromptStyle = blurredStyle
+					m.inputs[i].TextStyle = blurredStyle
+				}
+			}
+
+			return m, tea.Batch(cmds...)
+		case key.Matches(msg, constants.Keymap.Back):
+			return m, goToAccountsCmd()
+		}
+	}
+
+	cmds := make([]tea.Cmd, len(m.inputs))
+
+	for i := range m.inputs {
+		m.inputs[i], cmds[i] = m.inputs[i].Update(msg)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m accountFormModel) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(accountFormTitle) + "\n\n")
+
+	for i := range m.inputs {
+		b.WriteString(m.inputs[i].View())
+
+		if i < len(m.inputs)-1 {
+			b.WriteRune('\n')
+		}
+	}
+
+	button := &blurredButton
+	if m.focusIndex == len(m.inputs) {
+		button = &focusedButton
+	}
+
+	if m.editingName != nil {
+		b.WriteString("\n\nA new name is carried over to every expense and transfer of the account")
+	}
+
+	fmt.Fprintf(&b, "\n\n%s\n\n", *button)
+	b.WriteString(m.helpModel.View(m.navigationKeys))
+
+	return b.String()
+}
+
+// newAccountFormModel creates a form editing the given account, or adding a new one when it is nil.
+func newAccountFormModel(account *domain.Account) accountFormModel {
+	m := accountFormModel{
+		inputs:         make([]textinput.Model, 2),
+		helpModel:      help.New(),
+		navigationKeys: getNavigationKeymap(),
+	}
+
+	var t textinput.Model
+	for i := range m.inputs {
+		t = textinput.New()
+		t.Width = 100
+		t.CharLimit = 0
+		t.PromptStyle = blurredStyle
+		t.TextStyle = blurredStyle
+
+		switch i {
+		case 0:
+			t.Placeholder = "Name, e.g. Cash, Debit card or Shared wallet"
+			t.Focus()
+			t.PromptStyle = focusedStyle
+			t.TextStyle = focusedStyle
+		case 1:
+			t.Placeholder = "Opening balance (empty for 0, negative for a credit card in debt)"
+			t.Validate = validateOptionalFloat
+		}
+
+		m.inputs[i] = t
+	}
+
+	if account != nil {
+		m.inputs[0].SetValue(account.Name)
+		m.inputs[1].SetValue(strconv.FormatFloat(account.OpeningBalance, 'f', -1, 64))
+
+		name := account.Name
+		m.editingName = &name
+	}
+
+	return m
+}
@@ -0,0 +1,277 @@
+package menu
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/samber/lo"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	accountsTitle = "Accounts"
+	historyTitle  = "Running Balance of %s"
+)
+
+type accountsModel struct {
+	table    table.Model
+	balances []expense.AccountBalance
+
+	//running balance of the selected account
+	history      *domain.Account
+	historyTable table.Model
+	dateFormat   expense.DateFormat
+	location     *time.Location
+
+	//help
+	helpModel help.Model
+	keys      AccountKeyMap
+}
+
+func newAccountsModel() (tea.Model, error) {
+	t := table.New(
+		table.WithColumns([]table.Column{
+			{Title: "Account", Width: 24},
+			{Title: "Opening", Width: 12},
+			{Title: "Balance", Width: 12},
+			{Title: "Entries", Width: 8},
+		}),
+		table.WithFocused(true),
+		table.WithHeight(10),
+	)
+	t.SetStyles(getTableStyles())
+
+	history := table.New(
+		table.WithColumns([]table.Column{
+			{Title: "ID", Width: 4},
+			{Title: "Date", Width: 17},
+			{Title: "Description", Width: 30},
+			{Title: "Change", Width: 12},
+			{Title: "Balance", Width: 12},
+		}),
+		table.WithFocused(true),
+		table.WithHeight(12),
+	)
+	history.SetStyles(getTableStyles())
+
+	m := accountsModel{
+		table:        t,
+		historyTable: history,
+		dateFormat:   getDateFormat(),
+		location:     getLocation(),
+		helpModel:    help.New(),
+		keys:         getAccountKeymap(),
+	}
+
+	return m.reload()
+}
+
+func (m accountsModel) Init() tea.Cmd { return nil }
+
+func (m accountsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.history != nil {
+		switch {
+		case key.Matches(keyMsg, m.keys.Back):
+			m.history = nil
+			return m, nil
+		case key.Matches(keyMsg, m.keys.Up):
+			m.historyTable.MoveUp(1)
+			return m, nil
+		case key.Matches(keyMsg, m.keys.Down):
+			m.historyTable.MoveDown(1)
+			return m, nil
+		}
+
+		var cmd tea.Cmd
+		m.historyTable, cmd = m.historyTable.Update(msg)
+		return m, cmd
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keys.Back):
+		return m, backToTableCmd()
+	case key.Matches(keyMsg, m.keys.Create):
+		return m, goToAccountFormCmd(nil)
+	case key.Matches(keyMsg, m.keys.Edit):
+		if balance, ok := m.selectedBalance(); ok {
+			return m, goToAccountFormCmd(&balance.Account)
+		}
+
+		return m, nil
+	case key.Matches(keyMsg, m.keys.Delete):
+		if balance, ok := m.selectedBalance(); ok {
+			if err := expense.DeleteAccount(balance.Account.Name); err != nil {
+				return m, errorCmd(err, goToAccountsCmd())
+			}
+
+			newModel, err := m.reload()
+			if err != nil {
+				return m, errorCmd(err, backToTableCmd())
+			}
+
+			return newModel, nil
+		}
+
+		return m, nil
+	case key.Matches(keyMsg, m.keys.Transfer):
+		if len(m.balances) < 2 {
+			return m, infoCmd("A transfer needs at least two accounts", goToAccountsCmd())
+		}
+
+		return m, goToTransferFormCmd(nil, goToAccountsCmd())
+	case key.Matches(keyMsg, m.keys.History):
+		balance, ok := m.selectedBalance()
+		if !ok {
+			return m, nil
+		}
+
+		entries, err := expense.GetAccountEntries(balance.Account.Name)
+		if err != nil {
+			return m, errorCmd(err, goToAccountsCmd())
+		}
+
+		m.history = &balance.Account
+		m.historyTable.SetRows(lo.Map(entries, func(e expense.AccountEntry, _ int) table.Row {
+			return table.Row{
+				strconv.Itoa(e.Expense.Id),
+				m.dateFormat.Format(e.Expense.SpentAt, m.location),
+				getEntryDescription(e.Expense, balance.Account.Name),
+				fmt.Sprintf("%+.2f", e.Change),
+				fmt.Sprintf("%.2f", e.Balance),
+			}
+		}))
+		m.historyTable.GotoBottom()
+
+		return m, nil
+	case key.Matches(keyMsg, m.keys.Up):
+		m.table.MoveUp(1)
+		return m, nil
+	case key.Matches(keyMsg, m.keys.Down):
+		m.table.MoveDown(1)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+func (m accountsModel) View() string {
+	var b strings.Builder
+
+	if m.history != nil {
+		b.WriteString(titleStyle.Render(fmt.Sprintf(historyTitle, m.history.Name)) + "\n\n")
+		b.WriteString(tableStyle.Render(m.historyTable.View()) + "\n\n")
+		fmt.Fprintf(&b, "Opening balance %.2f, esc to go back\n\n", m.history.OpeningBalance)
+
+		return b.String()
+	}
+
+	b.WriteString(titleStyle.Render(accountsTitle) + "\n\n")
+	b.WriteString(tableStyle.Render(m.table.View()) + "\n\n")
+
+	if len(m.balances) == 0 {
+		b.WriteString("No accounts yet, add cash, cards or a shared wallet to see what each of them holds\n\n")
+	} else {
+		total := lo.SumBy(m.balances, func(b expense.AccountBalance) float64 {
+			return b.Balance
+		})
+
+		fmt.Fprintf(&b, "Held in all accounts: %.2f\n\n", total)
+	}
+
+	b.WriteString(m.helpModel.View(m.keys))
+
+	return b.String()
+}
+
+func (m accountsModel) reload() (accountsModel, error) {
+	balances, err := expense.GetAccountBalances()
+	if err != nil {
+		return m, fmt.Errorf("Error getting accounts: %w", err)
+	}
+
+	m.balances = balances
+	m.table.SetRows(lo.Map(balances, func(b expense.AccountBalance, _ int) table.Row {
+		return table.Row{
+			b.Account.Name,
+			fmt.Sprintf("%.2f", b.Account.OpeningBalance),
+			fmt.Sprintf("%.2f", b.Balance),
+			strconv.Itoa(b.Count),
+		}
+	}))
+
+	return m, nil
+}
+
+func (m accountsModel) selectedBalance() (expense.AccountBalance, bool) {
+	cursor := m.table.Cursor()
+
+	if cursor < 0 || cursor >= len(m.balances) {
+		return expense.AccountBalance{}, false
+	}
+
+	return m.balances[cursor], true
+}
+
+// getEntryDescription describes an entry of the running balance, naming the other account of a transfer.
+func getEntryDescription(e domain.Expense, account string) string {
+	if !e.IsTransfer() {
+		return e.Description
+	}
+
+	if domain.SameAccount(e.Account, account) {
+		return "→ " + e.ToAccount + " " + e.Description
+	}
+
+	return "← " + e.Account + " " + e.Description
+}
+
+// getAccountColumn shows the account an expense was paid from, or both accounts of a transfer.
+func getAccountColumn(e domain.Expense) string {
+	if e.IsTransfer() {
+		return e.Account + " → " + e.ToAccount
+	}
+
+	return e.Account
+}
+
+func getAccountNames(accounts []domain.Account) []string {
+	return lo.Map(accounts, func(a domain.Account, _ int) string {
+		return a.Name
+	})
+}
+
+// validateAccount returns a validator accepting the names of the given accounts, and an empty name
+// when the account is optional.
+func validateAccount(accounts []domain.Account, optional bool) textinput.ValidateFunc {
+	return func(name string) error {
+		if domain.NormalizeAccount(name) == "" {
+			if optional {
+				return nil
+			}
+
+			return fmt.Errorf("account cannot be empty")
+		}
+
+		if !lo.ContainsBy(accounts, func(a domain.Account) bool {
+			return domain.SameAccount(a.Name, name)
+		}) {
+			return fmt.Errorf("unknown account, add it on the accounts screen first")
+		}
+
+		return nil
+	}
+}
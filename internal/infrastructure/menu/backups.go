@@ -0,0 +1,173 @@
+package menu
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/samber/lo"
+	"strconv"
+	"strings"
+)
+
+const backupsTitle = "Backups"
+
+type backupsModel struct {
+	table   table.Model
+	backups []domain.Backup
+
+	//restore waiting for a confirmation
+	confirming bool
+
+	//help
+	helpModel help.Model
+	keys      BackupKeyMap
+}
+
+func newBackupsModel() (tea.Model, error) {
+	t := table.New(
+		table.WithColumns([]table.Column{
+			{Title: "Taken", Width: 20},
+			{Title: "Expenses", Width: 10},
+			{Title: "Name", Width: 40},
+		}),
+		table.WithFocused(true),
+		table.WithHeight(10),
+	)
+	t.SetStyles(getTableStyles())
+
+	m := backupsModel{
+		table:     t,
+		helpModel: help.New(),
+		keys:      getBackupKeymap(),
+	}
+
+	return m.reload()
+}
+
+func (m backupsModel) Init() tea.Cmd { return nil }
+
+func (m backupsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.confirming {
+		switch {
+		case key.Matches(keyMsg, m.keys.Back):
+			m.confirming = false
+			return m, nil
+		case key.Matches(keyMsg, m.keys.Confirm):
+			backup, ok := m.selectedBackup()
+			if !ok {
+				return m, backToTableCmd()
+			}
+
+			count, err := expense.RestoreBackup(backup.Name)
+			if err != nil {
+				return m, errorCmd(err, goToBackupsCmd())
+			}
+
+			return m, infoCmd(fmt.Sprintf("Restored %d expenses from %s", count, backup.Name), backToTableCmd())
+		}
+
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keys.Back):
+		return m, backToTableCmd()
+	case key.Matches(keyMsg, m.keys.BackupNow):
+		backup, err := expense.BackupExpenses()
+		if err != nil {
+			return m, errorCmd(err, goToBackupsCmd())
+		}
+
+		return m, infoCmd(fmt.Sprintf("Backed up %d expenses to %s", backup.Count, backup.Name), goToBackupsCmd())
+	case key.Matches(keyMsg, m.keys.Restore):
+		backup, ok := m.selectedBackup()
+		if ok && backup.Unreadable {
+			return m, infoCmd(fmt.Sprintf("%s cannot be read, so it cannot be restored", backup.Name), goToBackupsCmd())
+		}
+
+		m.confirming = ok
+
+		return m, nil
+	case key.Matches(keyMsg, m.keys.Up):
+		m.table.MoveUp(1)
+		return m, nil
+	case key.Matches(keyMsg, m.keys.Down):
+		m.table.MoveDown(1)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+func (m backupsModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(backupsTitle) + "\n\n")
+	b.WriteString(tableStyle.Render(m.table.View()) + "\n\n")
+
+	if m.confirming {
+		backup, _ := m.selectedBackup()
+		fmt.Fprintf(&b, "Replace every expense with the %d of %s? The current ones are backed up first.\n", backup.Count, backup.Name)
+		b.WriteString("enter to restore, esc to cancel\n\n")
+
+		return b.String()
+	}
+
+	if len(m.backups) == 0 {
+		b.WriteString("No backups yet, one is taken before the first change of every day\n\n")
+	}
+
+	b.WriteString(m.helpModel.View(m.keys))
+
+	return b.String()
+}
+
+func (m backupsModel) reload() (backupsModel, error) {
+	backups, err := expense.GetBackups()
+	if err != nil {
+		return m, fmt.Errorf("Error getting backups: %w", err)
+	}
+
+	layout := getDateFormat().Layout() + " 15:04"
+	loc := getLocation()
+
+	m.backups = backups
+	m.table.SetRows(lo.Map(backups, func(b domain.Backup, _ int) table.Row {
+		return table.Row{
+			b.CreatedAt.In(loc).Format(layout),
+			getBackupCount(b),
+			b.Name,
+		}
+	}))
+
+	return m, nil
+}
+
+func (m backupsModel) selectedBackup() (domain.Backup, bool) {
+	cursor := m.table.Cursor()
+
+	if cursor < 0 || cursor >= len(m.backups) {
+		return domain.Backup{}, false
+	}
+
+	return m.backups[cursor], true
+}
+
+func getBackupCount(b domain.Backup) string {
+	if b.Unreadable {
+		return "unreadable"
+	}
+
+	return strconv.Itoa(b.Count)
+}
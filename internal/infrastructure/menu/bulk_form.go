@@ -0,0 +1,201 @@
+package menu
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/menu/constants"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"strconv"
+	"strings"
+)
+
+const bulkFormTitle = "Bulk Edit"
+
+type bulkFormModel struct {
+	focusIndex int
+	inputs     []textinput.Model
+	ids        []int
+
+	//help
+	helpModel      help.Model
+	navigationKeys NavigationKeyMap
+}
+
+func (m bulkFormModel) Init() tea.Cmd { return nil }
+
+func (m bulkFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, constants.Keymap.Enter, constants.Keymap.Up, constants.Keymap.Down):
+			if key.Matches(msg, constants.Keymap.Enter) {
+				if m.focusIndex == len(m.inputs) {
+					hasError := false
+
+					for i := range m.inputs {
+						err := m.inputs[i].Err
+
+						if err != nil {
+							m.inputs[i].SetValue(err.Error())
+							hasError = true
+						}
+					}
+
+					if hasError {
+						return m, nil
+					}
+
+					change, err := m.getChange()
+					if err != nil {
+						return m, errorCmd(err, goToBulkEditCmd(m.ids))
+					}
+
+					updated, err := expense.UpdateExpenses(m.ids, change)
+					if err != nil {
+						return m, errorCmd(err, backToTableCmd())
+					}
+
+					return m, infoCmd(fmt.Sprintf("Updated %d expenses", len(updated)), backToTableCmd())
+				} else {
+					m.focusIndex++
+				}
+			} else {
+				if key.Matches(msg, constants.Keymap.Up) {
+					m.focusIndex--
+				} else if key.Matches(msg, constants.Keymap.Down) {
+					m.focusIndex++
+				}
+			}
+
+			if m.focusIndex > len(m.inputs) {
+				m.focusIndex = 0
+			} else if m.focusIndex < 0 {
+				m.focusIndex = len(m.inputs)
+			}
+
+			cmds := make([]tea.Cmd, len(m.inputs))
+
+			for i := 0; i < len(m.inputs); i++ {
+				if i == m.focusIndex {
+					// Set focused state
+					cmds[i] = m.inputs[i].Focus()
+					m.inputs[i].PromptStyle = focusedStyle
+					m.inputs[i].TextStyle = focusedStyle
+				} else {
+					// Remove focused state
+					m.inputs[i].Blur()
+					m.inputs[i].PromptStyle = blurredStyle
+					m.inputs[i].TextStyle = blurredStyle
+				}
+			}
+
+			return m, tea.Batch(cmds...)
+		case key.Matches(msg, constants.Keymap.Back):
+			return m, backToTableCmd()
+		}
+	}
+
+	cmds := make([]tea.Cmd, len(m.inputs))
+
+	for i := range m.inputs {
+		m.inputs[i], cmds[i] = m.inputs[i].Update(msg)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m bulkFormModel) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(bulkFormTitle) + "\n\n")
+	fmt.Fprintf(&b, "Selected expenses: %d\n\n", len(m.ids))
+
+	for i := range m.inputs {
+		b.WriteString(m.inputs[i].View())
+
+		if i < len(m.inputs)-1 {
+			b.WriteRune('\n')
+		}
+	}
+
+	button := &blurredButton
+	if m.focusIndex == len(m.inputs) {
+		button = &focusedButton
+	}
+	fmt.Fprintf(&b, "\n\n%s\n\n", *button)
+	b.WriteString(m.helpModel.View(m.navigationKeys))
+
+	return b.String()
+}
+
+func (m bulkFormModel) getChange() (expense.BulkChange, error) {
+	change := expense.BulkChange{
+		Category:   strings.TrimSpace(m.inputs[0].Value()),
+		AddTags:    domain.ParseTags(m.inputs[2].Value()),
+		RemoveTags: domain.ParseTags(m.inputs[3].Value()),
+	}
+
+	if shift := strings.TrimSpace(m.inputs[1].Value()); shift != "" {
+		days, err := strconv.Atoi(shift)
+		if err != nil {
+			return expense.BulkChange{}, err
+		}
+
+		change.ShiftDays = days
+	}
+
+	return change, nil
+}
+
+func newBulkFormModel(ids []int) bulkFormModel {
+	m := bulkFormModel{
+		inputs:         make([]textinput.Model, 4),
+		ids:            ids,
+		helpModel:      help.New(),
+		navigationKeys: getNavigationKeymap(),
+	}
+
+	var t textinput.Model
+	for i := range m.inputs {
+		t = textinput.New()
+		t.Width = 100
+		t.CharLimit = 0
+		t.PromptStyle = blurredStyle
+		t.TextStyle = blurredStyle
+
+		switch i {
+		case 0:
+			t.Placeholder = "New category (empty to keep)"
+			t.Focus()
+			t.PromptStyle = focusedStyle
+			t.TextStyle = focusedStyle
+		case 1:
+			t.Placeholder = "Shift dates by days, e.g. -3 or +7 (empty to keep)"
+			t.Validate = validateShiftDays
+		case 2:
+			t.Placeholder = "Tags to add, comma separated"
+		case 3:
+			t.Placeholder = "Tags to remove, comma separated"
+		}
+
+		m.inputs[i] = t
+	}
+
+	return m
+}
+
+func validateShiftDays(s string) error {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+
+	if _, err := strconv.Atoi(s); err != nil {
+		return fmt.Errorf("shift should be a whole number of days")
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package menu
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samber/lo"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	calendarTitle     = "Calendar"
+	calendarCellWidth = 10
+)
+
+// heatStyles shade days from the lightest to the heaviest spending of the month.
+var heatStyles = []lipgloss.Style{
+	lipgloss.NewStyle().Foreground(lipgloss.Color("255")).Background(lipgloss.Color("22")),
+	lipgloss.NewStyle().Foreground(lipgloss.Color("255")).Background(lipgloss.Color("28")),
+	lipgloss.NewStyle().Foreground(lipgloss.Color("232")).Background(lipgloss.Color("34")),
+	lipgloss.NewStyle().Foreground(lipgloss.Color("232")).Background(lipgloss.Color("40")),
+}
+
+type calendarModel struct {
+	cursor   time.Time
+	totals   map[int]float64
+	location *time.Location
+
+	//help
+	helpModel help.Model
+	keys      CalendarKeyMap
+}
+
+func newCalendarModel() (tea.Model, error) {
+	location := getLocation()
+	now := time.Now().In(location)
+
+	m := calendarModel{
+		cursor:    time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+		location:  location,
+		helpModel: help.New(),
+		keys:      getCalendarKeymap(),
+	}
+
+	return m.loadMonth()
+}
+
+func (m calendarModel) loadMonth() (calendarModel, error) {
+	totals, err := expense.GetMonthlyDailyTotals(m.cursor.Year(), m.cursor.Month())
+	if err != nil {
+		return m, fmt.Errorf("Error getting daily totals: %w", err)
+	}
+
+	m.totals = totals
+	return m, nil
+}
+
+func (m calendarModel) Init() tea.Cmd { return nil }
+
+func (m calendarModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	previous := m.cursor
+
+	switch {
+	case key.Matches(keyMsg, m.keys.Back):
+		return m, backToTableCmd()
+	case key.Matches(keyMsg, m.keys.Open):
+		return m, goToTableOnDateCmd(m.cursor)
+	case key.Matches(keyMsg, m.keys.Create):
+		return m, goToAddDraftCmd(domain.Expense{SpentAt: m.cursor})
+	case key.Matches(keyMsg, m.keys.PrevDay):
+		m.cursor = m.cursor.AddDate(0, 0, -1)
+	case key.Matches(keyMsg, m.keys.NextDay):
+		m.cursor = m.cursor.AddDate(0, 0, 1)
+	case key.Matches(keyMsg, m.keys.PrevWeek):
+		m.cursor = m.cursor.AddDate(0, 0, -7)
+	case key.Matches(keyMsg, m.keys.NextWeek):
+		m.cursor = m.cursor.AddDate(0, 0, 7)
+	case key.Matches(keyMsg, m.keys.PrevMonth):
+		m.cursor = m.cursor.AddDate(0, -1, 0)
+	case key.Matches(keyMsg, m.keys.NextMonth):
+		m.cursor = m.cursor.AddDate(0, 1, 0)
+	}
+
+	if m.cursor.Year() != previous.Year() || m.cursor.Month() != previous.Month() {
+		var err error
+
+		if m, err = m.loadMonth(); err != nil {
+			return m, errorCmd(err, backToTableCmd())
+		}
+	}
+
+	return m, nil
+}
+
+func (m calendarModel) View() string {
+	now := time.Now().In(m.location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	highest := lo.Max(lo.Values(m.totals))
+
+	grid := renderMonthGrid(m.cursor, calendarCellWidth, func(day time.Time) string {
+		total := m.totals[day.Day()]
+
+		amount := ""
+		if total > 0 {
+			amount = formatCompactAmount(total)
+		}
+
+		cell := fmt.Sprintf("%2d %7s", day.Day(), amount)
+
+		switch {
+		case day.Equal(m.cursor):
+			return pickedDayStyle.Render(cell)
+		case total > 0:
+			cell = heatStyle(total, highest).Render(cell)
+		}
+
+		if day.Equal(today) {
+			return todayStyle.Render(cell)
+		}
+
+		return cell
+	})
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(calendarTitle) + "\n\n")
+	b.WriteString(tableStyle.Render(m.cursor.Format("January 2006") + "\n\n" + grid))
+
+	fmt.Fprintf(&b, "\n%s: %.2f    Month: %.2f\n", m.cursor.Format("Mon, 2 Jan"), m.totals[m.cursor.Day()],
+		lo.Sum(lo.Values(m.totals)))
+	b.WriteString(m.helpModel.View(m.keys))
+
+	return b.String()
+}
+
+// heatStyle picks the shade for a day's total relative to the highest total of the month.
+func heatStyle(total float64, highest float64) lipgloss.Style {
+	level := int(math.Ceil(total/highest*float64(len(heatStyles)))) - 1
+	return heatStyles[max(0, min(level, len(heatStyles)-1))]
+}
+
+// formatCompactAmount keeps amounts within seven characters, shortening large ones to thousands.
+func formatCompactAmount(amount float64) string {
+	if amount >= 10000 {
+		return fmt.Sprintf("%.1fk", amount/1000)
+	}
+
+	return fmt.Sprintf("%.2f", amount)
+}
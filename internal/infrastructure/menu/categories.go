@@ -0,0 +1,239 @@
+package menu
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/samber/lo"
+	"strconv"
+	"strings"
+)
+
+const categoryManagerTitle = "Categories"
+
+type categoryAction int
+
+const (
+	noCategoryAction categoryAction = iota
+	renameCategoryAction
+	mergeCategoryAction
+	deleteCategoryAction
+)
+
+type categoryManagerModel struct {
+	table table.Model
+	stats []expense.CategoryStats
+
+	//action
+	action      categoryAction
+	actionInput textinput.Model
+	status      string
+
+	//help
+	helpModel help.Model
+	keys      CategoryKeyMap
+}
+
+func newCategoryManagerModel() (tea.Model, error) {
+	columns := []table.Column{
+		{Title: "Category", Width: 30},
+		{Title: "Expenses", Width: 9},
+		{Title: "Total", Width: 12},
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(10),
+	)
+	t.SetStyles(getTableStyles())
+
+	input := textinput.New()
+	input.Width = 40
+	input.CharLimit = 0
+	input.ShowSuggestions = true
+	input.PromptStyle = focusedStyle
+	input.TextStyle = focusedStyle
+
+	m := categoryManagerModel{
+		table:       t,
+		actionInput: input,
+		helpModel:   help.New(),
+		keys:        getCategoryKeymap(),
+	}
+
+	return m.reload()
+}
+
+func (m categoryManagerModel) Init() tea.Cmd { return nil }
+
+func (m categoryManagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.action != noCategoryAction {
+		switch {
+		case key.Matches(keyMsg, m.keys.Back):
+			m.action = noCategoryAction
+			m.actionInput.Blur()
+			return m, nil
+		case key.Matches(keyMsg, m.keys.Confirm):
+			return m.applyAction()
+		}
+
+		var cmd tea.Cmd
+		m.actionInput, cmd = m.actionInput.Update(msg)
+		return m, cmd
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keys.Back):
+		return m, backToTableCmd()
+	case key.Matches(keyMsg, m.keys.Rename):
+		return m.startAction(renameCategoryAction)
+	case key.Matches(keyMsg, m.keys.Merge):
+		return m.startAction(mergeCategoryAction)
+	case key.Matches(keyMsg, m.keys.Delete):
+		return m.startAction(deleteCategoryAction)
+	case key.Matches(keyMsg, m.keys.Up):
+		m.table.MoveUp(1)
+		return m, nil
+	case key.Matches(keyMsg, m.keys.Down):
+		m.table.MoveDown(1)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+func (m categoryManagerModel) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(categoryManagerTitle) + "\n\n")
+	b.WriteString(tableStyle.Render(m.table.View()) + "\n\n")
+
+	if m.action != noCategoryAction {
+		b.WriteString(m.actionInput.View() + "\n\n")
+	} else if m.status != "" {
+		b.WriteString(m.status + "\n\n")
+	}
+
+	b.WriteString(m.helpModel.View(m.keys))
+
+	return b.String()
+}
+
+func (m categoryManagerModel) startAction(action categoryAction) (tea.Model, tea.Cmd) {
+	category, ok := m.selectedCategory()
+	if !ok {
+		return m, nil
+	}
+
+	m.action = action
+	m.actionInput.SetValue("")
+
+	switch action {
+	case renameCategoryAction:
+		m.actionInput.Prompt = fmt.Sprintf("Rename %q to: ", category)
+		m.actionInput.SetValue(category)
+	case mergeCategoryAction:
+		m.actionInput.Prompt = fmt.Sprintf("Merge %q into: ", category)
+	case deleteCategoryAction:
+		m.actionInput.Prompt = fmt.Sprintf("Delete %q, move its expenses to: ", category)
+		m.actionInput.Placeholder = "-"
+	}
+
+	m.actionInput.SetSuggestions(lo.Without(m.categoryNames(), category))
+
+	return m, m.actionInput.Focus()
+}
+
+func (m categoryManagerModel) applyAction() (tea.Model, tea.Cmd) {
+	category, ok := m.selectedCategory()
+	if !ok {
+		return m, nil
+	}
+
+	value := strings.TrimSpace(m.actionInput.Value())
+
+	var affected int
+	var err error
+
+	switch m.action {
+	case renameCategoryAction:
+		affected, err = expense.RenameCategory(category, value)
+	case mergeCategoryAction:
+		affected, err = expense.MergeCategories([]string{category}, value)
+	case deleteCategoryAction:
+		if value == "" {
+			value = "-"
+		}
+
+		affected, err = expense.DeleteCategory(category, value)
+	}
+
+	if err != nil {
+		return m, errorCmd(err, goToCategoriesCmd())
+	}
+
+	m.action = noCategoryAction
+	m.actionInput.Blur()
+	m.actionInput.Placeholder = ""
+	m.status = fmt.Sprintf("Moved %d expenses from %q to %q", affected, category, value)
+
+	newModel, err := m.reload()
+	if err != nil {
+		return m, errorCmd(err, backToTableCmd())
+	}
+
+	return newModel, nil
+}
+
+func (m categoryManagerModel) reload() (categoryManagerModel, error) {
+	stats, err := expense.GetCategoriesStats()
+	if err != nil {
+		return m, fmt.Errorf("Error getting categories: %w", err)
+	}
+
+	m.stats = stats
+	m.table.SetRows(lo.Map(stats, func(s expense.CategoryStats, _ int) table.Row {
+		levels := domain.CategoryLevels(s.Name)
+		name := s.Name
+
+		if len(levels) > 0 {
+			name = strings.Repeat("  ", s.Depth-1) + levels[len(levels)-1]
+		}
+
+		return table.Row{
+			name,
+			strconv.Itoa(s.Count),
+			fmt.Sprintf("%.2f", s.Total),
+		}
+	}))
+
+	return m, nil
+}
+
+func (m categoryManagerModel) selectedCategory() (string, bool) {
+	cursor := m.table.Cursor()
+
+	if cursor < 0 || cursor >= len(m.stats) {
+		return "", false
+	}
+
+	return m.stats[cursor].Name, true
+}
+
+func (m categoryManagerModel) categoryNames() []string {
+	return lo.Map(m.stats, func(s expense.CategoryStats, _ int) string {
+		return s.Name
+	})
+}
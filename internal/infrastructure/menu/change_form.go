@@ -1,6 +1,7 @@
 package menu
 
 import (
+	"cmp"
 	"fmt"
 	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
 	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
@@ -10,6 +11,8 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/samber/lo"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -27,22 +30,91 @@ var (
 
 const changeFormTitle = "Expense Change Form"
 
+const (
+	// splitInputsStart is the index of the first input of the split lines, each line has splitLineInputs inputs:
+	// category, amount and note.
+	splitInputsStart = 9
+	splitLineInputs  = 3
+)
+
 type changeFormModel struct {
 	focusIndex int
 	inputs     []textinput.Model
 	editingId  *int
 
+	//dates
+	dateFormat expense.DateFormat
+	location   *time.Location
+	picker     *datePicker
+
+	//suggestions
+	knownTags    []string
+	knownMembers []string
+	suggestions  expense.Suggestions
+	classifier   *expense.Classifier
+	prediction   *expense.CategoryPrediction
+
 	//help
 	helpModel      help.Model
-	navigationKeys NavigationKeyMap
+	navigationKeys FormKeyMap
 }
 
 func (m changeFormModel) Init() tea.Cmd { return nil }
 
 func (m changeFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case datePickedMsg:
+		m.picker = nil
+
+		if !msg.date.IsZero() {
+			value := msg.date.Format(m.dateFormat.Layout())
+
+			// keep the time of day that was typed, the picker only chooses the day
+			if date, err := expense.ParseDateTime(m.inputs[3].Value(), m.dateFormat); err == nil && !domain.IsDateOnly(date) {
+				value += date.In(m.location).Format(" 15:04")
+			}
+
+			m.inputs[3].SetValue(value)
+			m.inputs[3].CursorEnd()
+		}
+
+		return m, nil
 	case tea.KeyMsg:
+		if m.picker != nil {
+			picker, cmd := m.picker.Update(msg)
+			m.picker = &picker
+			return m, cmd
+		}
+
 		switch {
+		case key.Matches(msg, m.navigationKeys.AddSplit):
+			return m.addSplitLine()
+		case key.Matches(msg, m.navigationKeys.RemoveSplit):
+			return m.removeSplitLine()
+		case m.focusIndex == 3 && key.Matches(msg, m.navigationKeys.Calendar):
+			date, err := expense.ParseDateTime(m.inputs[3].Value(), m.dateFormat)
+			if err != nil {
+				date = time.Now()
+			}
+
+			date = date.In(m.location)
+
+			picker := newDatePicker(date)
+			m.picker = &picker
+			return m, nil
+		case m.canAcceptSuggestion() && key.Matches(msg, m.inputs[m.focusIndex].KeyMap.AcceptSuggestion):
+			// let the focused input complete its suggestion instead of moving the focus
+			newModel, cmd := m.updateInputs(msg)
+
+			if newModel.focusIndex == 0 {
+				newModel = newModel.fillFromDescription()
+			}
+
+			return newModel, cmd
+		case m.canAcceptPrediction() && key.Matches(msg, m.inputs[m.focusIndex].KeyMap.AcceptSuggestion):
+			m.inputs[1].SetValue(m.prediction.Category)
+			m.inputs[1].CursorEnd()
+			return m, nil
 		case key.Matches(msg, constants.Keymap.Enter, constants.Keymap.Up, constants.Keymap.Down):
 			curInd := m.focusIndex
 
@@ -78,18 +150,28 @@ func (m changeFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						return m, errorCmd(err, goToAddCmd())
 					}
 
-					date, err := time.Parse("2006-01-02", m.inputs[3].Value())
+					date, err := expense.ParseDateTime(m.inputs[3].Value(), m.dateFormat)
 					if err != nil {
 						return m, errorCmd(err, goToAddCmd())
 					}
 
+					tags := domain.ParseTags(m.inputs[4].Value())
+					account := m.inputs[5].Value()
+
+					splits, err := m.getSplits()
+					if err != nil {
+						return m, errorCmd(err, goToAddCmd())
+					}
+
+					sharing := m.getSharing()
+
 					if m.editingId == nil {
-						_, err = expense.AddExpense(description, category, amount, date)
+						_, err = expense.AddExpense(description, category, amount, date, tags, account, splits, sharing)
 						if err != nil {
 							return m, errorCmd(err, goToAddCmd())
 						}
 					} else {
-						_, err = expense.UpdateExpense(*m.editingId, description, category, amount, date)
+						_, err = expense.UpdateExpense(*m.editingId, description, category, amount, date, tags, account, splits, sharing)
 						if err != nil {
 							return m, errorCmd(err, goToAddCmd())
 						}
@@ -113,23 +195,7 @@ func (m changeFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.focusIndex = len(m.inputs)
 			}
 
-			cmds := make([]tea.Cmd, len(m.inputs))
-
-			for i := 0; i < len(m.inputs); i++ {
-				if i == m.focusIndex {
-					// Set focused state
-					cmds[i] = m.inputs[i].Focus()
-					m.inputs[i].PromptStyle = focusedStyle
-					m.inputs[i].TextStyle = focusedStyle
-				} else {
-					// Remove focused state
-					m.inputs[i].Blur()
-					m.inputs[i].PromptStyle = blurredStyle
-					m.inputs[i].TextStyle = blurredStyle
-				}
-			}
-
-			return m, tea.Batch(cmds...)
+			return m.focusInputs()
 		case key.Matches(msg, constants.Keymap.Back):
 			return m, backToTableCmd()
 		}
@@ -143,18 +209,61 @@ func (m changeFormModel) View() string {
 	var b strings.Builder
 	b.WriteString(titleStyle.Render(changeFormTitle) + "\n\n")
 
-	for i := range m.inputs {
+	for i := range m.inputs[:splitInputsStart] {
 		b.WriteString(m.inputs[i].View())
 
-		if i < len(m.inputs)-1 {
+		if i == 1 && m.splitLines() > 0 {
+			b.WriteString(blurredStyle.Render("follows the largest split line"))
+		}
+
+		if i == 8 {
+			b.WriteString(blurredStyle.Render(m.getOwedPreview()))
+		}
+
+		if i == 3 {
+			if date, err := expense.ParseDateTime(m.inputs[3].Value(), m.dateFormat); err == nil {
+				if domain.IsDateOnly(date) {
+					b.WriteString(blurredStyle.Render(date.Format("Mon, 2 Jan 2006")))
+				} else {
+					b.WriteString(blurredStyle.Render(date.In(m.location).Format("Mon, 2 Jan 2006 15:04 MST")))
+				}
+			}
+
+			if m.picker != nil {
+				b.WriteString("\n" + m.picker.View() + "\n" + m.helpModel.View(m.picker.keys))
+			}
+		}
+
+		if i < splitInputsStart-1 {
+			b.WriteRune('\n')
+		}
+	}
+
+	if lines := m.splitLines(); lines > 0 {
+		b.WriteString("\n\nSplit lines (category, amount, note):")
+
+		for line := 0; line < lines; line++ {
 			b.WriteRune('\n')
+
+			for _, input := range m.inputs[splitInputsStart+line*splitLineInputs:][:splitLineInputs] {
+				b.WriteString(input.View() + " ")
+			}
 		}
+
+		assigned := m.getSplitTotal()
+		total, _ := strconv.ParseFloat(m.inputs[2].Value(), 64)
+		fmt.Fprintf(&b, "\n\nAssigned %.2f of %.2f, %.2f left", assigned, total, total-assigned)
 	}
 
 	button := &blurredButton
 	if m.focusIndex == len(m.inputs) {
 		button = &focusedButton
 	}
+	if m.prediction != nil && m.inputs[1].Value() == "" {
+		fmt.Fprintf(&b, "\n\nSuggested category: %s (%.0f%%), tab on the category to use it",
+			m.prediction.Category, m.prediction.Confidence*100)
+	}
+
 	fmt.Fprintf(&b, "\n\n%s\n\n", *button)
 	b.WriteString(m.helpModel.View(m.navigationKeys))
 
@@ -168,14 +277,232 @@ func (m changeFormModel) updateInputs(msg tea.Msg) (changeFormModel, tea.Cmd) {
 		m.inputs[i], cmds[i] = m.inputs[i].Update(msg)
 	}
 
+	m.inputs[4].SetSuggestions(getTagSuggestions(m.inputs[4].Value(), m.knownTags))
+	m.inputs[8].SetSuggestions(getParticipantSuggestions(m.inputs[8].Value(), m.knownMembers))
+	m.prediction = nil
+
+	if prediction, ok := m.classifier.Predict(m.inputs[0].Value()); ok {
+		m.prediction = &prediction
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// focusInputs focuses the input at focusIndex and blurs the others.
+func (m changeFormModel) focusInputs() (changeFormModel, tea.Cmd) {
+	cmds := make([]tea.Cmd, len(m.inputs))
+
+	for i := 0; i < len(m.inputs); i++ {
+		if i == m.focusIndex {
+			// Set focused state
+			cmds[i] = m.inputs[i].Focus()
+			m.inputs[i].PromptStyle = focusedStyle
+			m.inputs[i].TextStyle = focusedStyle
+		} else {
+			// Remove focused state
+			m.inputs[i].Blur()
+			m.inputs[i].PromptStyle = blurredStyle
+			m.inputs[i].TextStyle = blurredStyle
+		}
+	}
+
 	return m, tea.Batch(cmds...)
 }
 
+func (m changeFormModel) splitLines() int {
+	return (len(m.inputs) - splitInputsStart) / splitLineInputs
+}
+
+// addSplitLine appends a split line taking what is left of the amount. The first line starts with the
+// category typed so far.
+func (m changeFormModel) addSplitLine() (tea.Model, tea.Cmd) {
+	category := ""
+	if m.splitLines() == 0 && !domain.IsUncategorized(m.inputs[1].Value()) {
+		category = m.inputs[1].Value()
+	}
+
+	total, _ := strconv.ParseFloat(m.inputs[2].Value(), 64)
+	left := max(total-m.getSplitTotal(), 0)
+
+	m.inputs = append(slices.Clone(m.inputs), newSplitInputs(m.suggestions.Categories, domain.Split{Category: category, Amount: left})...)
+	m.focusIndex = len(m.inputs) - splitLineInputs
+
+	return m.focusInputs()
+}
+
+// removeSplitLine removes the focused split line, or the last one when the focus is elsewhere.
+func (m changeFormModel) removeSplitLine() (tea.Model, tea.Cmd) {
+	lines := m.splitLines()
+	if lines == 0 {
+		return m, nil
+	}
+
+	line := lines - 1
+	if m.focusIndex >= splitInputsStart && m.focusIndex < len(m.inputs) {
+		line = (m.focusIndex - splitInputsStart) / splitLineInputs
+	}
+
+	start := splitInputsStart + line*splitLineInputs
+	m.inputs = slices.Delete(slices.Clone(m.inputs), start, start+splitLineInputs)
+
+	if m.focusIndex >= start+splitLineInputs {
+		m.focusIndex -= splitLineInputs
+	} else if m.focusIndex >= start {
+		m.focusIndex = start - 1
+	}
+
+	return m.focusInputs()
+}
+
+// getSharing reads who paid the expense and how it is divided, nil when it is not shared.
+func (m changeFormModel) getSharing() *domain.Sharing {
+	paidBy, participants := m.inputs[6].Value(), domain.ParseParticipants(m.inputs[8].Value())
+
+	if domain.NormalizeMember(paidBy) == "" && len(participants) == 0 {
+		return nil
+	}
+
+	return &domain.Sharing{
+		PaidBy:       paidBy,
+		Method:       domain.ShareMethod(strings.TrimSpace(m.inputs[7].Value())),
+		Participants: participants,
+	}
+}
+
+// getOwedPreview tells what each participant owes once the sharing can be divided.
+func (m changeFormModel) getOwedPreview() string {
+	sharing := m.getSharing()
+	amount, err := strconv.ParseFloat(m.inputs[2].Value(), 64)
+
+	if sharing == nil || err != nil {
+		return ""
+	}
+
+	sharing.Method = cmp.Or(sharing.Method, domain.EqualShare)
+
+	if sharing.Validate(amount) != nil {
+		return ""
+	}
+
+	owed := sharing.Owed(amount)
+	parts := make([]string, len(owed))
+
+	for i, p := range sharing.Participants {
+		parts[i] = fmt.Sprintf("%s owes %.2f", p.Name, owed[i])
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// getSplits reads the split lines of the form, none when the expense is not split.
+func (m changeFormModel) getSplits() ([]domain.Split, error) {
+	splits := make([]domain.Split, 0, m.splitLines())
+
+	for line := 0; line < m.splitLines(); line++ {
+		inputs := m.inputs[splitInputsStart+line*splitLineInputs:]
+
+		amount, err := strconv.ParseFloat(inputs[1].Value(), 64)
+		if err != nil {
+			return nil, err
+		}
+
+		splits = append(splits, domain.Split{Category: inputs[0].Value(), Amount: amount, Note: inputs[2].Value()})
+	}
+
+	return splits, nil
+}
+
+// getSplitTotal sums the amounts of the split lines, skipping those that are not numbers yet.
+func (m changeFormModel) getSplitTotal() float64 {
+	total := 0.0
+
+	for line := 0; line < m.splitLines(); line++ {
+		if amount, err := strconv.ParseFloat(m.inputs[splitInputsStart+line*splitLineInputs+1].Value(), 64); err == nil {
+			total += amount
+		}
+	}
+
+	return total
+}
+
+// canAcceptPrediction reports whether the empty category input can take the predicted category.
+func (m changeFormModel) canAcceptPrediction() bool {
+	return m.focusIndex == 1 && m.prediction != nil && m.inputs[1].Value() == ""
+}
+
+// fillFromDescription fills in the usual category and amount of a known description,
+// keeping whatever was already typed into those inputs.
+func (m changeFormModel) fillFromDescription() changeFormModel {
+	suggestion, ok := m.suggestions.FindDescription(m.inputs[0].Value())
+	if !ok {
+		return m
+	}
+
+	if m.inputs[1].Value() == "" {
+		m.inputs[1].SetValue(suggestion.Category)
+	}
+
+	if amount, err := strconv.ParseFloat(m.inputs[2].Value(), 64); err != nil || amount == 0 {
+		m.inputs[2].SetValue(fmt.Sprintf("%.2f", suggestion.Amount))
+	}
+
+	return m
+}
+
+func (m changeFormModel) canAcceptSuggestion() bool {
+	return canAcceptSuggestion(m.inputs, m.focusIndex)
+}
+
+// canAcceptSuggestion reports whether the focused input has a suggestion longer than what was typed.
+func canAcceptSuggestion(inputs []textinput.Model, focusIndex int) bool {
+	if focusIndex >= len(inputs) || !inputs[focusIndex].ShowSuggestions {
+		return false
+	}
+
+	input := inputs[focusIndex]
+	suggestion := input.CurrentSuggestion()
+
+	return len([]rune(suggestion)) > len([]rune(input.Value()))
+}
+
 func newAdditionModel() (tea.Model, error) {
+	knownTags, err := expense.GetAllTags()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting tags: %w", err)
+	}
+
+	suggestions, err := expense.GetSuggestions()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting suggestions: %w", err)
+	}
+
+	classifier, err := expense.TrainClassifier()
+	if err != nil {
+		return nil, fmt.Errorf("Error learning categories: %w", err)
+	}
+
+	accounts, err := expense.GetAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting accounts: %w", err)
+	}
+
+	members, err := expense.GetMemberBalances()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting members: %w", err)
+	}
+
+	dateFormat := getDateFormat()
+
 	m := changeFormModel{
-		inputs:         make([]textinput.Model, 4),
+		inputs:         make([]textinput.Model, splitInputsStart),
+		dateFormat:     dateFormat,
+		location:       getLocation(),
+		knownTags:      knownTags,
+		knownMembers:   getMemberNames(members),
+		suggestions:    suggestions,
+		classifier:     classifier,
 		helpModel:      help.New(),
-		navigationKeys: getNavigationKeymap(),
+		navigationKeys: getFormKeymap(),
 	}
 
 	var t textinput.Model
@@ -189,19 +516,48 @@ func newAdditionModel() (tea.Model, error) {
 		switch i {
 		case 0:
 			t.Placeholder = "Description"
+			t.ShowSuggestions = true
+			t.SetSuggestions(lo.Map(suggestions.Descriptions, func(d expense.DescriptionSuggestion, _ int) string {
+				return d.Description
+			}))
 			t.Focus()
 			t.PromptStyle = focusedStyle
 			t.TextStyle = focusedStyle
 		case 1:
 			t.Placeholder = "Category"
+			t.ShowSuggestions = true
+			t.SetSuggestions(suggestions.Categories)
 		case 2:
 			t.Placeholder = "Amount"
 			t.SetValue("0")
 			t.Validate = validateAmount
 		case 3:
-			t.Placeholder = "YYYY-MM-DD"
-			t.Validate = validateDate
-			t.SetValue(time.Now().Format("2006-01-02"))
+			t.Placeholder = dateFormat.Hint()
+			t.Validate = validateDate(dateFormat)
+			t.Width = 36
+			t.SetValue(time.Now().In(m.location).Format(dateFormat.Layout()))
+		case 4:
+			t.Placeholder = "Tags, comma separated (e.g. work, reimbursable)"
+			t.ShowSuggestions = true
+		case 5:
+			t.Placeholder = "Account paid from (empty for none)"
+			t.ShowSuggestions = true
+			t.SetSuggestions(getAccountNames(accounts))
+			t.Validate = validateAccount(accounts, true)
+		case 6:
+			t.Placeholder = "Paid by (empty when the expense is not shared)"
+			t.ShowSuggestions = true
+			t.SetSuggestions(getMemberNames(members))
+		case 7:
+			t.Placeholder = "Share method: equal (default), shares or exact"
+			t.ShowSuggestions = true
+			t.SetSuggestions(lo.Map(domain.ShareMethods, func(method domain.ShareMethod, _ int) string {
+				return string(method)
+			}))
+			t.Validate = validateShareMethod
+		case 8:
+			t.Placeholder = "Participants, comma separated with their shares or amounts (e.g. alice 2, bob 1)"
+			t.ShowSuggestions = true
 		}
 
 		m.inputs[i] = t
@@ -211,38 +567,135 @@ func newAdditionModel() (tea.Model, error) {
 }
 
 func newChangeModel(existingExpense domain.Expense) (tea.Model, error) {
-	m, err := newAdditionModel()
+	m, err := newDraftModel(existingExpense)
 
 	if err != nil {
 		return nil, err
 	}
 
 	cModel, _ := m.(changeFormModel)
-	cModel.inputs[0].SetValue(existingExpense.Description)
-	cModel.inputs[1].SetValue(existingExpense.Category)
-	cModel.inputs[2].SetValue(fmt.Sprintf("%.2f", existingExpense.Amount))
-	cModel.inputs[3].SetValue(existingExpense.SpentAt.Format("2006-01-02"))
-
 	id := existingExpense.Id
 	cModel.editingId = &id
 
 	return cModel, nil
 }
 
-func validateDate(dateStr string) error {
-	if dateStr == "" {
-		return fmt.Errorf("date cannot be empty")
+// newDraftModel creates an addition form filled in with a draft expense, e.g. one from the quick-add bar.
+func newDraftModel(draft domain.Expense) (tea.Model, error) {
+	m, err := newAdditionModel()
+
+	if err != nil {
+		return nil, err
 	}
 
-	_, err := time.Parse("2006-01-02", dateStr)
+	cModel, _ := m.(changeFormModel)
+	cModel.inputs[0].SetValue(draft.Description)
+	cModel.inputs[1].SetValue(draft.Category)
+	cModel.inputs[2].SetValue(fmt.Sprintf("%.2f", draft.Amount))
+	cModel.inputs[3].SetValue(cModel.dateFormat.Format(draft.SpentAt, cModel.location))
+	cModel.inputs[4].SetValue(strings.Join(draft.Tags, ", "))
+	cModel.inputs[5].SetValue(draft.Account)
+
+	if draft.Sharing != nil {
+		cModel.inputs[6].SetValue(draft.Sharing.PaidBy)
+		cModel.inputs[7].SetValue(string(draft.Sharing.Method))
+		cModel.inputs[8].SetValue(domain.FormatParticipants(draft.Sharing.Participants))
+	}
 
-	if err != nil {
-		return fmt.Errorf("date should be in YYYY-MM-DD format")
+	for _, split := range draft.Splits {
+		cModel.inputs = append(cModel.inputs, newSplitInputs(cModel.suggestions.Categories, split)...)
+	}
+
+	return cModel, nil
+}
+
+// newSplitInputs creates the category, amount and note inputs of a split line.
+func newSplitInputs(categories []string, split domain.Split) []textinput.Model {
+	inputs := make([]textinput.Model, splitLineInputs)
+
+	for i := range inputs {
+		t := textinput.New()
+		t.CharLimit = 0
+		t.PromptStyle = blurredStyle
+		t.TextStyle = blurredStyle
+
+		switch i {
+		case 0:
+			t.Placeholder = "Category"
+			t.Width = 30
+			t.ShowSuggestions = true
+			t.SetSuggestions(categories)
+			t.SetValue(split.Category)
+		case 1:
+			t.Placeholder = "Amount"
+			t.Width = 10
+			t.Validate = validateAmount
+			t.SetValue(fmt.Sprintf("%.2f", split.Amount))
+		case 2:
+			t.Placeholder = "Note"
+			t.Width = 40
+			t.SetValue(split.Note)
+		}
+
+		inputs[i] = t
+	}
+
+	return inputs
+}
+
+// getTagSuggestions completes the last tag of a comma-separated list with known tags not used yet.
+func getTagSuggestions(value string, knownTags []string) []string {
+	prefix := ""
+
+	if i := strings.LastIndex(value, ","); i >= 0 {
+		last := strings.TrimLeft(value[i+1:], " ")
+		prefix = value[:len(value)-len(last)]
+	}
+
+	used := domain.ParseTags(prefix)
+
+	return lo.FilterMap(knownTags, func(tag string, _ int) (string, bool) {
+		return prefix + tag, !slices.Contains(used, tag)
+	})
+}
+
+// getParticipantSuggestions completes the last participant of a comma-separated list with known members
+// not taking part yet.
+func getParticipantSuggestions(value string, knownMembers []string) []string {
+	prefix := ""
+
+	if i := strings.LastIndex(value, ","); i >= 0 {
+		last := strings.TrimLeft(value[i+1:], " ")
+		prefix = value[:len(value)-len(last)]
+	}
+
+	used := domain.ParseParticipants(prefix)
+
+	return lo.FilterMap(knownMembers, func(member string, _ int) (string, bool) {
+		return prefix + member, !lo.ContainsBy(used, func(p domain.Participant) bool {
+			return domain.SameMember(p.Name, member)
+		})
+	})
+}
+
+func validateShareMethod(method string) error {
+	method = strings.TrimSpace(method)
+
+	if method != "" && !slices.Contains(domain.ShareMethods, domain.ShareMethod(method)) {
+		return fmt.Errorf("share method should be equal, shares or exact")
 	}
 
 	return nil
 }
 
+// validateDate returns a validator accepting the dates expense.ParseDateTime understands in the given format.
+func validateDate(format expense.DateFormat) textinput.ValidateFunc {
+	return func(dateStr string) error {
+		_, err := expense.ParseDateTime(dateStr, format)
+		return err
+	}
+}
+
 func validateAmount(amountStr string) error {
 	if amountStr == "" {
 		return fmt.Errorf("amount cannot be empty")
@@ -0,0 +1,247 @@
+package menu
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samber/lo"
+	"math"
+	"slices"
+	"strings"
+)
+
+const (
+	chartsTitle       = "Charts"
+	chartMonths       = 12
+	chartWidth        = 50
+	chartLabelWidth   = 20
+	stackedCategories = 5
+	otherCategory     = "Other"
+)
+
+type chartKind int
+
+const (
+	monthlyBarChart chartKind = iota
+	categorySparklines
+	stackedCategoryChart
+	chartKindsCount
+)
+
+var (
+	chartNames = map[chartKind]string{
+		monthlyBarChart:      "Monthly totals",
+		categorySparklines:   "Trend per category",
+		stackedCategoryChart: "Categories per month",
+	}
+
+	barEighths    = []rune(" ▏▎▍▌▋▊▉█")
+	sparkLevels   = []rune("▁▂▃▄▅▆▇█")
+	stackedFills  = []rune("█▓▒░#=")
+	stackedStyles = []lipgloss.Style{
+		lipgloss.NewStyle().Foreground(lipgloss.Color("205")),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("39")),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("214")),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("42")),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("141")),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("245")),
+	}
+)
+
+type chartsModel struct {
+	series     []expense.MonthlyTotal
+	categories []string
+	kind       chartKind
+
+	//help
+	helpModel help.Model
+	keys      ChartsKeyMap
+}
+
+func newChartsModel() (tea.Model, error) {
+	series, err := expense.GetMonthlySeries(chartMonths)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting monthly totals: %w", err)
+	}
+
+	return chartsModel{
+		series:     series,
+		categories: categoriesBySpending(series),
+		helpModel:  help.New(),
+		keys:       getChartsKeymap(),
+	}, nil
+}
+
+func (m chartsModel) Init() tea.Cmd { return nil }
+
+func (m chartsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keys.Back):
+		return m, backToTableCmd()
+	case key.Matches(keyMsg, m.keys.Next):
+		m.kind = (m.kind + 1) % chartKindsCount
+	case key.Matches(keyMsg, m.keys.Prev):
+		m.kind = (m.kind + chartKindsCount - 1) % chartKindsCount
+	}
+
+	return m, nil
+}
+
+func (m chartsModel) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf("%s: %s", chartsTitle, chartNames[m.kind])) + "\n\n")
+
+	var chart string
+
+	switch {
+	case lo.SumBy(m.series, func(t expense.MonthlyTotal) float64 { return t.Total }) == 0:
+		chart = fmt.Sprintf("No expenses in the last %d months", chartMonths)
+	case m.kind == monthlyBarChart:
+		chart = m.renderMonthlyBars()
+	case m.kind == categorySparklines:
+		chart = m.renderSparklines()
+	case m.kind == stackedCategoryChart:
+		chart = m.renderStackedBars()
+	}
+
+	b.WriteString(tableStyle.Render(chart) + "\n")
+	b.WriteString(m.helpModel.View(m.keys))
+
+	return b.String()
+}
+
+func (m chartsModel) renderMonthlyBars() string {
+	highest := lo.Max(lo.Map(m.series, func(t expense.MonthlyTotal, _ int) float64 { return t.Total }))
+
+	lines := lo.Map(m.series, func(t expense.MonthlyTotal, _ int) string {
+		return fmt.Sprintf("%s %s │%s %10.2f", t.Month.String()[:3], yearLabel(t.Year), renderBar(t.Total, highest, chartWidth), t.Total)
+	})
+
+	return strings.Join(lines, "\n")
+}
+
+func (m chartsModel) renderSparklines() string {
+	first, last := m.series[0], m.series[len(m.series)-1]
+	header := fmt.Sprintf("%-*s %-*s %10s", chartLabelWidth, "Category", len(m.series), "Trend", "Total")
+	footer := fmt.Sprintf("%s %s – %s %s, each trend scaled to its own highest month",
+		first.Month.String()[:3], yearLabel(first.Year), last.Month.String()[:3], yearLabel(last.Year))
+
+	lines := lo.Map(m.categories, func(category string, _ int) string {
+		values := lo.Map(m.series, func(t expense.MonthlyTotal, _ int) float64 { return t.ByCategory[category] })
+
+		return fmt.Sprintf("%-*s %s %10.2f", chartLabelWidth, truncateLabel(category, chartLabelWidth),
+			renderSparkline(values), lo.Sum(values))
+	})
+
+	return header + "\n" + strings.Join(lines, "\n") + "\n\n" + footer
+}
+
+func (m chartsModel) renderStackedBars() string {
+	shown := m.categories[:min(stackedCategories, len(m.categories))]
+	highest := lo.Max(lo.Map(m.series, func(t expense.MonthlyTotal, _ int) float64 { return t.Total }))
+
+	lines := lo.Map(m.series, func(t expense.MonthlyTotal, _ int) string {
+		parts := lo.Map(shown, func(category string, _ int) float64 { return t.ByCategory[category] })
+		parts = append(parts, t.Total-lo.Sum(parts))
+
+		return fmt.Sprintf("%s %s │%s %10.2f", t.Month.String()[:3], yearLabel(t.Year), renderStackedBar(parts, highest, chartWidth), t.Total)
+	})
+
+	legend := lo.Map(append(slices.Clone(shown), otherCategory), func(category string, i int) string {
+		return stackedStyles[i].Render(string(stackedFills[i])) + " " + category
+	})
+
+	return strings.Join(lines, "\n") + "\n\n" + strings.Join(legend, "   ")
+}
+
+// categoriesBySpending lists the categories of a series from the highest to the lowest total.
+func categoriesBySpending(series []expense.MonthlyTotal) []string {
+	totals := make(map[string]float64)
+
+	for _, t := range series {
+		for category, amount := range t.ByCategory {
+			totals[category] += amount
+		}
+	}
+
+	categories := lo.Keys(totals)
+	slices.SortFunc(categories, func(a, b string) int {
+		return cmp.Or(cmp.Compare(totals[b], totals[a]), cmp.Compare(a, b))
+	})
+
+	return categories
+}
+
+// renderBar draws value as a horizontal bar scaled so that highest fills width, in eighths of a character.
+func renderBar(value float64, highest float64, width int) string {
+	if highest <= 0 || value <= 0 {
+		return strings.Repeat(" ", width)
+	}
+
+	eighths := int(math.Round(value / highest * float64(width*8)))
+	bar := strings.Repeat(string(barEighths[8]), eighths/8)
+
+	if eighths%8 > 0 {
+		bar += string(barEighths[eighths%8])
+	}
+
+	return bar + strings.Repeat(" ", width-len([]rune(bar)))
+}
+
+// renderSparkline draws one character per value, scaled to the highest of the values.
+func renderSparkline(values []float64) string {
+	highest := lo.Max(values)
+
+	return string(lo.Map(values, func(value float64, _ int) rune {
+		if highest <= 0 || value <= 0 {
+			return ' '
+		}
+
+		level := int(math.Ceil(value/highest*float64(len(sparkLevels)))) - 1
+		return sparkLevels[max(0, min(level, len(sparkLevels)-1))]
+	}))
+}
+
+// renderStackedBar draws the parts one after another with their own fill, scaled so that highest fills width.
+func renderStackedBar(parts []float64, highest float64, width int) string {
+	var b strings.Builder
+	drawn, sum := 0, 0.0
+
+	for i, part := range parts {
+		if highest <= 0 || part <= 0 {
+			continue
+		}
+
+		// round the running sum, so that the segments always add up to the bar of the total
+		sum += part
+		end := int(math.Round(sum / highest * float64(width)))
+
+		b.WriteString(stackedStyles[i].Render(strings.Repeat(string(stackedFills[i]), end-drawn)))
+		drawn = end
+	}
+
+	return b.String() + strings.Repeat(" ", width-drawn)
+}
+
+func yearLabel(year int) string {
+	return fmt.Sprintf("'%02d", year%100)
+}
+
+func truncateLabel(label string, width int) string {
+	runes := []rune(label)
+
+	if len(runes) <= width {
+		return label
+	}
+
+	return string(runes[:width-1]) + "…"
+}
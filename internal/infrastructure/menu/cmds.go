@@ -1,9 +1,15 @@
 package menu
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	tea "github.com/charmbracelet/bubbletea"
+	"time"
+)
 
 type backMsg struct{}
-type addMsg struct{}
+type addMsg struct {
+	draft *domain.Expense
+}
 type errorMsg struct {
 	error
 	sourceBack tea.Cmd
@@ -12,6 +18,37 @@ type editMsg struct {
 	id int
 }
 type summaryMsg struct{}
+type calendarMsg struct{}
+type chartsMsg struct{}
+type comparisonMsg struct{}
+type backupsMsg struct{}
+type unlockedMsg struct{}
+type ledgersMsg struct{}
+type switchLedgerMsg struct {
+	name     string
+	previous string
+}
+type tableOnDateMsg struct {
+	date time.Time
+}
+type quickAddMsg struct{}
+type categoriesMsg struct{}
+type rulesMsg struct{}
+type ruleFormMsg struct {
+	rule *domain.Rule
+}
+type accountsMsg struct{}
+type accountFormMsg struct {
+	account *domain.Account
+}
+type membersMsg struct{}
+type transferFormMsg struct {
+	transfer   *domain.Expense
+	sourceBack tea.Cmd
+}
+type bulkEditMsg struct {
+	ids []int
+}
 type infoMsg struct {
 	message    string
 	sourceBack tea.Cmd
@@ -29,6 +66,19 @@ func goToAddCmd() tea.Cmd {
 	}
 }
 
+// goToAddDraftCmd opens the addition form filled in with a draft expense.
+func goToAddDraftCmd(draft domain.Expense) tea.Cmd {
+	return func() tea.Msg {
+		return addMsg{&draft}
+	}
+}
+
+func goToQuickAddCmd() tea.Cmd {
+	return func() tea.Msg {
+		return quickAddMsg{}
+	}
+}
+
 func goToEditCmd(id int) tea.Cmd {
 	return func() tea.Msg {
 		return editMsg{id}
@@ -41,6 +91,110 @@ func goToSummaryCmd() tea.Cmd {
 	}
 }
 
+func goToCalendarCmd() tea.Cmd {
+	return func() tea.Msg {
+		return calendarMsg{}
+	}
+}
+
+func goToChartsCmd() tea.Cmd {
+	return func() tea.Msg {
+		return chartsMsg{}
+	}
+}
+
+func goToComparisonCmd() tea.Cmd {
+	return func() tea.Msg {
+		return comparisonMsg{}
+	}
+}
+
+// unlockedCmd opens the table once the encrypted expenses were unlocked.
+func unlockedCmd() tea.Cmd {
+	return func() tea.Msg {
+		return unlockedMsg{}
+	}
+}
+
+func goToLedgersCmd() tea.Cmd {
+	return func() tea.Msg {
+		return ledgersMsg{}
+	}
+}
+
+// switchLedgerCmd opens the table of another ledger, going back to the previous one when its passphrase
+// is not given.
+func switchLedgerCmd(name string, previous string) tea.Cmd {
+	return func() tea.Msg {
+		return switchLedgerMsg{name, previous}
+	}
+}
+
+func goToBackupsCmd() tea.Cmd {
+	return func() tea.Msg {
+		return backupsMsg{}
+	}
+}
+
+// goToTableOnDateCmd opens the table showing only the expenses of a single day.
+func goToTableOnDateCmd(date time.Time) tea.Cmd {
+	return func() tea.Msg {
+		return tableOnDateMsg{date}
+	}
+}
+
+func goToCategoriesCmd() tea.Cmd {
+	return func() tea.Msg {
+		return categoriesMsg{}
+	}
+}
+
+func goToRulesCmd() tea.Cmd {
+	return func() tea.Msg {
+		return rulesMsg{}
+	}
+}
+
+// goToRuleFormCmd opens the rule form, editing the given rule or adding a new one when it is nil.
+func goToRuleFormCmd(rule *domain.Rule) tea.Cmd {
+	return func() tea.Msg {
+		return ruleFormMsg{rule}
+	}
+}
+
+func goToAccountsCmd() tea.Cmd {
+	return func() tea.Msg {
+		return accountsMsg{}
+	}
+}
+
+// goToAccountFormCmd opens the account form, editing the given account or adding a new one when it is nil.
+func goToAccountFormCmd(account *domain.Account) tea.Cmd {
+	return func() tea.Msg {
+		return accountFormMsg{account}
+	}
+}
+
+// goToTransferFormCmd opens the transfer form, editing the given transfer or adding a new one when it is nil.
+// sourceBack leaves the form, whether it was saved or not.
+func goToTransferFormCmd(transfer *domain.Expense, sourceBack tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		return transferFormMsg{transfer, sourceBack}
+	}
+}
+
+func goToMembersCmd() tea.Cmd {
+	return func() tea.Msg {
+		return membersMsg{}
+	}
+}
+
+func goToBulkEditCmd(ids []int) tea.Cmd {
+	return func() tea.Msg {
+		return bulkEditMsg{ids}
+	}
+}
+
 func errorCmd(err error, sourceBack tea.Cmd) tea.Cmd {
 	return func() tea.Msg {
 		return errorMsg{err, sourceBack}
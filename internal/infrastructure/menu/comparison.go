@@ -0,0 +1,143 @@
+package menu
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samber/lo"
+	"strings"
+	"time"
+)
+
+const (
+	comparisonTitle = "Comparison"
+	topMovers       = 3
+)
+
+var (
+	increaseStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("203"))
+	decreaseStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+)
+
+type comparisonModel struct {
+	month  time.Time
+	report expense.ComparisonReport
+
+	//help
+	helpModel help.Model
+	keys      ComparisonKeyMap
+}
+
+func newComparisonModel() (tea.Model, error) {
+	now := time.Now().In(getLocation())
+
+	m := comparisonModel{
+		month:     time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC),
+		helpModel: help.New(),
+		keys:      getComparisonKeymap(),
+	}
+
+	return m.load()
+}
+
+func (m comparisonModel) load() (comparisonModel, error) {
+	report, err := expense.GetMonthlyComparison(m.month.Year(), m.month.Month(), 1)
+	if err != nil {
+		return m, fmt.Errorf("Error comparing months: %w", err)
+	}
+
+	m.report = report
+	return m, nil
+}
+
+func (m comparisonModel) Init() tea.Cmd { return nil }
+
+func (m comparisonModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keys.Back):
+		return m, backToTableCmd()
+	case key.Matches(keyMsg, m.keys.PrevMonth):
+		m.month = m.month.AddDate(0, -1, 0)
+	case key.Matches(keyMsg, m.keys.NextMonth):
+		m.month = m.month.AddDate(0, 1, 0)
+	default:
+		return m, nil
+	}
+
+	m, err := m.load()
+	if err != nil {
+		return m, errorCmd(err, backToTableCmd())
+	}
+
+	return m, nil
+}
+
+func (m comparisonModel) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf("%s: %s", comparisonTitle, m.month.Format("January 2006"))) + "\n\n")
+
+	movers := lo.Map(m.report.BiggestMovers(topMovers), func(c expense.Comparison, _ int) string { return c.Name })
+
+	lines := []string{
+		fmt.Sprintf("%-20s %10s %10s %20s %10s %20s", "Category", "This month",
+			m.month.AddDate(0, -1, 0).Format("Jan 2006"), "Change", m.month.AddDate(-1, 0, 0).Format("Jan 2006"), "Change"),
+	}
+
+	for _, c := range append(m.report.Categories, m.report.Total) {
+		percent, ok := c.PreviousChangePercent()
+		previousChange := fmt.Sprintf("%20s", formatChange(c.PreviousChange(), percent, ok))
+
+		percent, ok = c.LastYearChangePercent()
+		lastYearChange := fmt.Sprintf("%20s", formatChange(c.LastYearChange(), percent, ok))
+
+		name := truncateLabel(c.Name, chartLabelWidth)
+
+		if lo.Contains(movers, c.Name) {
+			style := increaseStyle
+			if c.PreviousChange() < 0 {
+				style = decreaseStyle
+			}
+
+			name = style.Render(fmt.Sprintf("%-20s", name))
+			previousChange = style.Render(previousChange)
+		} else {
+			name = fmt.Sprintf("%-20s", name)
+		}
+
+		if c.Name == m.report.Total.Name {
+			lines = append(lines, "")
+		}
+
+		lines = append(lines, fmt.Sprintf("%s %10.2f %10.2f %s %10.2f %s", name, c.Current, c.Previous, previousChange, c.LastYear, lastYearChange))
+	}
+
+	b.WriteString(tableStyle.Render(strings.Join(lines, "\n")) + "\n")
+
+	if len(movers) > 0 {
+		b.WriteString("Biggest movers: " + strings.Join(movers, ", ") + "\n")
+	}
+
+	b.WriteString(m.helpModel.View(m.keys))
+
+	return b.String()
+}
+
+// formatChange shows an absolute change with its percentage, or "new" when there was nothing to compare with.
+func formatChange(change float64, percent float64, ok bool) string {
+	switch {
+	case change == 0:
+		return "0.00"
+	case !ok:
+		return fmt.Sprintf("%+.2f (new)", change)
+	}
+
+	return fmt.Sprintf("%+.2f (%+.0f%%)", change, percent)
+}
@@ -0,0 +1,110 @@
+package menu
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"strings"
+	"time"
+)
+
+var (
+	pickedDayStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")).Bold(true)
+	todayStyle     = lipgloss.NewStyle().Underline(true)
+)
+
+// datePicker is a keyboard-driven month calendar used as a popup by the forms.
+type datePicker struct {
+	cursor time.Time
+	keys   DatePickerKeyMap
+}
+
+// datePickedMsg is sent when a day is chosen, or with a zero date when the picker is closed without one.
+type datePickedMsg struct {
+	date time.Time
+}
+
+func newDatePicker(date time.Time) datePicker {
+	return datePicker{
+		cursor: time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+		keys:   getDatePickerKeymap(),
+	}
+}
+
+func (p datePicker) Update(msg tea.KeyMsg) (datePicker, tea.Cmd) {
+	switch {
+	case key.Matches(msg, p.keys.PrevDay):
+		p.cursor = p.cursor.AddDate(0, 0, -1)
+	case key.Matches(msg, p.keys.NextDay):
+		p.cursor = p.cursor.AddDate(0, 0, 1)
+	case key.Matches(msg, p.keys.PrevWeek):
+		p.cursor = p.cursor.AddDate(0, 0, -7)
+	case key.Matches(msg, p.keys.NextWeek):
+		p.cursor = p.cursor.AddDate(0, 0, 7)
+	case key.Matches(msg, p.keys.PrevMonth):
+		p.cursor = p.cursor.AddDate(0, -1, 0)
+	case key.Matches(msg, p.keys.NextMonth):
+		p.cursor = p.cursor.AddDate(0, 1, 0)
+	case key.Matches(msg, p.keys.Pick):
+		date := p.cursor
+		return p, func() tea.Msg { return datePickedMsg{date} }
+	case key.Matches(msg, p.keys.Cancel):
+		return p, func() tea.Msg { return datePickedMsg{} }
+	}
+
+	return p, nil
+}
+
+func (p datePicker) View() string {
+	now := time.Now().In(getLocation())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	grid := renderMonthGrid(p.cursor, 2, func(day time.Time) string {
+		cell := fmt.Sprintf("%2d", day.Day())
+
+		switch {
+		case day.Equal(p.cursor):
+			return pickedDayStyle.Render(cell)
+		case day.Equal(today):
+			return todayStyle.Render(cell)
+		}
+
+		return cell
+	})
+
+	return tableStyle.Render(p.cursor.Format("January 2006") + "\n\n" + grid)
+}
+
+// renderMonthGrid lays out the days of the month containing month in weeks starting on Monday.
+// cell renders a single day and must keep to width columns.
+func renderMonthGrid(month time.Time, width int, cell func(day time.Time) string) string {
+	var b strings.Builder
+
+	for i, name := range []string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"} {
+		if i > 0 {
+			b.WriteRune(' ')
+		}
+
+		fmt.Fprintf(&b, "%-*s", width, name)
+	}
+
+	b.WriteRune('\n')
+
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(first.Weekday()) + 6) % 7
+
+	b.WriteString(strings.Repeat(strings.Repeat(" ", width+1), offset))
+
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		b.WriteString(cell(day))
+
+		if day.Weekday() == time.Sunday {
+			b.WriteRune('\n')
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+
+	return strings.TrimRight(b.String(), " \n")
+}
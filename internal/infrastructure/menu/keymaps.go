@@ -6,13 +6,34 @@ import (
 )
 
 type ActionKeyMap struct {
-	Delete key.Binding
-	Create key.Binding
-	Quit   key.Binding
-	Edit   key.Binding
-	GetSum key.Binding
-	Filter key.Binding
-	Export key.Binding
+	Delete   key.Binding
+	Create   key.Binding
+	QuickAdd key.Binding
+	Quit     key.Binding
+	Edit     key.Binding
+	GetSum   key.Binding
+	Filter   key.Binding
+	Export   key.Binding
+	Help     key.Binding
+
+	//screens
+	Categories key.Binding
+	Rules      key.Binding
+	Calendar   key.Binding
+	Charts     key.Binding
+	Comparison key.Binding
+	Backups    key.Binding
+	Ledgers    key.Binding
+	Accounts   key.Binding
+	Members    key.Binding
+
+	//selection
+	Select          key.Binding
+	SelectRangeUp   key.Binding
+	SelectRangeDown key.Binding
+	SelectAll       key.Binding
+	ClearSelection  key.Binding
+	BulkEdit        key.Binding
 }
 
 type NavigationKeyMap struct {
@@ -24,13 +45,302 @@ type NavigationKeyMap struct {
 
 // ShortHelp implements the ActionKeyMap interface.
 func (km ActionKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{km.Create, km.Delete, km.Edit, km.Filter, km.GetSum, km.Export, km.Quit}
+	return []key.Binding{km.Create, km.Delete, km.Edit, km.Filter, km.GetSum, km.Export, km.Help, km.Quit}
 }
 
 // FullHelp implements the ActionKeyMap interface.
 func (km ActionKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{km.Create, km.Delete, km.Edit, km.Filter, km.GetSum, km.Export, km.Quit},
+		{km.Create, km.QuickAdd, km.Delete, km.Edit, km.Filter, km.GetSum, km.Export},
+		{km.Select, km.SelectRangeUp, km.SelectRangeDown, km.SelectAll, km.ClearSelection, km.BulkEdit},
+		{km.Categories, km.Rules, km.Calendar, km.Charts, km.Comparison, km.Backups, km.Ledgers, km.Accounts, km.Members},
+		{km.Help, km.Quit},
+	}
+}
+
+type CategoryKeyMap struct {
+	Rename  key.Binding
+	Merge   key.Binding
+	Delete  key.Binding
+	Up      key.Binding
+	Down    key.Binding
+	Confirm key.Binding
+	Back    key.Binding
+}
+
+// ShortHelp implements the CategoryKeyMap interface.
+func (km CategoryKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.Rename, km.Merge, km.Delete, km.Up, km.Down, km.Back}
+}
+
+// FullHelp implements the CategoryKeyMap interface.
+func (km CategoryKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{km.Rename, km.Merge, km.Delete},
+		{km.Up, km.Down, km.Confirm, km.Back},
+	}
+}
+
+type RuleKeyMap struct {
+	Create  key.Binding
+	Edit    key.Binding
+	Delete  key.Binding
+	Apply   key.Binding
+	Up      key.Binding
+	Down    key.Binding
+	Confirm key.Binding
+	Back    key.Binding
+}
+
+// ShortHelp implements the RuleKeyMap interface.
+func (km RuleKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.Create, km.Edit, km.Delete, km.Apply, km.Up, km.Down, km.Back}
+}
+
+// FullHelp implements the RuleKeyMap interface.
+func (km RuleKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{km.Create, km.Edit, km.Delete, km.Apply},
+		{km.Up, km.Down, km.Confirm, km.Back},
+	}
+}
+
+type BackupKeyMap struct {
+	BackupNow key.Binding
+	Restore   key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	Confirm   key.Binding
+	Back      key.Binding
+}
+
+// ShortHelp implements the BackupKeyMap interface.
+func (km BackupKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.BackupNow, km.Restore, km.Up, km.Down, km.Back}
+}
+
+// FullHelp implements the BackupKeyMap interface.
+func (km BackupKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{km.BackupNow, km.Restore},
+		{km.Up, km.Down, km.Confirm, km.Back},
+	}
+}
+
+type UnlockKeyMap struct {
+	Unlock key.Binding
+	Back   key.Binding
+	Quit   key.Binding
+}
+
+// ShortHelp implements the UnlockKeyMap interface.
+func (km UnlockKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.Unlock, km.Back, km.Quit}
+}
+
+// FullHelp implements the UnlockKeyMap interface.
+func (km UnlockKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{km.Unlock, km.Back, km.Quit}}
+}
+
+type LedgerKeyMap struct {
+	Switch key.Binding
+	Create key.Binding
+	Up     key.Binding
+	Down   key.Binding
+	Save   key.Binding
+	Back   key.Binding
+}
+
+// ShortHelp implements the LedgerKeyMap interface.
+func (km LedgerKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.Switch, km.Create, km.Up, km.Down, km.Back}
+}
+
+// FullHelp implements the LedgerKeyMap interface.
+func (km LedgerKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{km.Switch, km.Create},
+		{km.Up, km.Down, km.Save, km.Back},
+	}
+}
+
+type AccountKeyMap struct {
+	Create   key.Binding
+	Edit     key.Binding
+	Delete   key.Binding
+	Transfer key.Binding
+	History  key.Binding
+	Up       key.Binding
+	Down     key.Binding
+	Back     key.Binding
+}
+
+// ShortHelp implements the AccountKeyMap interface.
+func (km AccountKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.History, km.Create, km.Edit, km.Delete, km.Transfer, km.Back}
+}
+
+// FullHelp implements the AccountKeyMap interface.
+func (km AccountKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{km.History, km.Create, km.Edit, km.Delete, km.Transfer},
+		{km.Up, km.Down, km.Back},
+	}
+}
+
+type MemberKeyMap struct {
+	Settle key.Binding
+	Up     key.Binding
+	Down   key.Binding
+	Back   key.Binding
+}
+
+// ShortHelp implements the MemberKeyMap interface.
+func (km MemberKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.Settle, km.Up, km.Down, km.Back}
+}
+
+// FullHelp implements the MemberKeyMap interface.
+func (km MemberKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{km.Settle, km.Up, km.Down, km.Back},
+	}
+}
+
+type QuickAddKeyMap struct {
+	Save key.Binding
+	Form key.Binding
+	Back key.Binding
+}
+
+// ShortHelp implements the QuickAddKeyMap interface.
+func (km QuickAddKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.Save, km.Form, km.Back}
+}
+
+// FullHelp implements the QuickAddKeyMap interface.
+func (km QuickAddKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{km.Save, km.Form, km.Back}}
+}
+
+type FormKeyMap struct {
+	NavigationKeyMap
+	Calendar    key.Binding
+	AddSplit    key.Binding
+	RemoveSplit key.Binding
+}
+
+// ShortHelp implements the FormKeyMap interface.
+func (km FormKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.Confirm, km.Up, km.Down, km.Calendar, km.AddSplit, km.RemoveSplit, km.Back}
+}
+
+// FullHelp implements the FormKeyMap interface.
+func (km FormKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{km.Confirm, km.Up, km.Down, km.Back},
+		{km.Calendar, km.AddSplit, km.RemoveSplit},
+	}
+}
+
+type DatePickerKeyMap struct {
+	PrevDay   key.Binding
+	NextDay   key.Binding
+	PrevWeek  key.Binding
+	NextWeek  key.Binding
+	PrevMonth key.Binding
+	NextMonth key.Binding
+	Pick      key.Binding
+	Cancel    key.Binding
+}
+
+// ShortHelp implements the DatePickerKeyMap interface.
+func (km DatePickerKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.PrevDay, km.NextDay, km.PrevWeek, km.NextWeek, km.PrevMonth, km.NextMonth, km.Pick, km.Cancel}
+}
+
+// FullHelp implements the DatePickerKeyMap interface.
+func (km DatePickerKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{km.PrevDay, km.NextDay, km.PrevWeek, km.NextWeek},
+		{km.PrevMonth, km.NextMonth, km.Pick, km.Cancel},
+	}
+}
+
+type CalendarKeyMap struct {
+	PrevDay   key.Binding
+	NextDay   key.Binding
+	PrevWeek  key.Binding
+	NextWeek  key.Binding
+	PrevMonth key.Binding
+	NextMonth key.Binding
+	Open      key.Binding
+	Create    key.Binding
+	Back      key.Binding
+}
+
+// ShortHelp implements the CalendarKeyMap interface.
+func (km CalendarKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.Open, km.Create, km.PrevMonth, km.NextMonth, km.Back}
+}
+
+// FullHelp implements the CalendarKeyMap interface.
+func (km CalendarKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{km.PrevDay, km.NextDay, km.PrevWeek, km.NextWeek},
+		{km.PrevMonth, km.NextMonth, km.Open, km.Create, km.Back},
+	}
+}
+
+type ChartsKeyMap struct {
+	Next key.Binding
+	Prev key.Binding
+	Back key.Binding
+}
+
+// ShortHelp implements the ChartsKeyMap interface.
+func (km ChartsKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.Next, km.Prev, km.Back}
+}
+
+// FullHelp implements the ChartsKeyMap interface.
+func (km ChartsKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{km.Next, km.Prev, km.Back}}
+}
+
+type ComparisonKeyMap struct {
+	PrevMonth key.Binding
+	NextMonth key.Binding
+	Back      key.Binding
+}
+
+// ShortHelp implements the ComparisonKeyMap interface.
+func (km ComparisonKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.PrevMonth, km.NextMonth, km.Back}
+}
+
+// FullHelp implements the ComparisonKeyMap interface.
+func (km ComparisonKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{km.PrevMonth, km.NextMonth, km.Back}}
+}
+
+type SummaryKeyMap struct {
+	NavigationKeyMap
+	RollUp    key.Binding
+	DrillDown key.Binding
+}
+
+// ShortHelp implements the SummaryKeyMap interface.
+func (km SummaryKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{km.Confirm, km.Up, km.Down, km.RollUp, km.DrillDown, km.Back}
+}
+
+// FullHelp implements the SummaryKeyMap interface.
+func (km SummaryKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{km.Confirm, km.Up, km.Down, km.Back},
+		{km.RollUp, km.DrillDown},
 	}
 }
 
@@ -51,14 +361,48 @@ func getActionKeymap() ActionKeyMap {
 	return ActionKeyMap{
 		Delete: constants.Keymap.Delete,
 		Create: constants.Keymap.Create,
-		Quit:   constants.Keymap.Quit,
-		Edit:   constants.Keymap.Enter,
+		QuickAdd: key.NewBinding(key.WithKeys("a"),
+			key.WithHelp("a", "quick add")),
+		Quit: constants.Keymap.Quit,
+		Edit: constants.Keymap.Enter,
 		GetSum: key.NewBinding(key.WithKeys("s"),
 			key.WithHelp("s", "summary")),
 		Filter: key.NewBinding(key.WithKeys("ctrl+f"),
 			key.WithHelp("ctrl+f", "filter")),
 		Export: key.NewBinding(key.WithKeys("ctrl+e"),
 			key.WithHelp("ctrl+e", "export to csv")),
+		Help: key.NewBinding(key.WithKeys("?"),
+			key.WithHelp("?", "more")),
+		Categories: key.NewBinding(key.WithKeys("m"),
+			key.WithHelp("m", "manage categories")),
+		Rules: key.NewBinding(key.WithKeys("r"),
+			key.WithHelp("r", "categorization rules")),
+		Calendar: key.NewBinding(key.WithKeys("v"),
+			key.WithHelp("v", "calendar")),
+		Charts: key.NewBinding(key.WithKeys("t"),
+			key.WithHelp("t", "charts")),
+		Comparison: key.NewBinding(key.WithKeys("p"),
+			key.WithHelp("p", "compare periods")),
+		Backups: key.NewBinding(key.WithKeys("ctrl+b"),
+			key.WithHelp("ctrl+b", "backups")),
+		Ledgers: key.NewBinding(key.WithKeys("l"),
+			key.WithHelp("l", "switch ledger")),
+		Accounts: key.NewBinding(key.WithKeys("w"),
+			key.WithHelp("w", "accounts")),
+		Members: key.NewBinding(key.WithKeys("o"),
+			key.WithHelp("o", "who owes whom")),
+		Select: key.NewBinding(key.WithKeys(" "),
+			key.WithHelp("space", "select")),
+		SelectRangeUp: key.NewBinding(key.WithKeys("shift+up"),
+			key.WithHelp("shift+↑", "select up")),
+		SelectRangeDown: key.NewBinding(key.WithKeys("shift+down"),
+			key.WithHelp("shift+↓", "select down")),
+		SelectAll: key.NewBinding(key.WithKeys("ctrl+a"),
+			key.WithHelp("ctrl+a", "select all visible")),
+		ClearSelection: key.NewBinding(key.WithKeys("esc"),
+			key.WithHelp("esc", "clear selection/day")),
+		BulkEdit: key.NewBinding(key.WithKeys("b"),
+			key.WithHelp("b", "bulk edit")),
 	}
 }
 
@@ -74,3 +418,209 @@ func getNavigationKeymap() NavigationKeyMap {
 		),
 	}
 }
+
+// getCategoryKeymap returns a default set of keybindings for the category manager.
+func getCategoryKeymap() CategoryKeyMap {
+	return CategoryKeyMap{
+		Rename: constants.Keymap.Rename,
+		Merge: key.NewBinding(key.WithKeys("m"),
+			key.WithHelp("m", "merge into")),
+		Delete: constants.Keymap.Delete,
+		Up:     constants.Keymap.Up,
+		Down:   constants.Keymap.Down,
+		Confirm: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "apply"),
+		),
+		Back: constants.Keymap.Back,
+	}
+}
+
+// getRuleKeymap returns a default set of keybindings for the rules editor.
+func getRuleKeymap() RuleKeyMap {
+	return RuleKeyMap{
+		Create: constants.Keymap.Create,
+		Edit: key.NewBinding(key.WithKeys("enter"),
+			key.WithHelp("enter", "edit")),
+		Delete: constants.Keymap.Delete,
+		Apply: key.NewBinding(key.WithKeys("a"),
+			key.WithHelp("a", "re-apply to expenses")),
+		Up:   constants.Keymap.Up,
+		Down: constants.Keymap.Down,
+		Confirm: key.NewBinding(key.WithKeys("enter"),
+			key.WithHelp("enter", "apply changes")),
+		Back: constants.Keymap.Back,
+	}
+}
+
+// getBackupKeymap returns a default set of keybindings for the backups screen.
+func getBackupKeymap() BackupKeyMap {
+	return BackupKeyMap{
+		BackupNow: key.NewBinding(key.WithKeys("n"),
+			key.WithHelp("n", "back up now")),
+		Restore: key.NewBinding(key.WithKeys("enter"),
+			key.WithHelp("enter", "restore")),
+		Up:   constants.Keymap.Up,
+		Down: constants.Keymap.Down,
+		Confirm: key.NewBinding(key.WithKeys("enter"),
+			key.WithHelp("enter", "confirm restore")),
+		Back: constants.Keymap.Back,
+	}
+}
+
+// getUnlockKeymap returns a default set of keybindings for the passphrase prompt. Quitting is left
+// to ctrl+c alone, since q may be part of the passphrase.
+func getUnlockKeymap() UnlockKeyMap {
+	return UnlockKeyMap{
+		Unlock: key.NewBinding(key.WithKeys("enter"),
+			key.WithHelp("enter", "unlock")),
+		Back: constants.Keymap.Back,
+		Quit: key.NewBinding(key.WithKeys("ctrl+c"),
+			key.WithHelp("ctrl+c", "quit")),
+	}
+}
+
+// getLedgerKeymap returns a default set of keybindings for the ledger switcher.
+func getLedgerKeymap() LedgerKeyMap {
+	return LedgerKeyMap{
+		Switch: key.NewBinding(key.WithKeys("enter"),
+			key.WithHelp("enter", "switch")),
+		Create: key.NewBinding(key.WithKeys("c"),
+			key.WithHelp("c", "new ledger")),
+		Up:   constants.Keymap.Up,
+		Down: constants.Keymap.Down,
+		Save: key.NewBinding(key.WithKeys("enter"),
+			key.WithHelp("enter", "create")),
+		Back: constants.Keymap.Back,
+	}
+}
+
+// getAccountKeymap returns a default set of keybindings for the accounts screen.
+func getAccountKeymap() AccountKeyMap {
+	return AccountKeyMap{
+		Create: constants.Keymap.Create,
+		Edit: key.NewBinding(key.WithKeys("e"),
+			key.WithHelp("e", "edit")),
+		Delete: constants.Keymap.Delete,
+		Transfer: key.NewBinding(key.WithKeys("t"),
+			key.WithHelp("t", "transfer")),
+		History: key.NewBinding(key.WithKeys("enter"),
+			key.WithHelp("enter", "running balance")),
+		Up:   constants.Keymap.Up,
+		Down: constants.Keymap.Down,
+		Back: constants.Keymap.Back,
+	}
+}
+
+// getMemberKeymap returns a default set of keybindings for the shared expenses screen.
+func getMemberKeymap() MemberKeyMap {
+	return MemberKeyMap{
+		Settle: key.NewBinding(key.WithKeys("enter"),
+			key.WithHelp("enter", "record payment")),
+		Up:   constants.Keymap.Up,
+		Down: constants.Keymap.Down,
+		Back: constants.Keymap.Back,
+	}
+}
+
+// getQuickAddKeymap returns a default set of keybindings for the quick-add bar.
+func getQuickAddKeymap() QuickAddKeyMap {
+	return QuickAddKeyMap{
+		Save: key.NewBinding(key.WithKeys("enter"),
+			key.WithHelp("enter", "save")),
+		Form: key.NewBinding(key.WithKeys("ctrl+o"),
+			key.WithHelp("ctrl+o", "open in full form")),
+		Back: constants.Keymap.Back,
+	}
+}
+
+// getFormKeymap returns a default set of keybindings for the expense form, with its date input and split lines.
+func getFormKeymap() FormKeyMap {
+	return FormKeyMap{
+		NavigationKeyMap: getNavigationKeymap(),
+		Calendar: key.NewBinding(key.WithKeys("ctrl+t"),
+			key.WithHelp("ctrl+t", "pick date")),
+		AddSplit: key.NewBinding(key.WithKeys("ctrl+s"),
+			key.WithHelp("ctrl+s", "add split line")),
+		RemoveSplit: key.NewBinding(key.WithKeys("ctrl+x"),
+			key.WithHelp("ctrl+x", "remove split line")),
+	}
+}
+
+// getDatePickerKeymap returns a default set of keybindings for the calendar popup.
+func getDatePickerKeymap() DatePickerKeyMap {
+	return DatePickerKeyMap{
+		PrevDay: key.NewBinding(key.WithKeys("left", "h"),
+			key.WithHelp("←", "previous day")),
+		NextDay: key.NewBinding(key.WithKeys("right", "l"),
+			key.WithHelp("→", "next day")),
+		PrevWeek: key.NewBinding(key.WithKeys("up", "k"),
+			key.WithHelp("↑", "previous week")),
+		NextWeek: key.NewBinding(key.WithKeys("down", "j"),
+			key.WithHelp("↓", "next week")),
+		PrevMonth: key.NewBinding(key.WithKeys("pgup", "["),
+			key.WithHelp("pgup", "previous month")),
+		NextMonth: key.NewBinding(key.WithKeys("pgdown", "]"),
+			key.WithHelp("pgdown", "next month")),
+		Pick: key.NewBinding(key.WithKeys("enter"),
+			key.WithHelp("enter", "pick")),
+		Cancel: key.NewBinding(key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel")),
+	}
+}
+
+// getCalendarKeymap returns a default set of keybindings for the calendar view.
+func getCalendarKeymap() CalendarKeyMap {
+	return CalendarKeyMap{
+		PrevDay: key.NewBinding(key.WithKeys("left", "h"),
+			key.WithHelp("←", "previous day")),
+		NextDay: key.NewBinding(key.WithKeys("right", "l"),
+			key.WithHelp("→", "next day")),
+		PrevWeek: key.NewBinding(key.WithKeys("up", "k"),
+			key.WithHelp("↑", "previous week")),
+		NextWeek: key.NewBinding(key.WithKeys("down", "j"),
+			key.WithHelp("↓", "next week")),
+		PrevMonth: key.NewBinding(key.WithKeys("pgup", "["),
+			key.WithHelp("pgup", "previous month")),
+		NextMonth: key.NewBinding(key.WithKeys("pgdown", "]"),
+			key.WithHelp("pgdown", "next month")),
+		Open: key.NewBinding(key.WithKeys("enter"),
+			key.WithHelp("enter", "show expenses")),
+		Create: key.NewBinding(key.WithKeys("c"),
+			key.WithHelp("c", "add on this day")),
+		Back: constants.Keymap.Back,
+	}
+}
+
+// getChartsKeymap returns a default set of keybindings for the charts screen.
+func getChartsKeymap() ChartsKeyMap {
+	return ChartsKeyMap{
+		Next: key.NewBinding(key.WithKeys("tab", "right"),
+			key.WithHelp("tab/→", "next chart")),
+		Prev: key.NewBinding(key.WithKeys("shift+tab", "left"),
+			key.WithHelp("shift+tab/←", "previous chart")),
+		Back: constants.Keymap.Back,
+	}
+}
+
+// getComparisonKeymap returns a default set of keybindings for the comparison report.
+func getComparisonKeymap() ComparisonKeyMap {
+	return ComparisonKeyMap{
+		PrevMonth: key.NewBinding(key.WithKeys("left", "pgup"),
+			key.WithHelp("←", "previous month")),
+		NextMonth: key.NewBinding(key.WithKeys("right", "pgdown"),
+			key.WithHelp("→", "next month")),
+		Back: constants.Keymap.Back,
+	}
+}
+
+// getSummaryKeymap returns a default set of keybindings for the summary screen.
+func getSummaryKeymap() SummaryKeyMap {
+	return SummaryKeyMap{
+		NavigationKeyMap: getNavigationKeymap(),
+		RollUp: key.NewBinding(key.WithKeys("pgup"),
+			key.WithHelp("pgup", "roll up categories")),
+		DrillDown: key.NewBinding(key.WithKeys("pgdown"),
+			key.WithHelp("pgdown", "drill down categories")),
+	}
+}
@@ -0,0 +1,165 @@
+package menu
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/samber/lo"
+	"strings"
+)
+
+const ledgersTitle = "Ledgers"
+
+type ledgersModel struct {
+	table   table.Model
+	ledgers []string
+	current string
+
+	//new ledger being named
+	creating  bool
+	nameInput textinput.Model
+	createErr error
+
+	//help
+	helpModel help.Model
+	keys      LedgerKeyMap
+}
+
+func newLedgersModel() (tea.Model, error) {
+	t := table.New(
+		table.WithColumns([]table.Column{
+			{Title: " ", Width: 2},
+			{Title: "Ledger", Width: 40},
+		}),
+		table.WithFocused(true),
+		table.WithHeight(10),
+	)
+	t.SetStyles(getTableStyles())
+
+	input := textinput.New()
+	input.Prompt = "Name: "
+	input.Placeholder = "e.g. household"
+	input.Width = 40
+	input.CharLimit = 40
+	input.PromptStyle = focusedStyle
+	input.TextStyle = focusedStyle
+
+	ledgers, err := expense.GetLedgers()
+	if err != nil {
+		return ledgersModel{}, err
+	}
+
+	current := expense.CurrentLedger()
+
+	t.SetRows(lo.Map(ledgers, func(name string, _ int) table.Row {
+		marker := ""
+		if name == current {
+			marker = "*"
+		}
+
+		return table.Row{marker, name}
+	}))
+
+	if i := lo.IndexOf(ledgers, current); i >= 0 {
+		t.SetCursor(i)
+	}
+
+	return ledgersModel{
+		table:     t,
+		ledgers:   ledgers,
+		current:   current,
+		nameInput: input,
+		helpModel: help.New(),
+		keys:      getLedgerKeymap(),
+	}, nil
+}
+
+func (m ledgersModel) Init() tea.Cmd { return nil }
+
+func (m ledgersModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.creating {
+		switch {
+		case key.Matches(keyMsg, m.keys.Back):
+			m.creating = false
+			m.createErr = nil
+			m.nameInput.Blur()
+			return m, nil
+		case key.Matches(keyMsg, m.keys.Save):
+			name := strings.TrimSpace(m.nameInput.Value())
+
+			if err := expense.CreateLedger(name); err != nil {
+				m.createErr = err
+				return m, nil
+			}
+
+			return m, switchLedgerCmd(name, m.current)
+		}
+
+		var cmd tea.Cmd
+		m.nameInput, cmd = m.nameInput.Update(msg)
+		return m, cmd
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keys.Back):
+		return m, backToTableCmd()
+	case key.Matches(keyMsg, m.keys.Create):
+		m.creating = true
+		m.nameInput.Reset()
+		return m, m.nameInput.Focus()
+	case key.Matches(keyMsg, m.keys.Switch):
+		cursor := m.table.Cursor()
+		if cursor < 0 || cursor >= len(m.ledgers) {
+			return m, nil
+		}
+
+		if m.ledgers[cursor] == m.current {
+			return m, backToTableCmd()
+		}
+
+		return m, switchLedgerCmd(m.ledgers[cursor], m.current)
+	case key.Matches(keyMsg, m.keys.Up):
+		m.table.MoveUp(1)
+		return m, nil
+	case key.Matches(keyMsg, m.keys.Down):
+		m.table.MoveDown(1)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+func (m ledgersModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(ledgersTitle) + "\n\n")
+	b.WriteString(tableStyle.Render(m.table.View()) + "\n\n")
+
+	if m.creating {
+		b.WriteString(m.nameInput.View() + "\n\n")
+
+		if m.createErr != nil {
+			b.WriteString(m.createErr.Error() + "\n\n")
+		}
+
+		b.WriteString("Every ledger has its own expenses, settings, rules and backups, enter to create, esc to cancel\n\n")
+
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "Keeping the expenses in the %s ledger\n\n", m.current)
+	b.WriteString(m.helpModel.View(m.keys))
+
+	return b.String()
+}
@@ -0,0 +1,155 @@
+package menu
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/samber/lo"
+	"strings"
+)
+
+const membersTitle = "Shared Expenses"
+
+type membersModel struct {
+	balancesTable table.Model
+	settleTable   table.Model
+	balances      []expense.MemberBalance
+	settlements   []expense.Settlement
+
+	//help
+	helpModel help.Model
+	keys      MemberKeyMap
+}
+
+func newMembersModel() (tea.Model, error) {
+	balances := table.New(
+		table.WithColumns([]table.Column{
+			{Title: "Member", Width: 20},
+			{Title: "Paid", Width: 12},
+			{Title: "Owed", Width: 12},
+			{Title: "Balance", Width: 12},
+		}),
+		table.WithHeight(8),
+	)
+	balances.SetStyles(getTableStyles())
+
+	settle := table.New(
+		table.WithColumns([]table.Column{
+			{Title: "From", Width: 20},
+			{Title: "To", Width: 20},
+			{Title: "Amount", Width: 12},
+		}),
+		table.WithFocused(true),
+		table.WithHeight(6),
+	)
+	settle.SetStyles(getTableStyles())
+
+	m := membersModel{
+		balancesTable: balances,
+		settleTable:   settle,
+		helpModel:     help.New(),
+		keys:          getMemberKeymap(),
+	}
+
+	return m.reload()
+}
+
+func (m membersModel) Init() tea.Cmd { return nil }
+
+func (m membersModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keys.Back):
+		return m, backToTableCmd()
+	case key.Matches(keyMsg, m.keys.Settle):
+		cursor := m.settleTable.Cursor()
+		if cursor < 0 || cursor >= len(m.settlements) {
+			return m, nil
+		}
+
+		s := m.settlements[cursor]
+
+		today, err := expense.ParseDateTime("today", getDateFormat())
+		if err != nil {
+			return m, errorCmd(err, goToMembersCmd())
+		}
+
+		if _, err = expense.Settle(today, s.From, s.To, s.Amount); err != nil {
+			return m, errorCmd(err, goToMembersCmd())
+		}
+
+		return m, infoCmd(fmt.Sprintf("Recorded %s paying %s %.2f", s.From, s.To, s.Amount), goToMembersCmd())
+	case key.Matches(keyMsg, m.keys.Up):
+		m.settleTable.MoveUp(1)
+		return m, nil
+	case key.Matches(keyMsg, m.keys.Down):
+		m.settleTable.MoveDown(1)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.settleTable, cmd = m.settleTable.Update(msg)
+	return m, cmd
+}
+
+func (m membersModel) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(membersTitle) + "\n\n")
+
+	if len(m.balances) == 0 {
+		b.WriteString("No shared expenses yet, fill in who paid and the participants in the expense form\n\n")
+		b.WriteString(m.helpModel.View(m.keys))
+
+		return b.String()
+	}
+
+	b.WriteString(tableStyle.Render(m.balancesTable.View()) + "\n\n")
+
+	if len(m.settlements) == 0 {
+		b.WriteString("Everybody is even\n\n")
+	} else {
+		b.WriteString("Settle up with these payments:\n")
+		b.WriteString(tableStyle.Render(m.settleTable.View()) + "\n\n")
+	}
+
+	b.WriteString(m.helpModel.View(m.keys))
+
+	return b.String()
+}
+
+func (m membersModel) reload() (membersModel, error) {
+	balances, err := expense.GetMemberBalances()
+	if err != nil {
+		return m, fmt.Errorf("Error getting members: %w", err)
+	}
+
+	m.balances = balances
+	m.settlements = expense.SettleUp(balances)
+
+	m.balancesTable.SetRows(lo.Map(balances, func(b expense.MemberBalance, _ int) table.Row {
+		return table.Row{
+			b.Name,
+			fmt.Sprintf("%.2f", b.Paid),
+			fmt.Sprintf("%.2f", b.Owed),
+			fmt.Sprintf("%+.2f", b.Balance),
+		}
+	}))
+	m.settleTable.SetRows(lo.Map(m.settlements, func(s expense.Settlement, _ int) table.Row {
+		return table.Row{s.From, s.To, fmt.Sprintf("%.2f", s.Amount)}
+	}))
+
+	return m, nil
+}
+
+func getMemberNames(balances []expense.MemberBalance) []string {
+	return lo.Map(balances, func(b expense.MemberBalance, _ int) string {
+		return b.Name
+	})
+}
@@ -2,6 +2,7 @@ package menu
 
 import (
 	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -12,6 +13,21 @@ const (
 	changeState
 	msgState
 	sumState
+	bulkState
+	categoriesState
+	rulesState
+	ruleFormState
+	quickAddState
+	calendarState
+	chartsState
+	comparisonState
+	backupsState
+	unlockState
+	ledgersState
+	accountsState
+	accountFormState
+	transferFormState
+	membersState
 )
 
 type MainModel struct {
@@ -19,21 +35,74 @@ type MainModel struct {
 	models       map[state]tea.Model
 }
 
+// InitialModel opens the application on the table, or on the passphrase prompt while the expenses are
+// encrypted and locked.
 func InitialModel() (tea.Model, error) {
+	locked, err := expense.IsLocked()
+	if err != nil {
+		return nil, err
+	}
+
+	if locked {
+		m := newMainModel(tableModel{})
+		m.models[unlockState] = newUnlockModel(nil)
+		m.currentState = unlockState
+
+		return m, nil
+	}
+
 	tableModel, err := newTableModel()
 	if err != nil {
 		return nil, err
 	}
 
+	return newMainModel(tableModel), nil
+}
+
+func newMainModel(table tea.Model) MainModel {
 	return MainModel{
 		currentState: tableState,
 		models: map[state]tea.Model{
-			tableState:  tableModel,
-			changeState: changeFormModel{},
-			sumState:    summaryInfoModel{},
-			msgState:    msgModel{},
+			tableState:        table,
+			changeState:       changeFormModel{},
+			sumState:          summaryInfoModel{},
+			msgState:          msgModel{},
+			bulkState:         bulkFormModel{},
+			categoriesState:   categoryManagerModel{},
+			rulesState:        rulesModel{},
+			ruleFormState:     ruleFormModel{},
+			quickAddState:     quickAddModel{},
+			calendarState:     calendarModel{},
+			chartsState:       chartsModel{},
+			comparisonState:   comparisonModel{},
+			backupsState:      backupsModel{},
+			unlockState:       unlockModel{},
+			ledgersState:      ledgersModel{},
+			accountsState:     accountsModel{},
+			accountFormState:  accountFormModel{},
+			transferFormState: transferFormModel{},
+			membersState:      membersModel{},
 		},
-	}, nil
+	}
+}
+
+// InitialDraftModel opens the application on the addition form filled in with a draft expense.
+func InitialDraftModel(draft domain.Expense) (tea.Model, error) {
+	m, err := InitialModel()
+	if err != nil {
+		return nil, err
+	}
+
+	form, err := newDraftModel(draft)
+	if err != nil {
+		return nil, err
+	}
+
+	mainModel := m.(MainModel)
+	mainModel.models[changeState] = form
+	mainModel.currentState = changeState
+
+	return mainModel, nil
 }
 
 func (m MainModel) Init() tea.Cmd { return nil }
@@ -48,10 +117,68 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, errorCmd(err, backToTableCmd())
 		}
 
+		m.models[tableState] = newTable
+		m.currentState = tableState
+	case unlockedMsg:
+		newTable, err := newTableModel()
+		if err != nil {
+			return m, errorCmd(err, tea.Quit)
+		}
+
+		m.models[tableState] = newTable
+		m.currentState = tableState
+	case ledgersMsg:
+		newLedgersModel, err := newLedgersModel()
+		if err != nil {
+			return m, errorCmd(err, backToTableCmd())
+		}
+
+		m.models[ledgersState] = newLedgersModel
+		m.currentState = ledgersState
+	case switchLedgerMsg:
+		if err := expense.SwitchLedger(msg.name); err != nil {
+			return m, errorCmd(err, goToLedgersCmd())
+		}
+
+		locked, err := expense.IsLocked()
+		if err != nil {
+			return m, errorCmd(err, switchLedgerCmd(msg.previous, msg.name))
+		}
+
+		if locked {
+			m.models[unlockState] = newUnlockModel(switchLedgerCmd(msg.previous, msg.name))
+			m.currentState = unlockState
+			break
+		}
+
+		newTable, err := newTableModel()
+		if err != nil {
+			return m, errorCmd(err, switchLedgerCmd(msg.previous, msg.name))
+		}
+
+		m.models[tableState] = newTable
+		m.currentState = tableState
+	case tableOnDateMsg:
+		newTable, err := m.models[tableState].(tableModel).UpdateExpenses()
+		if err != nil {
+			return m, errorCmd(err, goToCalendarCmd())
+		}
+
+		date := msg.date
+		newTable.dateFilter = &date
+
 		m.models[tableState] = newTable
 		m.currentState = tableState
 	case addMsg:
-		newAddInput, err := newAdditionModel()
+		var newAddInput tea.Model
+		var err error
+
+		if msg.draft != nil {
+			newAddInput, err = newDraftModel(*msg.draft)
+		} else {
+			newAddInput, err = newAdditionModel()
+		}
+
 		if err != nil {
 			return m, errorCmd(err, backToTableCmd())
 		}
@@ -64,6 +191,21 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, errorCmd(err, backToTableCmd())
 		}
 
+		if exp.IsSettlement() {
+			return m, infoCmd("A settlement cannot be edited, delete it and settle up again", backToTableCmd())
+		}
+
+		if exp.IsTransfer() {
+			newTransferForm, err := newTransferFormModel(&exp, backToTableCmd())
+			if err != nil {
+				return m, errorCmd(err, backToTableCmd())
+			}
+
+			m.models[transferFormState] = newTransferForm
+			m.currentState = transferFormState
+			break
+		}
+
 		newEditInput, err := newChangeModel(exp)
 		if err != nil {
 			return m, errorCmd(err, backToTableCmd())
@@ -79,6 +221,95 @@ func (m MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		m.models[sumState] = newSummaryModel
 		m.currentState = sumState
+	case calendarMsg:
+		newCalendarModel, err := newCalendarModel()
+		if err != nil {
+			return m, errorCmd(err, backToTableCmd())
+		}
+
+		m.models[calendarState] = newCalendarModel
+		m.currentState = calendarState
+	case chartsMsg:
+		newChartsModel, err := newChartsModel()
+		if err != nil {
+			return m, errorCmd(err, backToTableCmd())
+		}
+
+		m.models[chartsState] = newChartsModel
+		m.currentState = chartsState
+	case comparisonMsg:
+		newComparisonModel, err := newComparisonModel()
+		if err != nil {
+			return m, errorCmd(err, backToTableCmd())
+		}
+
+		m.models[comparisonState] = newComparisonModel
+		m.currentState = comparisonState
+	case backupsMsg:
+		newBackupsModel, err := newBackupsModel()
+		if err != nil {
+			return m, errorCmd(err, backToTableCmd())
+		}
+
+		m.models[backupsState] = newBackupsModel
+		m.currentState = backupsState
+	case accountsMsg:
+		newAccountsModel, err := newAccountsModel()
+		if err != nil {
+			return m, errorCmd(err, backToTableCmd())
+		}
+
+		m.models[accountsState] = newAccountsModel
+		m.currentState = accountsState
+	case membersMsg:
+		newMembersModel, err := newMembersModel()
+		if err != nil {
+			return m, errorCmd(err, backToTableCmd())
+		}
+
+		m.models[membersState] = newMembersModel
+		m.currentState = membersState
+	case accountFormMsg:
+		m.models[accountFormState] = newAccountFormModel(msg.account)
+		m.currentState = accountFormState
+	case transferFormMsg:
+		newTransferForm, err := newTransferFormModel(msg.transfer, msg.sourceBack)
+		if err != nil {
+			return m, errorCmd(err, msg.sourceBack)
+		}
+
+		m.models[transferFormState] = newTransferForm
+		m.currentState = transferFormState
+	case bulkEditMsg:
+		m.models[bulkState] = newBulkFormModel(msg.ids)
+		m.currentState = bulkState
+	case categoriesMsg:
+		newCategoriesModel, err := newCategoryManagerModel()
+		if err != nil {
+			return m, errorCmd(err, backToTableCmd())
+		}
+
+		m.models[categoriesState] = newCategoriesModel
+		m.currentState = categoriesState
+	case quickAddMsg:
+		m.models[quickAddState] = newQuickAddModel()
+		m.currentState = quickAddState
+	case rulesMsg:
+		newRulesModel, err := newRulesModel()
+		if err != nil {
+			return m, errorCmd(err, backToTableCmd())
+		}
+
+		m.models[rulesState] = newRulesModel
+		m.currentState = rulesState
+	case ruleFormMsg:
+		newRuleForm, err := newRuleFormModel(msg.rule)
+		if err != nil {
+			return m, errorCmd(err, goToRulesCmd())
+		}
+
+		m.models[ruleFormState] = newRuleForm
+		m.currentState = ruleFormState
 	case errorMsg:
 		m.models[msgState] = newMsgModel(msg.error.Error(), msg.sourceBack)
 		m.currentState = msgState
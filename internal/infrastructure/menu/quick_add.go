@@ -0,0 +1,118 @@
+package menu
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"strings"
+)
+
+const quickAddTitle = "Quick Add"
+
+type quickAddModel struct {
+	input      textinput.Model
+	entry      *expense.QuickEntry
+	parseErr   error
+	dateFormat expense.DateFormat
+
+	//help
+	helpModel help.Model
+	keys      QuickAddKeyMap
+}
+
+func newQuickAddModel() quickAddModel {
+	input := textinput.New()
+	input.Placeholder = `e.g. coffee 3.50 food yesterday`
+	input.Width = 100
+	input.CharLimit = 0
+	input.PromptStyle = focusedStyle
+	input.TextStyle = focusedStyle
+	input.Focus()
+
+	return quickAddModel{
+		input:      input,
+		dateFormat: getDateFormat(),
+		helpModel:  help.New(),
+		keys:       getQuickAddKeymap(),
+	}
+}
+
+func (m quickAddModel) Init() tea.Cmd { return nil }
+
+func (m quickAddModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(keyMsg, m.keys.Back):
+			return m, backToTableCmd()
+		case key.Matches(keyMsg, m.keys.Form):
+			if m.entry == nil {
+				return m, goToAddCmd()
+			}
+
+			return m, goToAddDraftCmd(m.entry.Draft())
+		case key.Matches(keyMsg, m.keys.Save):
+			if m.entry == nil || m.parseErr != nil {
+				return m, nil
+			}
+
+			if m.entry.IsAmbiguous() {
+				return m, goToAddDraftCmd(m.entry.Draft())
+			}
+
+			if _, err := expense.AddQuickEntry(*m.entry); err != nil {
+				return m, errorCmd(err, goToQuickAddCmd())
+			}
+
+			return m, backToTableCmd()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+
+	m.entry, m.parseErr = nil, nil
+
+	if line := strings.TrimSpace(m.input.Value()); line != "" {
+		entry, err := expense.ParseQuickAdd(line)
+		m.entry, m.parseErr = &entry, err
+	}
+
+	return m, cmd
+}
+
+func (m quickAddModel) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(quickAddTitle) + "\n\n")
+	b.WriteString(m.input.View() + "\n\n")
+
+	switch {
+	case m.parseErr != nil:
+		b.WriteString(m.parseErr.Error() + "\n\n")
+	case m.entry != nil:
+		e := m.entry.Expense()
+
+		category := e.Category
+		if m.entry.Category == "" {
+			category = "- (set by a matching rule)"
+		}
+
+		amount := fmt.Sprintf("%.2f", e.Amount)
+		if m.entry.Currency != "" {
+			amount += " " + strings.ToUpper(m.entry.Currency)
+		}
+
+		fmt.Fprintf(&b, "Description: %s\nCategory:    %s\nAmount:      %s\nDate:        %s\n\n",
+			e.Description, category, amount, m.dateFormat.Format(e.SpentAt, getLocation()))
+
+		if m.entry.IsAmbiguous() {
+			fmt.Fprintf(&b, "Not sure about it: %s, enter opens the full form\n\n", strings.Join(m.entry.Ambiguities, ", "))
+		}
+	}
+
+	b.WriteString(m.helpModel.View(m.keys))
+
+	return b.String()
+}
@@ -0,0 +1,273 @@
+package menu
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/menu/constants"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/samber/lo"
+	"strconv"
+	"strings"
+)
+
+const ruleFormTitle = "Categorization Rule"
+
+type ruleFormModel struct {
+	focusIndex int
+	inputs     []textinput.Model
+	editingId  *int
+
+	//help
+	helpModel      help.Model
+	navigationKeys NavigationKeyMap
+}
+
+func (m ruleFormModel) Init() tea.Cmd { return nil }
+
+func (m ruleFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case canAcceptSuggestion(m.inputs, m.focusIndex) && key.Matches(msg, m.inputs[m.focusIndex].KeyMap.AcceptSuggestion):
+			// let the focused input complete its suggestion instead of moving the focus
+		case key.Matches(msg, constants.Keymap.Enter, constants.Keymap.Up, constants.Keymap.Down):
+			if key.Matches(msg, constants.Keymap.Enter) {
+				if m.focusIndex == len(m.inputs) {
+					hasError := false
+
+					for i := range m.inputs {
+						err := m.inputs[i].Err
+
+						if err != nil {
+							m.inputs[i].SetValue(err.Error())
+							hasError = true
+						}
+					}
+
+					if hasError {
+						return m, nil
+					}
+
+					rule, err := m.getRule()
+					if err != nil {
+						return m, errorCmd(err, goToRulesCmd())
+					}
+
+					if m.editingId == nil {
+						_, err = expense.AddRule(rule)
+					} else {
+						rule.Id = *m.editingId
+						_, err = expense.UpdateRule(rule)
+					}
+
+					if err != nil {
+						return m, errorCmd(err, goToRulesCmd())
+					}
+
+					return m, goToRulesCmd()
+				} else {
+					m.focusIndex++
+				}
+			} else {
+				if key.Matches(msg, constants.Keymap.Up) {
+					m.focusIndex--
+				} else if key.Matches(msg, constants.Keymap.Down) {
+					m.focusIndex++
+				}
+			}
+
+			if m.focusIndex > len(m.inputs) {
+				m.focusIndex = 0
+			} else if m.focusIndex < 0 {
+				m.focusIndex = len(m.inputs)
+			}
+
+			cmds := make([]tea.Cmd, len(m.inputs))
+
+			for i := 0; i < len(m.inputs); i++ {
+				if i == m.focusIndex {
+					// Set focused state
+					cmds[i] = m.inputs[i].Focus()
+					m.inputs[i].PromptStyle = focusedStyle
+					m.inputs[i].TextStyle = focusedStyle
+				} else {
+					// Remove focused state
+					m.inputs[i].Blur()
+					m.inputs[i].PromptStyle = blurredStyle
+					m.inputs[i].TextStyle = blurredStyle
+				}
+			}
+
+			return m, tea.Batch(cmds...)
+		case key.Matches(msg, constants.Keymap.Back):
+			return m, goToRulesCmd()
+		}
+	}
+
+	cmds := make([]tea.Cmd, len(m.inputs))
+
+	for i := range m.inputs {
+		m.inputs[i], cmds[i] = m.inputs[i].Update(msg)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m ruleFormModel) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(ruleFormTitle) + "\n\n")
+
+	for i := range m.inputs {
+		b.WriteString(m.inputs[i].View())
+
+		if i < len(m.inputs)-1 {
+			b.WriteRune('\n')
+		}
+	}
+
+	button := &blurredButton
+	if m.focusIndex == len(m.inputs) {
+		button = &focusedButton
+	}
+	fmt.Fprintf(&b, "\n\n%s\n\n", *button)
+	b.WriteString(m.helpModel.View(m.navigationKeys))
+
+	return b.String()
+}
+
+func (m ruleFormModel) getRule() (domain.Rule, error) {
+	rule := domain.Rule{
+		Kind:     domain.RuleKind(strings.TrimSpace(m.inputs[1].Value())),
+		Pattern:  m.inputs[2].Value(),
+		Category: m.inputs[5].Value(),
+	}
+
+	var err error
+
+	if rule.Priority, err = parseOptionalInt(m.inputs[0].Value()); err != nil {
+		return domain.Rule{}, err
+	}
+
+	if rule.MinAmount, err = parseOptionalFloat(m.inputs[3].Value()); err != nil {
+		return domain.Rule{}, err
+	}
+
+	if rule.MaxAmount, err = parseOptionalFloat(m.inputs[4].Value()); err != nil {
+		return domain.Rule{}, err
+	}
+
+	return rule, nil
+}
+
+// newRuleFormModel creates a form editing the given rule, or adding a new one when it is nil.
+func newRuleFormModel(rule *domain.Rule) (tea.Model, error) {
+	stats, err := expense.GetCategoriesStats()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting categories: %w", err)
+	}
+
+	m := ruleFormModel{
+		inputs:         make([]textinput.Model, 6),
+		helpModel:      help.New(),
+		navigationKeys: getNavigationKeymap(),
+	}
+
+	var t textinput.Model
+	for i := range m.inputs {
+		t = textinput.New()
+		t.Width = 100
+		t.CharLimit = 0
+		t.PromptStyle = blurredStyle
+		t.TextStyle = blurredStyle
+
+		switch i {
+		case 0:
+			t.Placeholder = "Priority, lower runs first"
+			t.Validate = validateOptionalInt
+			t.Focus()
+			t.PromptStyle = focusedStyle
+			t.TextStyle = focusedStyle
+		case 1:
+			t.Placeholder = "Kind: substring, regex or payee"
+			t.ShowSuggestions = true
+			t.SetSuggestions(lo.Map(domain.RuleKinds, func(kind domain.RuleKind, _ int) string {
+				return string(kind)
+			}))
+			t.SetValue(string(domain.SubstringRule))
+		case 2:
+			t.Placeholder = "Pattern, e.g. TESCO or ^UBER \\*TRIP (empty to match any description)"
+		case 3:
+			t.Placeholder = "Minimum amount (empty for none)"
+			t.Validate = validateOptionalFloat
+		case 4:
+			t.Placeholder = "Maximum amount (empty for none)"
+			t.Validate = validateOptionalFloat
+		case 5:
+			t.Placeholder = "Category"
+			t.ShowSuggestions = true
+			t.SetSuggestions(lo.Map(stats, func(s expense.CategoryStats, _ int) string {
+				return s.Name
+			}))
+		}
+
+		m.inputs[i] = t
+	}
+
+	if rule != nil {
+		m.inputs[0].SetValue(strconv.Itoa(rule.Priority))
+		m.inputs[1].SetValue(string(rule.Kind))
+		m.inputs[2].SetValue(rule.Pattern)
+		m.inputs[3].SetValue(formatOptionalFloat(rule.MinAmount))
+		m.inputs[4].SetValue(formatOptionalFloat(rule.MaxAmount))
+		m.inputs[5].SetValue(rule.Category)
+
+		id := rule.Id
+		m.editingId = &id
+	}
+
+	return m, nil
+}
+
+func parseOptionalInt(s string) (int, error) {
+	if s = strings.TrimSpace(s); s == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(s)
+}
+
+func parseOptionalFloat(s string) (float64, error) {
+	if s = strings.TrimSpace(s); s == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseFloat(s, 64)
+}
+
+func formatOptionalFloat(f float64) string {
+	if f == 0 {
+		return ""
+	}
+
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func validateOptionalInt(s string) error {
+	if _, err := parseOptionalInt(s); err != nil {
+		return fmt.Errorf("should be a whole number")
+	}
+
+	return nil
+}
+
+func validateOptionalFloat(s string) error {
+	if _, err := parseOptionalFloat(s); err != nil {
+		return fmt.Errorf("should be a number")
+	}
+
+	return nil
+}
@@ -0,0 +1,230 @@
+package menu
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/samber/lo"
+	"strconv"
+	"strings"
+)
+
+const (
+	rulesTitle        = "Categorization Rules"
+	rulesPreviewTitle = "Re-apply Rules (dry run)"
+)
+
+type rulesModel struct {
+	table table.Model
+	rules []domain.Rule
+
+	//dry run of re-applying the rules
+	previewing   bool
+	previewTable table.Model
+	changes      []expense.CategoryChange
+
+	//help
+	helpModel help.Model
+	keys      RuleKeyMap
+}
+
+func newRulesModel() (tea.Model, error) {
+	t := table.New(
+		table.WithColumns([]table.Column{
+			{Title: "Priority", Width: 8},
+			{Title: "Kind", Width: 10},
+			{Title: "Pattern", Width: 30},
+			{Title: "Amount", Width: 16},
+			{Title: "Category", Width: 20},
+		}),
+		table.WithFocused(true),
+		table.WithHeight(10),
+	)
+	t.SetStyles(getTableStyles())
+
+	preview := table.New(
+		table.WithColumns([]table.Column{
+			{Title: "ID", Width: 4},
+			{Title: "Description", Width: 30},
+			{Title: "Category", Width: 20},
+			{Title: "New category", Width: 20},
+		}),
+		table.WithFocused(true),
+		table.WithHeight(10),
+	)
+	preview.SetStyles(getTableStyles())
+
+	m := rulesModel{
+		table:        t,
+		previewTable: preview,
+		helpModel:    help.New(),
+		keys:         getRuleKeymap(),
+	}
+
+	return m.reload()
+}
+
+func (m rulesModel) Init() tea.Cmd { return nil }
+
+func (m rulesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.previewing {
+		switch {
+		case key.Matches(keyMsg, m.keys.Back):
+			m.previewing = false
+			return m, nil
+		case key.Matches(keyMsg, m.keys.Confirm):
+			changes, err := expense.ApplyRules()
+			if err != nil {
+				return m, errorCmd(err, goToRulesCmd())
+			}
+
+			return m, infoCmd(fmt.Sprintf("Recategorized %d expenses", len(changes)), goToRulesCmd())
+		case key.Matches(keyMsg, m.keys.Up):
+			m.previewTable.MoveUp(1)
+			return m, nil
+		case key.Matches(keyMsg, m.keys.Down):
+			m.previewTable.MoveDown(1)
+			return m, nil
+		}
+
+		var cmd tea.Cmd
+		m.previewTable, cmd = m.previewTable.Update(msg)
+		return m, cmd
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keys.Back):
+		return m, backToTableCmd()
+	case key.Matches(keyMsg, m.keys.Create):
+		return m, goToRuleFormCmd(nil)
+	case key.Matches(keyMsg, m.keys.Edit):
+		if rule, ok := m.selectedRule(); ok {
+			return m, goToRuleFormCmd(&rule)
+		}
+
+		return m, nil
+	case key.Matches(keyMsg, m.keys.Delete):
+		if rule, ok := m.selectedRule(); ok {
+			if err := expense.DeleteRule(rule.Id); err != nil {
+				return m, errorCmd(err, goToRulesCmd())
+			}
+
+			newModel, err := m.reload()
+			if err != nil {
+				return m, errorCmd(err, backToTableCmd())
+			}
+
+			return newModel, nil
+		}
+
+		return m, nil
+	case key.Matches(keyMsg, m.keys.Apply):
+		changes, err := expense.PreviewRules()
+		if err != nil {
+			return m, errorCmd(err, goToRulesCmd())
+		}
+
+		if len(changes) == 0 {
+			return m, infoCmd("Rules would not change any expense", goToRulesCmd())
+		}
+
+		m.changes = changes
+		m.previewing = true
+		m.previewTable.SetRows(lo.Map(changes, func(c expense.CategoryChange, _ int) table.Row {
+			return table.Row{
+				strconv.Itoa(c.Expense.Id),
+				c.Expense.Description,
+				c.Expense.Category,
+				c.NewCategory,
+			}
+		}))
+		m.previewTable.GotoTop()
+
+		return m, nil
+	case key.Matches(keyMsg, m.keys.Up):
+		m.table.MoveUp(1)
+		return m, nil
+	case key.Matches(keyMsg, m.keys.Down):
+		m.table.MoveDown(1)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+func (m rulesModel) View() string {
+	var b strings.Builder
+
+	if m.previewing {
+		b.WriteString(titleStyle.Render(rulesPreviewTitle) + "\n\n")
+		b.WriteString(tableStyle.Render(m.previewTable.View()) + "\n\n")
+		fmt.Fprintf(&b, "%d expenses would be recategorized, enter to apply, esc to cancel\n\n", len(m.changes))
+
+		return b.String()
+	}
+
+	b.WriteString(titleStyle.Render(rulesTitle) + "\n\n")
+	b.WriteString(tableStyle.Render(m.table.View()) + "\n\n")
+
+	if len(m.rules) == 0 {
+		b.WriteString("No rules yet, they categorize new expenses added without a category\n\n")
+	}
+
+	b.WriteString(m.helpModel.View(m.keys))
+
+	return b.String()
+}
+
+func (m rulesModel) reload() (rulesModel, error) {
+	rules, err := expense.GetRules()
+	if err != nil {
+		return m, fmt.Errorf("Error getting rules: %w", err)
+	}
+
+	m.rules = rules
+	m.table.SetRows(lo.Map(rules, func(r domain.Rule, _ int) table.Row {
+		return table.Row{
+			strconv.Itoa(r.Priority),
+			string(r.Kind),
+			r.Pattern,
+			formatAmountRange(r.MinAmount, r.MaxAmount),
+			r.Category,
+		}
+	}))
+
+	return m, nil
+}
+
+func (m rulesModel) selectedRule() (domain.Rule, bool) {
+	cursor := m.table.Cursor()
+
+	if cursor < 0 || cursor >= len(m.rules) {
+		return domain.Rule{}, false
+	}
+
+	return m.rules[cursor], true
+}
+
+func formatAmountRange(minAmount float64, maxAmount float64) string {
+	switch {
+	case minAmount == 0 && maxAmount == 0:
+		return "any"
+	case maxAmount == 0:
+		return fmt.Sprintf(">= %.2f", minAmount)
+	case minAmount == 0:
+		return fmt.Sprintf("<= %.2f", maxAmount)
+	}
+
+	return fmt.Sprintf("%.2f-%.2f", minAmount, maxAmount)
+}
@@ -0,0 +1,29 @@
+package menu
+
+import (
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/config"
+	"time"
+)
+
+// getDateFormat returns the preferred date format, falling back to ISO when the settings cannot be read.
+func getDateFormat() expense.DateFormat {
+	settings, err := config.Load()
+
+	if err != nil || settings.DateFormat == "" {
+		return expense.ISODateFormat
+	}
+
+	return expense.DateFormat(settings.DateFormat)
+}
+
+// getLocation returns the timezone dates are shown in, falling back to the local one when the settings cannot be read.
+func getLocation() *time.Location {
+	settings, err := config.Load()
+
+	if err != nil {
+		return time.Local
+	}
+
+	return settings.Location()
+}
@@ -1,13 +1,17 @@
 package menu
 
 import (
+	"cmp"
 	"fmt"
 	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
 	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/menu/constants"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/samber/lo"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -36,10 +40,17 @@ type summaryInfoModel struct {
 
 	//data
 	currentSummary float64
+	tagsSummary    map[string]float64
+	anomalies      []expense.Anomaly
+	forecast       *expense.MonthForecast
+	breakdown      map[string]float64
+	categoryDepth  int
+	year           int
+	month          time.Month
 
 	//help
 	helpModel      help.Model
-	navigationKeys NavigationKeyMap
+	navigationKeys SummaryKeyMap
 
 	//err
 	summaryErr error
@@ -83,13 +94,38 @@ func (m summaryInfoModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 					summary, err := expense.GetMonthlyExpensesSummary(year, month)
 					if err != nil {
-						m.summaryErr = fmt.Errorf("Eror fetching summary: %w", err)
+						m.summaryErr = fmt.Errorf("Error fetching summary: %w", err)
 					} else {
 						m.summaryErr = nil
 					}
 
+					tagsSummary, err := expense.GetMonthlyTagsSummary(year, month)
+					if err != nil {
+						m.summaryErr = fmt.Errorf("Error fetching tags summary: %w", err)
+					}
+
+					anomalies, err := expense.GetMonthlyAnomalies(year, month)
+					if err != nil {
+						m.summaryErr = fmt.Errorf("Error finding anomalies: %w", err)
+					}
+
+					m.anomalies = anomalies
+					m.forecast = nil
+
+					if now := time.Now().In(getLocation()); now.Year() == year && now.Month() == month {
+						forecast, err := expense.GetMonthForecast()
+						if err != nil {
+							m.summaryErr = fmt.Errorf("Error forecasting the month: %w", err)
+						} else {
+							m.forecast = &forecast
+						}
+					}
+
 					m.currentSummary = summary
-					return m, nil
+					m.tagsSummary = tagsSummary
+					m.year = year
+					m.month = month
+					return m.updateBreakdown(), nil
 				} else {
 					m.focusIndex++
 				}
@@ -126,6 +162,18 @@ func (m summaryInfoModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		case key.Matches(msg, m.navigationKeys.Back):
 			return m, backToTableCmd()
+		case key.Matches(msg, m.navigationKeys.RollUp):
+			if m.categoryDepth > 1 {
+				m.categoryDepth--
+			}
+
+			return m.updateBreakdown(), nil
+		case key.Matches(msg, m.navigationKeys.DrillDown):
+			if m.categoryDepth < m.maxBreakdownDepth() {
+				m.categoryDepth++
+			}
+
+			return m.updateBreakdown(), nil
 		}
 	}
 
@@ -154,7 +202,68 @@ func (m summaryInfoModel) View() string {
 	if m.summaryErr != nil {
 		b.WriteString(m.summaryErr.Error())
 	} else {
-		b.WriteString(fmt.Sprintf("Total spent for chosen month: %.2f\n\n", m.currentSummary))
+		b.WriteString(fmt.Sprintf("Total spent for chosen month: %.2f\n", m.currentSummary))
+
+		if m.forecast != nil {
+			b.WriteString(fmt.Sprintf("Forecast for the end of the month: %.2f\n", m.forecast.Total.Projected()))
+		}
+
+		b.WriteString("\n")
+
+		if m.forecast != nil && len(m.forecast.Categories) > 0 {
+			b.WriteString(fmt.Sprintf("  %-30s %10s %10s %10s\n", "Category", "Actual", "Expected", "Projected"))
+
+			for _, f := range m.forecast.Categories {
+				b.WriteString(fmt.Sprintf("  %-30s %10.2f %10.2f %10.2f\n", f.Category, f.Actual, f.Recurring+f.Variable, f.Projected()))
+			}
+
+			b.WriteString("\n")
+		}
+
+		if len(m.breakdown) > 0 {
+			b.WriteString(fmt.Sprintf("By category (level %d):\n", m.categoryDepth))
+
+			categories := lo.Keys(m.breakdown)
+			slices.SortFunc(categories, func(a, b string) int {
+				return slices.Compare(domain.CategoryLevels(a), domain.CategoryLevels(b))
+			})
+
+			for _, category := range categories {
+				b.WriteString(fmt.Sprintf("  %-30s %10.2f\n", category, m.breakdown[category]))
+			}
+
+			b.WriteString("\n")
+		}
+
+		if len(m.tagsSummary) > 0 {
+			b.WriteString("By tag:\n")
+
+			tags := lo.Keys(m.tagsSummary)
+			slices.SortFunc(tags, func(a, b string) int {
+				return cmp.Compare(m.tagsSummary[b], m.tagsSummary[a])
+			})
+
+			for _, tag := range tags {
+				b.WriteString(fmt.Sprintf("  #%-20s %10.2f\n", tag, m.tagsSummary[tag]))
+			}
+
+			b.WriteString("\n")
+		}
+
+		if len(m.anomalies) > 0 {
+			b.WriteString("Anomalies:\n")
+
+			for _, anomaly := range m.anomalies {
+				subject := anomaly.Category
+				if anomaly.ExpenseId != 0 {
+					subject = fmt.Sprintf("expense %d", anomaly.ExpenseId)
+				}
+
+				b.WriteString(anomalyStyle.Render(fmt.Sprintf("  ! %-14s %-12s %s", anomaly.Kind, subject, anomaly.Reason)) + "\n")
+			}
+
+			b.WriteString("\n")
+		}
 	}
 
 	b.WriteString(m.helpModel.View(m.navigationKeys))
@@ -166,7 +275,8 @@ func newSummaryInfoModel() (tea.Model, error) {
 	m := summaryInfoModel{
 		inputs:         make([]textinput.Model, 2),
 		helpModel:      help.New(),
-		navigationKeys: getNavigationKeymap(),
+		navigationKeys: getSummaryKeymap(),
+		categoryDepth:  1,
 	}
 
 	var t textinput.Model
@@ -179,7 +289,7 @@ func newSummaryInfoModel() (tea.Model, error) {
 	t.Validate = validateYear
 	t.PromptStyle = focusedStyle
 	t.TextStyle = focusedStyle
-	t.SetValue(strconv.Itoa(time.Now().Year()))
+	t.SetValue(strconv.Itoa(time.Now().In(getLocation()).Year()))
 	m.inputs[0] = t
 
 	// Month input
@@ -190,7 +300,7 @@ func newSummaryInfoModel() (tea.Model, error) {
 	t.Validate = validateMonth
 	t.PromptStyle = blurredStyle
 	t.TextStyle = blurredStyle
-	t.SetValue(time.Now().Month().String())
+	t.SetValue(time.Now().In(getLocation()).Month().String())
 	m.inputs[1] = t
 
 	m.inputs[0].Focus()
@@ -198,6 +308,33 @@ func newSummaryInfoModel() (tea.Model, error) {
 	return m, nil
 }
 
+func (m summaryInfoModel) updateBreakdown() summaryInfoModel {
+	if m.year == 0 {
+		return m
+	}
+
+	breakdown, err := expense.GetMonthlyCategoryBreakdown(m.year, m.month, m.categoryDepth)
+	if err != nil {
+		m.summaryErr = fmt.Errorf("Error fetching category breakdown: %w", err)
+		return m
+	}
+
+	m.breakdown = breakdown
+	return m
+}
+
+// maxBreakdownDepth returns the deepest category level of the current breakdown.
+func (m summaryInfoModel) maxBreakdownDepth() int {
+	deepest, err := expense.GetMonthlyCategoryBreakdown(m.year, m.month, 0)
+	if err != nil {
+		return m.categoryDepth
+	}
+
+	return lo.Max(lo.Map(lo.Keys(deepest), func(category string, _ int) int {
+		return domain.CategoryDepth(category)
+	}))
+}
+
 func (m summaryInfoModel) updateInputs(msg tea.Msg) tea.Cmd {
 	cmds := make([]tea.Cmd, len(m.inputs))
 
@@ -13,12 +13,15 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/samber/lo"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var (
-	tableStyle = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240"))
+	tableStyle   = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240"))
+	anomalyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
 )
 
 type tableModel struct {
@@ -26,22 +29,33 @@ type tableModel struct {
 	help          help.Model
 	actionsKeyMap ActionKeyMap
 	expensesSum   float64
+	dateFormat    expense.DateFormat
+	location      *time.Location
+	ledger        string
 
 	allExpenses    []domain.Expense
 	expensesToShow []domain.Expense
+	anomalies      map[int]expense.Anomaly
+
+	//selection
+	selected map[int]struct{}
 
 	//filter
 	filterEnabled bool
 	filterInput   textinput.Model
+	dateFilter    *time.Time
 }
 
 func newTableModel() (tea.Model, error) {
 	columns := []table.Column{
+		{Title: " ", Width: 2},
 		{Title: "ID", Width: 4},
 		{Title: "Category", Width: 15},
-		{Title: "Description", Width: 30},
+		{Title: "Description", Width: 26},
 		{Title: "Amount", Width: 10},
-		{Title: "Date", Width: 12},
+		{Title: "Date", Width: 17},
+		{Title: "Account", Width: 22},
+		{Title: "Tags", Width: 16},
 	}
 
 	allExpenses, err := expense.GetAllExpenses()
@@ -49,29 +63,27 @@ func newTableModel() (tea.Model, error) {
 		return tableModel{}, fmt.Errorf("Error getting all expenses: %w", err)
 	}
 
+	anomalies, err := getAnomaliesById()
+	if err != nil {
+		return tableModel{}, err
+	}
+
+	dateFormat, location := getDateFormat(), getLocation()
+
 	t := table.New(
 		table.WithColumns(columns),
-		table.WithRows(lo.Map(allExpenses, getRow)),
+		table.WithRows(lo.Map(allExpenses, func(e domain.Expense, _ int) table.Row {
+			return getRow(e, false, anomalies, dateFormat, location)
+		})),
 		table.WithFocused(false),
 		table.WithHeight(7),
 	)
 
 	t.Focus()
-
-	s := table.DefaultStyles()
-	s.Header = s.Header.
-		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("240")).
-		BorderBottom(true).
-		Bold(false)
-	s.Selected = s.Selected.
-		Foreground(lipgloss.Color("229")).
-		Background(lipgloss.Color("170")).
-		Bold(false)
-	t.SetStyles(s)
+	t.SetStyles(getTableStyles())
 
 	f := textinput.New()
-	f.Placeholder = "Type to filter by category"
+	f.Placeholder = "Type to filter by category, #tag for tags, @account for accounts"
 	f.Prompt = "Filter: "
 	f.Width = 20
 	f.CharLimit = 0
@@ -84,9 +96,29 @@ func newTableModel() (tea.Model, error) {
 		filterInput:    f,
 		allExpenses:    allExpenses,
 		expensesToShow: allExpenses,
+		anomalies:      anomalies,
+		dateFormat:     dateFormat,
+		location:       location,
+		ledger:         expense.CurrentLedger(),
+		selected:       make(map[int]struct{}),
 	}, nil
 }
 
+func getTableStyles() table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("170")).
+		Bold(false)
+
+	return s
+}
+
 func (m tableModel) Init() tea.Cmd {
 	return nil
 }
@@ -107,15 +139,22 @@ func (m tableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			switch {
 			case key.Matches(msg, constants.Keymap.Delete):
-				if len(m.table.Rows()) > 0 {
-					selectedRow := m.table.SelectedRow()
+				if len(m.selected) > 0 {
+					ids := m.selectedIds()
 
-					id, err := strconv.Atoi(selectedRow[0])
+					err := expense.DeleteExpenses(ids)
 					if err != nil {
 						return m, errorCmd(err, backToTableCmd())
 					}
 
-					err = expense.DeleteExpense(id)
+					m, err = m.UpdateExpenses()
+					if err != nil {
+						return m, errorCmd(err, backToTableCmd())
+					}
+
+					return m.updateShowData(), infoCmd(fmt.Sprintf("Deleted %d expenses", len(ids)), backToTableCmd())
+				} else if id, ok := m.cursorExpenseId(); ok {
+					err := expense.DeleteExpense(id)
 					if err != nil {
 						return m, errorCmd(err, backToTableCmd())
 					}
@@ -129,17 +168,12 @@ func (m tableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, goToAddCmd()
 			case key.Matches(msg, constants.Keymap.Quit):
 				return m, tea.Quit
+			case key.Matches(msg, m.actionsKeyMap.QuickAdd):
+				return m, goToQuickAddCmd()
 			case key.Matches(msg, m.actionsKeyMap.GetSum):
 				return m, goToSummaryCmd()
 			case key.Matches(msg, constants.Keymap.Enter):
-				if len(m.table.Rows()) > 0 {
-					selectedRow := m.table.SelectedRow()
-
-					id, err := strconv.Atoi(selectedRow[0])
-					if err != nil {
-						return m, errorCmd(err, backToTableCmd())
-					}
-
+				if id, ok := m.cursorExpenseId(); ok {
 					return m, goToEditCmd(id)
 				}
 			case key.Matches(msg, m.actionsKeyMap.Filter):
@@ -154,6 +188,73 @@ func (m tableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 
 				return m, infoCmd(fmt.Sprintf("Expenses exported to %s", csv.GetSaveFilePath()), backToTableCmd())
+			case key.Matches(msg, m.actionsKeyMap.Select):
+				if id, ok := m.cursorExpenseId(); ok {
+					m.toggleSelection(id)
+				}
+
+				return m.updateShowData(), nil
+			case key.Matches(msg, m.actionsKeyMap.SelectRangeUp, m.actionsKeyMap.SelectRangeDown):
+				if id, ok := m.cursorExpenseId(); ok {
+					m.selected[id] = struct{}{}
+
+					if key.Matches(msg, m.actionsKeyMap.SelectRangeUp) {
+						m.table.MoveUp(1)
+					} else {
+						m.table.MoveDown(1)
+					}
+
+					if id, ok = m.cursorExpenseId(); ok {
+						m.selected[id] = struct{}{}
+					}
+				}
+
+				return m.updateShowData(), nil
+			case key.Matches(msg, m.actionsKeyMap.SelectAll):
+				allSelected := lo.EveryBy(m.expensesToShow, func(e domain.Expense) bool {
+					_, ok := m.selected[e.Id]
+					return ok
+				})
+
+				for _, e := range m.expensesToShow {
+					if allSelected {
+						delete(m.selected, e.Id)
+					} else {
+						m.selected[e.Id] = struct{}{}
+					}
+				}
+			case key.Matches(msg, m.actionsKeyMap.ClearSelection):
+				if len(m.selected) > 0 {
+					m.selected = make(map[int]struct{})
+				} else {
+					m.dateFilter = nil
+				}
+			case key.Matches(msg, m.actionsKeyMap.BulkEdit):
+				if len(m.selected) > 0 {
+					return m, goToBulkEditCmd(m.selectedIds())
+				}
+
+				return m, nil
+			case key.Matches(msg, m.actionsKeyMap.Categories):
+				return m, goToCategoriesCmd()
+			case key.Matches(msg, m.actionsKeyMap.Rules):
+				return m, goToRulesCmd()
+			case key.Matches(msg, m.actionsKeyMap.Calendar):
+				return m, goToCalendarCmd()
+			case key.Matches(msg, m.actionsKeyMap.Charts):
+				return m, goToChartsCmd()
+			case key.Matches(msg, m.actionsKeyMap.Comparison):
+				return m, goToComparisonCmd()
+			case key.Matches(msg, m.actionsKeyMap.Backups):
+				return m, goToBackupsCmd()
+			case key.Matches(msg, m.actionsKeyMap.Ledgers):
+				return m, goToLedgersCmd()
+			case key.Matches(msg, m.actionsKeyMap.Accounts):
+				return m, goToAccountsCmd()
+			case key.Matches(msg, m.actionsKeyMap.Members):
+				return m, goToMembersCmd()
+			case key.Matches(msg, m.actionsKeyMap.Help):
+				m.help.ShowAll = !m.help.ShowAll
 			}
 		}
 	case backMsg:
@@ -177,13 +278,29 @@ func (m tableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m tableModel) View() string {
 	var sb strings.Builder
+	sb.WriteString(titleStyle.Render("Ledger: "+m.ledger) + "\n\n")
 
 	if m.filterEnabled {
 		sb.WriteString(m.filterInput.View() + "\n\n")
 	}
 
+	if m.dateFilter != nil {
+		sb.WriteString(fmt.Sprintf("Day: %s (esc to show all)", m.dateFilter.Format("Mon, 2 Jan 2006")) + "\n")
+	}
+
 	sb.WriteString(tableStyle.Render(m.table.View() + "\n"))
 	sb.WriteString("\n" + fmt.Sprintf("Total spent: %.2f", m.expensesSum) + "\n")
+
+	if id, ok := m.cursorExpenseId(); ok {
+		if anomaly, ok := m.anomalies[id]; ok {
+			sb.WriteString(anomalyStyle.Render(fmt.Sprintf("! Unusual %s: %s", anomaly.Kind, anomaly.Reason)) + "\n")
+		}
+	}
+
+	if len(m.selected) > 0 {
+		sb.WriteString(fmt.Sprintf("Selected: %d", len(m.selected)) + "\n")
+	}
+
 	sb.WriteString(m.help.View(m.actionsKeyMap))
 
 	return sb.String()
@@ -195,29 +312,173 @@ func (m tableModel) UpdateExpenses() (tableModel, error) {
 		return m, fmt.Errorf("error getting all expenses: %w", err)
 	}
 
+	anomalies, err := getAnomaliesById()
+	if err != nil {
+		return m, err
+	}
+
 	m.allExpenses = allExpenses
+	m.anomalies = anomalies
+	m.dateFormat = getDateFormat()
+	m.location = getLocation()
+	m.ledger = expense.CurrentLedger()
+
+	existing := lo.SliceToMap(allExpenses, func(e domain.Expense) (int, struct{}) {
+		return e.Id, struct{}{}
+	})
+
+	for id := range m.selected {
+		if _, ok := existing[id]; !ok {
+			delete(m.selected, id)
+		}
+	}
+
 	return m, nil
 }
 
 func (m tableModel) updateShowData() tableModel {
+	expenses := m.allExpenses
+
+	if m.dateFilter != nil {
+		expenses = lo.Filter(expenses, func(expense domain.Expense, _ int) bool {
+			return expense.IsOnDate(*m.dateFilter, m.location)
+		})
+	}
+
+	var categoryFilter string
+	var tagFilters, accountFilters []string
+
 	if m.filterEnabled {
-		filterVal := m.filterInput.Value()
-		filtered := lo.Filter(m.allExpenses, func(expense domain.Expense, _ int) bool {
-			return strings.HasPrefix(strings.ToLower(expense.Category), strings.ToLower(filterVal))
+		categoryFilter, tagFilters, accountFilters = parseFilter(m.filterInput.Value())
+		filtered := lo.Filter(expenses, func(expense domain.Expense, _ int) bool {
+			if !lo.SomeBy(expense.Categories(), func(category string) bool {
+				return matchesCategoryFilter(category, categoryFilter)
+			}) {
+				return false
+			}
+
+			if !lo.EveryBy(accountFilters, func(accountFilter string) bool {
+				return matchesAccountFilter(expense, accountFilter)
+			}) {
+				return false
+			}
+
+			return lo.EveryBy(tagFilters, func(tagFilter string) bool {
+				return lo.SomeBy(expense.Tags, func(tag string) bool {
+					return strings.HasPrefix(tag, tagFilter)
+				})
+			})
 		})
 		m.expensesToShow = filtered
 	} else {
-		m.expensesToShow = m.allExpenses
+		m.expensesToShow = expenses
 	}
 
-	m.expensesSum = lo.SumBy(m.expensesToShow, func(expense domain.Expense) float64 {
-		return expense.Amount
+	// only the split lines within the filtered category count towards the total
+	m.expensesSum = lo.SumBy(domain.SplitParts(domain.Spending(m.expensesToShow)), func(part domain.Expense) float64 {
+		if !matchesCategoryFilter(part.Category, categoryFilter) {
+			return 0
+		}
+
+		return part.Amount
 	})
-	m.table.SetRows(lo.Map(m.expensesToShow, getRow))
+	m.table.SetRows(lo.Map(m.expensesToShow, func(e domain.Expense, _ int) table.Row {
+		_, selected := m.selected[e.Id]
+		return getRow(e, selected, m.anomalies, m.dateFormat, m.location)
+	}))
 
 	return m
 }
 
+func (m tableModel) cursorExpenseId() (int, bool) {
+	cursor := m.table.Cursor()
+
+	if cursor < 0 || cursor >= len(m.expensesToShow) {
+		return 0, false
+	}
+
+	return m.expensesToShow[cursor].Id, true
+}
+
+func (m tableModel) toggleSelection(id int) {
+	if _, ok := m.selected[id]; ok {
+		delete(m.selected, id)
+	} else {
+		m.selected[id] = struct{}{}
+	}
+}
+
+func (m tableModel) selectedIds() []int {
+	ids := lo.Keys(m.selected)
+	slices.Sort(ids)
+	return ids
+}
+
+// parseFilter splits the filter input into a category prefix, tag prefixes marked with '#'
+// and account prefixes marked with '@'.
+func parseFilter(value string) (string, []string, []string) {
+	var category []string
+	var tags []string
+	var accounts []string
+
+	for _, field := range strings.Fields(value) {
+		if tag, ok := strings.CutPrefix(field, "#"); ok {
+			tags = append(tags, domain.NormalizeTag(tag))
+		} else if account, ok := strings.CutPrefix(field, "@"); ok {
+			accounts = append(accounts, strings.ToLower(account))
+		} else {
+			category = append(category, field)
+		}
+	}
+
+	return strings.Join(category, " "), tags, accounts
+}
+
+// matchesAccountFilter reports whether the expense was paid from, or transferred into, an account whose name
+// begins with the filter. Spaces of the name can be left out, so "@debit" and "@debitcard" match "Debit card".
+func matchesAccountFilter(expense domain.Expense, filter string) bool {
+	accounts := []string{expense.Account}
+	if expense.IsTransfer() {
+		accounts = append(accounts, expense.ToAccount)
+	}
+
+	return lo.SomeBy(accounts, func(account string) bool {
+		account = strings.ToLower(account)
+		return account != "" && (strings.HasPrefix(account, filter) || strings.HasPrefix(strings.ReplaceAll(account, " ", ""), filter))
+	})
+}
+
+// matchesCategoryFilter reports whether the category, or its part starting at any level, begins with the filter,
+// so both "Food" and "Groceries" match "Food:Groceries".
+func matchesCategoryFilter(category string, filter string) bool {
+	filter = strings.ToLower(filter)
+	levels := domain.CategoryLevels(strings.ToLower(category))
+
+	if len(levels) == 0 {
+		return strings.HasPrefix(strings.ToLower(category), filter)
+	}
+
+	for i := range levels {
+		if strings.HasPrefix(strings.Join(levels[i:], domain.CategorySeparator), filter) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getAnomaliesById returns the unusual expenses keyed by their ids.
+func getAnomaliesById() (map[int]expense.Anomaly, error) {
+	anomalies, err := expense.GetExpenseAnomalies()
+	if err != nil {
+		return nil, fmt.Errorf("Error finding unusual expenses: %w", err)
+	}
+
+	return lo.KeyBy(anomalies, func(a expense.Anomaly) int {
+		return a.ExpenseId
+	}), nil
+}
+
 func getStringRows(expenses []domain.Expense) [][]string {
 	return lo.Map(expenses, func(expense domain.Expense, _ int) []string {
 		return []string{
@@ -226,16 +487,40 @@ func getStringRows(expenses []domain.Expense) [][]string {
 			expense.Description,
 			fmt.Sprintf("%.2f", expense.Amount),
 			expense.SpentAt.Format("2006.01.02"),
+			strings.Join(expense.Tags, ","),
+			getAccountColumn(expense),
 		}
 	})
 }
 
-func getRow(expense domain.Expense, _ int) table.Row {
+func getRow(expense domain.Expense, selected bool, anomalies map[int]expense.Anomaly, dateFormat expense.DateFormat, location *time.Location) table.Row {
+	mark := " "
+	if selected {
+		mark = "✓"
+	}
+
+	if _, ok := anomalies[expense.Id]; ok {
+		mark += "!"
+	}
+
+	category := expense.Category
+	if expense.IsTransfer() {
+		category = "transfer"
+	} else if expense.IsSettlement() {
+		category = "settlement"
+	} else if expense.IsSplit() {
+		// the count of split lines comes first so it survives the truncation of long categories
+		category = fmt.Sprintf("[%d] %s", len(expense.Splits), category)
+	}
+
 	return table.Row{
+		mark,
 		strconv.Itoa(expense.Id),
-		expense.Category,
+		category,
 		expense.Description,
 		fmt.Sprintf("%.2f", expense.Amount),
-		expense.SpentAt.Format("2006-01-02"),
+		dateFormat.Format(expense.SpentAt, location),
+		getAccountColumn(expense),
+		strings.Join(expense.Tags, ", "),
 	}
 }
@@ -0,0 +1,234 @@
+package menu
+
+import (
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/infrastructure/menu/constants"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const transferFormTitle = "Transfer Between Accounts"
+
+type transferFormModel struct {
+	focusIndex int
+	inputs     []textinput.Model
+	editingId  *int
+	backCmd    tea.Cmd
+
+	//dates
+	dateFormat expense.DateFormat
+	location   *time.Location
+
+	//help
+	helpModel      help.Model
+	navigationKeys NavigationKeyMap
+}
+
+func (m transferFormModel) Init() tea.Cmd { return nil }
+
+func (m transferFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case canAcceptSuggestion(m.inputs, m.focusIndex) && key.Matches(msg, m.inputs[m.focusIndex].KeyMap.AcceptSuggestion):
+			// let the focused input complete its suggestion instead of moving the focus
+		case key.Matches(msg, constants.Keymap.Enter, constants.Keymap.Up, constants.Keymap.Down):
+			if key.Matches(msg, constants.Keymap.Enter) {
+				if m.focusIndex == len(m.inputs) {
+					hasError := false
+
+					for i := range m.inputs {
+						err := m.inputs[i].Err
+
+						if err != nil {
+							m.inputs[i].SetValue(err.Error())
+							hasError = true
+						}
+					}
+
+					if hasError {
+						return m, nil
+					}
+
+					amount, err := strconv.ParseFloat(m.inputs[2].Value(), 64)
+					if err != nil {
+						return m, errorCmd(err, m.backCmd)
+					}
+
+					date, err := expense.ParseDateTime(m.inputs[3].Value(), m.dateFormat)
+					if err != nil {
+						return m, errorCmd(err, m.backCmd)
+					}
+
+					from, to, note := m.inputs[0].Value(), m.inputs[1].Value(), m.inputs[4].Value()
+
+					if m.editingId == nil {
+						_, err = expense.AddTransfer(date, from, to, amount, note)
+					} else {
+						_, err = expense.UpdateTransfer(*m.editingId, date, from, to, amount, note)
+					}
+
+					if err != nil {
+						return m, errorCmd(err, m.backCmd)
+					}
+
+					return m, m.backCmd
+				} else {
+					m.focusIndex++
+				}
+			} else {
+				if key.Matches(msg, constants.Keymap.Up) {
+					m.focusIndex--
+				} else if key.Matches(msg, constants.Keymap.Down) {
+					m.focusIndex++
+				}
+			}
+
+			if m.focusIndex > len(m.inputs) {
+				m.focusIndex = 0
+			} else if m.focusIndex < 0 {
+				m.focusIndex = len(m.inputs)
+			}
+
+			cmds := make([]tea.Cmd, len(m.inputs))
+
+			for i := 0; i < len(m.inputs); i++ {
+				if i == m.focusIndex {
+					// Set focused state
+					cmds[i] = m.inputs[i].Focus()
+					m.inputs[i].PromptStyle = focusedStyle
+					m.inputs[i].TextStyle = focusedStyle
+				} else {
+					// Remove focused state
+					m.inputs[i].Blur()
+					m.inputs[i].PromptStyle = blurredStyle
+					m.inputs[i].TextStyle = blurredStyle
+				}
+			}
+
+			return m, tea.Batch(cmds...)
+		case key.Matches(msg, constants.Keymap.Back):
+			return m, m.backCmd
+		}
+	}
+
+	cmds := make([]tea.Cmd, len(m.inputs))
+
+	for i := range m.inputs {
+		m.inputs[i], cmds[i] = m.inputs[i].Update(msg)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m transferFormModel) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(transferFormTitle) + "\n\n")
+
+	for i := range m.inputs {
+		b.WriteString(m.inputs[i].View())
+
+		if i == 3 {
+			if date, err := expense.ParseDateTime(m.inputs[3].Value(), m.dateFormat); err == nil {
+				if domain.IsDateOnly(date) {
+					b.WriteString(blurredStyle.Render(date.Format("Mon, 2 Jan 2006")))
+				} else {
+					b.WriteString(blurredStyle.Render(date.In(m.location).Format("Mon, 2 Jan 2006 15:04 MST")))
+				}
+			}
+		}
+
+		if i < len(m.inputs)-1 {
+			b.WriteRune('\n')
+		}
+	}
+
+	button := &blurredButton
+	if m.focusIndex == len(m.inputs) {
+		button = &focusedButton
+	}
+
+	b.WriteString("\n\nTransfers change the balances of both accounts and are not counted as spending")
+	fmt.Fprintf(&b, "\n\n%s\n\n", *button)
+	b.WriteString(m.helpModel.View(m.navigationKeys))
+
+	return b.String()
+}
+
+// newTransferFormModel creates a form editing the given transfer, or adding a new one when it is nil.
+// backCmd leaves the form.
+func newTransferFormModel(transfer *domain.Expense, backCmd tea.Cmd) (tea.Model, error) {
+	accounts, err := expense.GetAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting accounts: %w", err)
+	}
+
+	dateFormat := getDateFormat()
+
+	m := transferFormModel{
+		inputs:         make([]textinput.Model, 5),
+		backCmd:        backCmd,
+		dateFormat:     dateFormat,
+		location:       getLocation(),
+		helpModel:      help.New(),
+		navigationKeys: getNavigationKeymap(),
+	}
+
+	var t textinput.Model
+	for i := range m.inputs {
+		t = textinput.New()
+		t.Width = 100
+		t.CharLimit = 0
+		t.PromptStyle = blurredStyle
+		t.TextStyle = blurredStyle
+
+		switch i {
+		case 0:
+			t.Placeholder = "From account"
+			t.ShowSuggestions = true
+			t.SetSuggestions(getAccountNames(accounts))
+			t.Validate = validateAccount(accounts, false)
+			t.Focus()
+			t.PromptStyle = focusedStyle
+			t.TextStyle = focusedStyle
+		case 1:
+			t.Placeholder = "To account"
+			t.ShowSuggestions = true
+			t.SetSuggestions(getAccountNames(accounts))
+			t.Validate = validateAccount(accounts, false)
+		case 2:
+			t.Placeholder = "Amount"
+			t.SetValue("0")
+			t.Validate = validateAmount
+		case 3:
+			t.Placeholder = dateFormat.Hint()
+			t.Validate = validateDate(dateFormat)
+			t.Width = 36
+			t.SetValue(time.Now().In(m.location).Format(dateFormat.Layout()))
+		case 4:
+			t.Placeholder = "Note, e.g. ATM withdrawal"
+		}
+
+		m.inputs[i] = t
+	}
+
+	if transfer != nil {
+		m.inputs[0].SetValue(transfer.Account)
+		m.inputs[1].SetValue(transfer.ToAccount)
+		m.inputs[2].SetValue(fmt.Sprintf("%.2f", transfer.Amount))
+		m.inputs[3].SetValue(dateFormat.Format(transfer.SpentAt, m.location))
+		m.inputs[4].SetValue(transfer.Description)
+
+		id := transfer.Id
+		m.editingId = &id
+	}
+
+	return m, nil
+}
@@ -0,0 +1,97 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/application/expense"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"strings"
+)
+
+const unlockTitle = "Encrypted Expenses"
+
+type unlockModel struct {
+	input     textinput.Model
+	unlockErr error
+	backCmd   tea.Cmd
+
+	//help
+	helpModel help.Model
+	keys      UnlockKeyMap
+}
+
+// newUnlockModel asks for the passphrase of the current ledger. backCmd leaves the prompt, at startup there
+// is nowhere to go back to and it is nil.
+func newUnlockModel(backCmd tea.Cmd) unlockModel {
+	input := textinput.New()
+	input.Prompt = "Passphrase: "
+	input.EchoMode = textinput.EchoPassword
+	input.EchoCharacter = '•'
+	input.Width = 50
+	input.CharLimit = 0
+	input.PromptStyle = focusedStyle
+	input.TextStyle = focusedStyle
+	input.Focus()
+
+	keys := getUnlockKeymap()
+	keys.Back.SetEnabled(backCmd != nil)
+
+	return unlockModel{
+		input:     input,
+		backCmd:   backCmd,
+		helpModel: help.New(),
+		keys:      keys,
+	}
+}
+
+func (m unlockModel) Init() tea.Cmd { return nil }
+
+func (m unlockModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(keyMsg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(keyMsg, m.keys.Back):
+			return m, m.backCmd
+		case key.Matches(keyMsg, m.keys.Unlock):
+			if m.input.Value() == "" {
+				return m, nil
+			}
+
+			err := expense.Unlock(m.input.Value())
+			if errors.Is(err, expense.ErrWrongPassphrase) {
+				m.unlockErr = err
+				m.input.Reset()
+				return m, nil
+			}
+
+			if err != nil {
+				return m, errorCmd(err, tea.Quit)
+			}
+
+			return m, unlockedCmd()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m unlockModel) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(unlockTitle) + "\n\n")
+	fmt.Fprintf(&b, "The expenses of the %s ledger are encrypted, enter the passphrase to open them\n\n", expense.CurrentLedger())
+	b.WriteString(m.input.View() + "\n\n")
+
+	if m.unlockErr != nil {
+		b.WriteString(m.unlockErr.Error() + ", try again\n\n")
+	}
+
+	b.WriteString(m.helpModel.View(m.keys))
+
+	return b.String()
+}
@@ -0,0 +1,304 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	_ "modernc.org/sqlite"
+	"strings"
+	"time"
+)
+
+// FileName is the database file kept in the application directory.
+const FileName = "expenses.db"
+
+const schema = `
+CREATE TABLE IF NOT EXISTS expenses (
+	id          INTEGER PRIMARY KEY,
+	spent_at    TEXT NOT NULL,
+	description TEXT NOT NULL,
+	category    TEXT NOT NULL,
+	amount      REAL NOT NULL,
+	tags        TEXT NOT NULL DEFAULT '[]'
+);
+CREATE INDEX IF NOT EXISTS expenses_spent_at ON expenses (spent_at);
+CREATE INDEX IF NOT EXISTS expenses_category ON expenses (category);
+`
+
+// upgrades bring a database written by an older version up to date. The upgrade at index i moves
+// it from user_version i to i+1.
+var upgrades = []string{
+	`ALTER TABLE expenses ADD COLUMN account TEXT NOT NULL DEFAULT '';
+ALTER TABLE expenses ADD COLUMN kind TEXT NOT NULL DEFAULT '';
+ALTER TABLE expenses ADD COLUMN to_account TEXT NOT NULL DEFAULT '';`,
+	`ALTER TABLE expenses ADD COLUMN splits TEXT NOT NULL DEFAULT '[]';`,
+	`ALTER TABLE expenses ADD COLUMN sharing TEXT NOT NULL DEFAULT 'null';`,
+}
+
+// ExpenseStorage keeps the expenses in a SQLite database. The database is opened for every call,
+// the same way the JSON file is, so no connection has to be managed by the callers.
+type ExpenseStorage struct {
+	path string
+}
+
+func NewExpenseStorage(path string) *ExpenseStorage {
+	return &ExpenseStorage{path: path}
+}
+
+func (s *ExpenseStorage) Get(id int) (domain.Expense, bool, error) {
+	expenses, err := s.Query(domain.ExpenseFilter{Ids: []int{id}})
+	if err != nil || len(expenses) == 0 {
+		return domain.Expense{}, false, err
+	}
+
+	return expenses[0], true, nil
+}
+
+const insertStatement = `INSERT INTO expenses (spent_at, description, category, amount, tags, account, kind, to_account, splits, sharing, id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+func (s *ExpenseStorage) Insert(expenses ...domain.Expense) error {
+	return s.write(insertStatement, expenses)
+}
+
+func (s *ExpenseStorage) Update(expenses ...domain.Expense) error {
+	return s.write(`UPDATE expenses SET spent_at = ?, description = ?, category = ?, amount = ?, tags = ?, account = ?, kind = ?, to_account = ?, splits = ?, sharing = ? WHERE id = ?`, expenses)
+}
+
+func (s *ExpenseStorage) Delete(ids ...int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	db, err := open(s.path)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	_, err = db.Exec(`DELETE FROM expenses WHERE id IN (`+placeholders(len(ids))+`)`, toArgs(ids)...)
+	return err
+}
+
+// Replace empties the table and inserts the expenses in one transaction.
+func (s *ExpenseStorage) Replace(expenses ...domain.Expense) error {
+	return s.transaction(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`DELETE FROM expenses`); err != nil {
+			return err
+		}
+
+		return writeAll(tx, insertStatement, expenses)
+	})
+}
+
+// Query narrows the expenses down by id in the database. The categories and the date range are checked
+// afterwards: categories are compared level by level once trimmed, which older expenses may not be, split
+// lines carry their own, and expenses without a time of day have to be compared by their date in the
+// filter location.
+func (s *ExpenseStorage) Query(filter domain.ExpenseFilter) ([]domain.Expense, error) {
+	db, err := open(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.Close()
+
+	query := `SELECT id, spent_at, description, category, amount, tags, account, kind, to_account, splits, sharing FROM expenses WHERE 1 = 1`
+	args := make([]any, 0)
+
+	if filter.Ids != nil {
+		query += ` AND id IN (` + placeholders(len(filter.Ids)) + `)`
+		args = append(args, toArgs(filter.Ids)...)
+	}
+
+	rows, err := db.Query(query+` ORDER BY id`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	expenses := make([]domain.Expense, 0)
+
+	for rows.Next() {
+		var e domain.Expense
+		var spentAt, tags, splits, sharing string
+
+		if err := rows.Scan(&e.Id, &spentAt, &e.Description, &e.Category, &e.Amount, &tags, &e.Account, &e.Kind, &e.ToAccount, &splits, &sharing); err != nil {
+			return nil, err
+		}
+
+		if e.SpentAt, err = time.Parse(time.RFC3339Nano, spentAt); err != nil {
+			return nil, fmt.Errorf("Error reading the date of expense %d: %w", e.Id, err)
+		}
+
+		if err := json.Unmarshal([]byte(tags), &e.Tags); err != nil {
+			return nil, fmt.Errorf("Error reading the tags of expense %d: %w", e.Id, err)
+		}
+
+		if err := json.Unmarshal([]byte(splits), &e.Splits); err != nil {
+			return nil, fmt.Errorf("Error reading the split lines of expense %d: %w", e.Id, err)
+		}
+
+		if err := json.Unmarshal([]byte(sharing), &e.Sharing); err != nil {
+			return nil, fmt.Errorf("Error reading the sharing of expense %d: %w", e.Id, err)
+		}
+
+		if filter.Matches(e) {
+			expenses = append(expenses, e)
+		}
+	}
+
+	return expenses, rows.Err()
+}
+
+func (s *ExpenseStorage) NextID() (int, error) {
+	db, err := open(s.path)
+	if err != nil {
+		return 0, err
+	}
+
+	defer db.Close()
+
+	var id int
+	err = db.QueryRow(`SELECT COALESCE(MAX(id), 0) + 1 FROM expenses`).Scan(&id)
+	return id, err
+}
+
+// write runs statement for every expense in one transaction.
+func (s *ExpenseStorage) write(statement string, expenses []domain.Expense) error {
+	if len(expenses) == 0 {
+		return nil
+	}
+
+	return s.transaction(func(tx *sql.Tx) error {
+		return writeAll(tx, statement, expenses)
+	})
+}
+
+// transaction runs fn in a transaction that is committed when fn succeeds and rolled back otherwise.
+func (s *ExpenseStorage) transaction(fn func(tx *sql.Tx) error) error {
+	db, err := open(s.path)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// writeAll runs statement for every expense. The statement takes the fields in the order
+// spent_at, description, category, amount, tags, account, kind, to_account, splits, sharing and id.
+func writeAll(tx *sql.Tx, statement string, expenses []domain.Expense) error {
+	if len(expenses) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.Prepare(statement)
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	for _, e := range expenses {
+		tags, err := json.Marshal(e.Tags)
+		if err != nil {
+			return err
+		}
+
+		splits, err := json.Marshal(e.Splits)
+		if err != nil {
+			return err
+		}
+
+		sharing, err := json.Marshal(e.Sharing)
+		if err != nil {
+			return err
+		}
+
+		_, err = stmt.Exec(e.SpentAt.Format(time.RFC3339Nano), e.Description, e.Category, e.Amount, string(tags), e.Account, e.Kind, e.ToAccount, string(splits), string(sharing), e.Id)
+		if err != nil {
+			return fmt.Errorf("Error saving expense %d: %w", e.Id, err)
+		}
+	}
+
+	return nil
+}
+
+// open connects to the database at path, creating it and its schema or upgrading an older one when needed.
+func open(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("Error preparing database %s: %w", path, err)
+	}
+
+	if err := upgrade(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("Error upgrading database %s: %w", path, err)
+	}
+
+	return db, nil
+}
+
+// upgrade runs the upgrades the database has not seen yet, each in its own transaction.
+func upgrade(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+
+	for ; version < len(upgrades); version++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.Exec(upgrades[version]); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if _, err = tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, version+1)); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func toArgs(ids []int) []any {
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	return args
+}
@@ -0,0 +1,126 @@
+package sqlite
+
+import (
+	"database/sql"
+	"github.com/Lexv0lk/expense-tracker-tui/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExpenseStorage(t *testing.T) {
+	t.Parallel()
+
+	storage := NewExpenseStorage(filepath.Join(t.TempDir(), FileName))
+
+	loaded, err := storage.Query(domain.ExpenseFilter{})
+	assert.NoError(t, err)
+	assert.Empty(t, loaded)
+
+	id, err := storage.NextID()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+
+	expenses := []domain.Expense{
+		{Id: 1, Description: "Coffee", Category: "Food", Amount: 3.5, SpentAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Id: 2, Description: "Taxi", Category: "Transport", Amount: 12, SpentAt: time.Date(2024, 1, 2, 21, 30, 0, 0, time.FixedZone("", 2*60*60)), Tags: []string{"work"}},
+		{Id: 3, Description: "Bread", Category: "Food:Groceries", Amount: 2, SpentAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Account: "Cash"},
+		{Id: 4, Description: "Savings", Amount: 100, SpentAt: time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC), Account: "Debit card", Kind: domain.TransferKind, ToAccount: "Cash"},
+		{Id: 5, Description: "Supermarket", Category: "Household", Amount: 30, SpentAt: time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC), Splits: []domain.Split{
+			{Category: "Household", Amount: 20, Note: "detergent"},
+			{Category: "Food:Groceries", Amount: 10},
+		}, Sharing: &domain.Sharing{PaidBy: "Alice", Method: domain.WeightedShare, Participants: []domain.Participant{
+			{Name: "Alice", Share: 2},
+			{Name: "Bob", Share: 1},
+		}}},
+	}
+
+	assert.NoError(t, storage.Insert(expenses...))
+
+	loaded, err = storage.Query(domain.ExpenseFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, loaded, 5)
+	assert.Equal(t, expenses[0], loaded[0])
+	assert.Equal(t, expenses[3], loaded[3])
+	assert.Equal(t, expenses[4], loaded[4])
+	assert.True(t, expenses[1].SpentAt.Equal(loaded[1].SpentAt))
+	assert.Equal(t, expenses[1].Tags, loaded[1].Tags)
+
+	id, err = storage.NextID()
+	assert.NoError(t, err)
+	assert.Equal(t, 6, id)
+
+	loaded, err = storage.Query(domain.ExpenseFilter{Category: "Food"})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Expense{expenses[0], expenses[2], expenses[4]}, loaded)
+
+	loaded, err = storage.Query(domain.ExpenseFilter{Account: "cash"})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Expense{expenses[2], expenses[3]}, loaded)
+
+	loaded, err = storage.Query(domain.MonthFilter(2024, time.January, time.UTC))
+	assert.NoError(t, err)
+	assert.Len(t, loaded, 2)
+
+	updated := expenses[0]
+	updated.Amount = 4
+	assert.NoError(t, storage.Update(updated))
+
+	found, ok, err := storage.Get(1)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, updated, found)
+
+	assert.NoError(t, storage.Delete(1, 3, 4, 5))
+
+	_, ok, err = storage.Get(1)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	loaded, err = storage.Query(domain.ExpenseFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, loaded, 1)
+	assert.Equal(t, 2, loaded[0].Id)
+
+	assert.NoError(t, storage.Replace(expenses[0], expenses[2]))
+
+	loaded, err = storage.Query(domain.ExpenseFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Expense{expenses[0], expenses[2]}, loaded)
+
+	// a failed replacement leaves the expenses as they were
+	assert.Error(t, storage.Replace(expenses[3], expenses[3]))
+
+	loaded, err = storage.Query(domain.ExpenseFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Expense{expenses[0], expenses[2]}, loaded)
+}
+
+func TestUpgrade(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), FileName)
+
+	db, err := sql.Open("sqlite", path)
+	assert.NoError(t, err)
+
+	_, err = db.Exec(schema + `INSERT INTO expenses (id, spent_at, description, category, amount) VALUES (1, '2024-01-01T00:00:00Z', 'Coffee', 'Food', 3.5);`)
+	assert.NoError(t, err)
+	assert.NoError(t, db.Close())
+
+	storage := NewExpenseStorage(path)
+
+	loaded, err := storage.Query(domain.ExpenseFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Expense{
+		{Id: 1, Description: "Coffee", Category: "Food", Amount: 3.5, SpentAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Tags: []string{}, Splits: []domain.Split{}},
+	}, loaded)
+
+	loaded[0].Account = "Cash"
+	assert.NoError(t, storage.Update(loaded[0]))
+
+	found, _, err := storage.Get(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Cash", found.Account)
+}